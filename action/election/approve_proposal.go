@@ -0,0 +1,107 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// ApproveProposal accepts a pending proposal in an election that moderates
+// proposals, so it is listed and may be ranked on a ballot. Proposals cannot be
+// approved once voting has started. Only the election organizer, or an admin, may
+// moderate proposals.
+type ApproveProposal struct {
+	ElectionID string
+	ProposalID string
+}
+
+type approveProposalHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewApproveProposalHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *approveProposalHandler {
+	return &approveProposalHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *approveProposalHandler) Verify(ctx authorization.Context, cmd ApproveProposal) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *approveProposalHandler) On(ctx context.Context, cmd ApproveProposal, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.approve-proposal")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	proposal, err := getPendingProposal(ctx, h.repository, cmd.ElectionID, cmd.ProposalID)
+	if err != nil {
+		return err
+	}
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if election.VotingStartsAt > 0 && occurredAt >= election.VotingStartsAt {
+		return ErrVotingStarted
+	}
+
+	proposal.ModerationStatus = electionrepository.ModerationStatusApproved
+	proposal.ModeratedAt = occurredAt
+
+	err = h.repository.ValidateProposalUpdate(ctx, proposal)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ProposalWasApproved{
+		ElectionID: cmd.ElectionID,
+		ProposalID: cmd.ProposalID,
+		OccurredAt: occurredAt,
+	})
+}
+
+// getPendingProposal returns a proposal in electionID that is awaiting
+// moderation.
+func getPendingProposal(ctx context.Context, repository electionrepository.Repository, electionID, proposalID string) (electionrepository.Proposal, error) {
+	proposal, err := repository.GetProposal(ctx, proposalID)
+	if err != nil {
+		return electionrepository.Proposal{}, err
+	}
+
+	if proposal.ElectionID != electionID {
+		return electionrepository.Proposal{}, electionrepository.NewErrInvalidElectionProposal(proposalID, electionID)
+	}
+
+	if proposal.IsWithdrawn {
+		return electionrepository.Proposal{}, electionrepository.NewErrProposalWithdrawn(proposalID)
+	}
+
+	if proposal.ModerationStatus != electionrepository.ModerationStatusPending {
+		return electionrepository.Proposal{}, ErrProposalNotPending
+	}
+
+	return proposal, nil
+}
+
+var ErrProposalNotPending = errors.New("proposal is not awaiting moderation")
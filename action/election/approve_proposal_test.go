@@ -0,0 +1,157 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestApproveProposal(t *testing.T) {
+	const (
+		electionID  = "e5f6a7b8-c9d0-4e1f-8a2b-4c5d6e7f8a9b"
+		proposalID  = "f6a7b8c9-d0e1-4f2a-9b3c-5d6e7f8a9b0c"
+		ownerUserID = "a7b8c9d0-e1f2-4a3b-8c4d-6e7f8a9b0c1d"
+	)
+
+	seedPendingProposal := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, election1 electionrepository.Election) {
+		require.NoError(t, repository.SaveElection(ctx, election1))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       proposalID,
+			OwnerUserID:      ownerUserID,
+			ModerationStatus: electionrepository.ModerationStatusPending,
+		}))
+	}
+
+	t.Run("approves pending proposal so it is listed and may be ranked", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedPendingProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			ModerateProposals: true,
+		})
+		command := election.ApproveProposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ProposalWasApproved{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		totalProposals, proposals, err := app.ElectionRepository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalProposals)
+		assert.Equal(t, electionrepository.ModerationStatusApproved, proposals[0].ModerationStatus)
+
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "b8c9d0e1-f2a3-4b4c-9d5e-7f8a9b0c1d2e",
+			ElectionID:        electionID,
+			UserID:            ownerUserID,
+			RankedProposalIDs: []string{proposalID},
+		}))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not the election organizer", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedPendingProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   ownerUserID,
+				ModerateProposals: true,
+			})
+			command := election.ApproveProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when proposal is not pending", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedPendingProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   app.RegularUserID,
+				ModerateProposals: true,
+			})
+			command := election.ApproveProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			}
+			_, err := app.ExecuteCommand(ctx, command)
+			require.NoError(t, err)
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrProposalNotPending, err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+
+		t.Run("when voting has started", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedPendingProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   app.RegularUserID,
+				ModerateProposals: true,
+				VotingStartsAt:    1,
+			})
+			const lateProposalID = "c9d0e1f2-a3b4-4c5d-8e6f-8a9b0c1d2e3f"
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:       electionID,
+				ProposalID:       lateProposalID,
+				OwnerUserID:      ownerUserID,
+				ModerationStatus: electionrepository.ModerationStatusPending,
+			}))
+			_, err := app.ExecuteCommand(ctx, election.ApproveProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			})
+			require.NoError(t, err)
+			command := election.ApproveProposal{
+				ElectionID: electionID,
+				ProposalID: lateProposalID,
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrVotingStarted, err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package election
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+const ballotKeyBits = 2048
+
+// saveNewBallotKey generates the RSA key used to blind-sign the ballot tokens of
+// a secret-ballot election. The private key never leaves the repository.
+func saveNewBallotKey(ctx context.Context, repository electionrepository.Repository, electionID string) error {
+	privateKey, err := rsa.GenerateKey(rand.Reader, ballotKeyBits)
+	if err != nil {
+		return fmt.Errorf("unable to generate ballot key: %w", err)
+	}
+
+	encodedKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("unable to encode ballot key: %w", err)
+	}
+
+	return repository.SaveBallotKey(ctx, electionID, encodedKey)
+}
+
+func getBallotKey(ctx context.Context, repository electionrepository.Repository, electionID string) (*rsa.PrivateKey, error) {
+	encodedKey, err := repository.GetBallotKey(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode ballot key: %w", err)
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ballot key type %T", key)
+	}
+
+	return privateKey, nil
+}
+
+// verifySecretBallotElection rejects ballot token requests for elections that do
+// not use secret ballots, and those outside the voting window.
+func verifySecretBallotElection(election electionrepository.Election, occurredAt int) error {
+	if election.BallotSecrecy != electionrepository.BallotSecrecySecret {
+		return ErrNotSecretBallot
+	}
+
+	if election.IsClosed {
+		return electionrepository.NewErrElectionClosed(election.ElectionID)
+	}
+
+	if election.VotingStartsAt > 0 && occurredAt < election.VotingStartsAt {
+		return ErrVotingNotStarted
+	}
+
+	if election.VotingEndsAt > 0 && occurredAt >= election.VotingEndsAt {
+		return ErrVotingEnded
+	}
+
+	return nil
+}
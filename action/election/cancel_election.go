@@ -0,0 +1,69 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// CancelElection closes an election that was abandoned or set up by mistake,
+// without selecting a winner. Unlike CloseElectionByOwner, an election may be
+// cancelled before any votes are cast. Its proposals and ballots are kept, but it
+// is no longer listed by ListOpenElections and no longer accepts proposals or
+// ballots. Only the election organizer or an admin may cancel an election.
+type CancelElection struct {
+	ElectionID string
+	Reason     string
+}
+
+type cancelElectionHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewCancelElectionHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *cancelElectionHandler {
+	return &cancelElectionHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *cancelElectionHandler) Verify(ctx authorization.Context, cmd CancelElection) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *cancelElectionHandler) On(ctx context.Context, cmd CancelElection, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.cancel-election")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if election.IsClosed {
+		return electionrepository.NewErrElectionClosed(cmd.ElectionID)
+	}
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ElectionWasCancelled{
+		ElectionID: cmd.ElectionID,
+		Reason:     cmd.Reason,
+		OccurredAt: occurredAt,
+	})
+}
@@ -0,0 +1,123 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestCancelElection(t *testing.T) {
+	const (
+		electionID  = "3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f"
+		proposalID  = "4d5e6f7a-8b9c-4d0e-9f1a-2b3c4d5e6f7a"
+		otherUserID = "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b"
+	)
+
+	seedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, organizerUserID string) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+			Name:            "Election Name",
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: otherUserID,
+		}))
+	}
+
+	t.Run("cancels election without votes and keeps its data", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		command := election.CancelElection{
+			ElectionID: electionID,
+			Reason:     "Wrong voting method",
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ElectionWasCancelled{
+			ElectionID: electionID,
+			Reason:     "Wrong voting method",
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.True(t, actualElection.IsClosed)
+		assert.True(t, actualElection.IsCancelled)
+		assert.Equal(t, "Wrong voting method", actualElection.CancellationReason)
+		assert.Equal(t, "", actualElection.WinningProposalID)
+
+		_, err = app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+
+		listResponse, err := app.ExecuteQuery(ctx, election.ListOpenElections{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, listResponse.(election.ListOpenElectionsResponse).TotalResults)
+
+		err = app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8b9c",
+			ElectionID:        electionID,
+			UserID:            otherUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.Equal(t, electionrepository.NewErrElectionClosed(electionID), err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user does not organize election", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, otherUserID)
+			command := election.CancelElection{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election is already closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+				IsClosed:        true,
+			}))
+			command := election.CancelElection{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionClosed(electionID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package election
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/blindsig"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/pkg/sleep"
+)
+
+// CastSecretBallot casts an anonymous ballot in an election with secret ballots.
+// Token is the random ballot token chosen by the voter, and Signature is its unblinded
+// signature from IssueBallotToken, both base64 encoded. The request is not authenticated,
+// and should be sent without a bearer token: the ballot token alone authorizes it, and
+// each token may be spent once. The ballot is stored apart from the votes, without a
+// UserID, a submission time, or a place in the ballot chain. The event log still orders
+// the ballot among the election's other events, so voters should wait a while after
+// receiving their token, and submit the ballot over an anonymizing channel. The ballot
+// must follow the election's ballot rules, as with CastVote. The voter's receipt is
+// ballotreceipt.Receipt over the VoteID, ElectionID, and RankedProposalIDs.
+type CastSecretBallot struct {
+	VoteID            string
+	ElectionID        string
+	Token             string
+	Signature         string
+	RankedProposalIDs []string
+}
+
+type castSecretBallotHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewCastSecretBallotHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *castSecretBallotHandler {
+	return &castSecretBallotHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *castSecretBallotHandler) On(ctx context.Context, cmd CastSecretBallot, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.cast-secret-ballot")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	sleep.Rand(2 * time.Millisecond)
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = verifySecretBallotElection(election, occurredAt)
+	if err != nil {
+		return err
+	}
+
+	err = validateBallot(ctx, h.repository, election, cmd.RankedProposalIDs)
+	if err != nil {
+		return err
+	}
+
+	token, err := base64.StdEncoding.DecodeString(cmd.Token)
+	if err != nil || len(token) == 0 {
+		return ErrInvalidBallotToken
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(cmd.Signature)
+	if err != nil {
+		return ErrInvalidBallotToken
+	}
+
+	privateKey, err := getBallotKey(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = blindsig.Verify(&privateKey.PublicKey, token, signature)
+	if err != nil {
+		return ErrInvalidBallotToken
+	}
+
+	ballotTokenHash := sha256.Sum256(token)
+
+	secretBallot := electionrepository.SecretBallot{
+		VoteID:            cmd.VoteID,
+		ElectionID:        cmd.ElectionID,
+		RankedProposalIDs: append([]string{}, cmd.RankedProposalIDs...),
+		BallotTokenHash:   hex.EncodeToString(ballotTokenHash[:]),
+	}
+
+	err = h.repository.ValidateSecretBallot(ctx, secretBallot)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.SecretBallotWasCast{
+		VoteID:            secretBallot.VoteID,
+		ElectionID:        secretBallot.ElectionID,
+		RankedProposalIDs: append([]string{}, cmd.RankedProposalIDs...),
+		BallotTokenHash:   secretBallot.BallotTokenHash,
+		Receipt:           ballotreceipt.Receipt(secretBallot.VoteID, secretBallot.ElectionID, secretBallot.RankedProposalIDs),
+	})
+}
+
+// getCountedBallots returns the latest vote of each voter, followed by the
+// election's secret ballots.
+func getCountedBallots(ctx context.Context, repository electionrepository.Repository, electionID string) ([]electionrepository.Vote, error) {
+	votes, err := repository.GetVotes(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSecretBallots(ctx, repository, electionID, electionrepository.CountedVotes(votes))
+}
+
+// withSecretBallots appends the election's secret ballots to votes, as votes
+// without a UserID or SubmittedAt. Secret ballots are counted, but are not part
+// of the ballot chain.
+func withSecretBallots(ctx context.Context, repository electionrepository.Repository, electionID string, votes []electionrepository.Vote) ([]electionrepository.Vote, error) {
+	secretBallots, err := repository.GetSecretBallots(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots := append([]electionrepository.Vote{}, votes...)
+	for _, secretBallot := range secretBallots {
+		ballots = append(ballots, electionrepository.Vote{
+			VoteID:            secretBallot.VoteID,
+			ElectionID:        secretBallot.ElectionID,
+			RankedProposalIDs: secretBallot.RankedProposalIDs,
+		})
+	}
+
+	return ballots, nil
+}
+
+var ErrInvalidBallotToken = errors.New("ballot token does not have a valid signature for this election")
@@ -0,0 +1,277 @@
+package election_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/blindsig"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestCastSecretBallot(t *testing.T) {
+	const (
+		electionID = "4d1e7f0a-3b6c-4d9e-8f2a-5b8c1d4e7f0a"
+		proposalID = "5e2f8a1b-4c7d-4eaf-9a3b-6c9d2e5f8a1b"
+		voteID     = "6f3a9b2c-5d8e-4fb0-8b4c-7dae3f6a9b2c"
+	)
+
+	t.Run("saves ballot apart from the votes without authentication and raises event", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			BallotSecrecy:   cqrs.String(electionrepository.BallotSecrecySecret),
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: app.RegularUserID,
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		})
+		require.NoError(t, err)
+
+		response, err := app.ExecuteQuery(ctx, election.GetBallotToken{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+		})
+		require.NoError(t, err)
+		publicKey := parseBallotPublicKey(t, response.(election.GetBallotTokenResponse).PublicKey)
+
+		token := []byte("9c2f5a8b1e4d7a0c3f6b9e2d5a8c1f4b")
+		blindedToken, blindingFactor := blindBallotToken(t, publicKey, token)
+		_, err = app.ExecuteCommand(ctx, election.IssueBallotToken{
+			ElectionID:   electionID,
+			UserID:       app.RegularUserID,
+			BlindedToken: blindedToken,
+		})
+		require.NoError(t, err)
+
+		response, err = app.ExecuteQuery(ctx, election.GetBallotToken{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+		})
+		require.NoError(t, err)
+		signature := unblindBallotToken(t, publicKey, response.(election.GetBallotTokenResponse).BlindSignature, blindingFactor)
+
+		command := election.CastSecretBallot{
+			VoteID:            voteID,
+			ElectionID:        electionID,
+			Token:             base64.StdEncoding.EncodeToString(token),
+			Signature:         signature,
+			RankedProposalIDs: []string{proposalID},
+		}
+
+		// When
+		commandResponse, err := app.ExecuteCommand(context.Background(), command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, commandResponse)
+		tokenHash := sha256.Sum256(token)
+		assert.Equal(t, event.SecretBallotWasCast{
+			VoteID:            voteID,
+			ElectionID:        electionID,
+			RankedProposalIDs: []string{proposalID},
+			BallotTokenHash:   hex.EncodeToString(tokenHash[:]),
+			Receipt:           ballotreceipt.Receipt(voteID, electionID, []string{proposalID}),
+		}, app.EventDispatcher.GetEvent(3))
+
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		assert.Empty(t, votes)
+		secretBallots, err := app.ElectionRepository.GetSecretBallots(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, []electionrepository.SecretBallot{
+			{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				RankedProposalIDs: []string{proposalID},
+				BallotTokenHash:   hex.EncodeToString(tokenHash[:]),
+			},
+		}, secretBallots)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when ballot token has already been spent", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			privateKey := saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			}))
+			token, signature := signBallotToken(t, privateKey, []byte("0d3a6b9c2f5e8a1d4b7c0f3e6a9d2c5b"))
+			_, err := app.ExecuteCommand(ctx, election.CastSecretBallot{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				Token:             token,
+				Signature:         signature,
+				RankedProposalIDs: []string{proposalID},
+			})
+			require.NoError(t, err)
+			command := election.CastSecretBallot{
+				VoteID:            "7a4bac3d-6e9f-4ac1-9c5d-8ebf4a7bac3d",
+				ElectionID:        electionID,
+				Token:             token,
+				Signature:         signature,
+				RankedProposalIDs: []string{proposalID},
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrBallotTokenSpent(electionID), err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+
+		t.Run("when ballot token signature is invalid", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+			require.NoError(t, err)
+			token, signature := signBallotToken(t, otherKey, []byte("1e4b7cad3a6f9b2e5c8d1a4f7b0e3d6c"))
+			command := election.CastSecretBallot{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				Token:             token,
+				Signature:         signature,
+				RankedProposalIDs: []string{proposalID},
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrInvalidBallotToken, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election does not use secret ballots", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+				BallotSecrecy:   electionrepository.BallotSecrecyPublic,
+			}))
+			command := election.CastSecretBallot{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				Token:             base64.StdEncoding.EncodeToString([]byte("token")),
+				Signature:         base64.StdEncoding.EncodeToString([]byte("signature")),
+				RankedProposalIDs: []string{proposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrNotSecretBallot, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
+
+// saveSecretBallotElection saves an open election with secret ballots, along
+// with a ballot key. A small key keeps the tests fast.
+func saveSecretBallotElection(t *testing.T, ctx context.Context, repository electionrepository.Repository, electionID string) *rsa.PrivateKey {
+	t.Helper()
+
+	require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+		ElectionID:      electionID,
+		OrganizerUserID: "8b5cbd4e-7fa0-4bd2-8d6e-9fc05b8cbd4e",
+		BallotSecrecy:   electionrepository.BallotSecrecySecret,
+	}))
+
+	return saveBallotKey(t, ctx, repository, electionID)
+}
+
+func saveBallotKey(t *testing.T, ctx context.Context, repository electionrepository.Repository, electionID string) *rsa.PrivateKey {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	encodedKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, repository.SaveBallotKey(ctx, electionID, encodedKey))
+
+	return privateKey
+}
+
+func parseBallotPublicKey(t *testing.T, encodedPublicKey string) *rsa.PublicKey {
+	t.Helper()
+
+	der, err := base64.StdEncoding.DecodeString(encodedPublicKey)
+	require.NoError(t, err)
+
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	require.NoError(t, err)
+
+	return publicKey.(*rsa.PublicKey)
+}
+
+func blindBallotToken(t *testing.T, publicKey *rsa.PublicKey, token []byte) (string, []byte) {
+	t.Helper()
+
+	blindedToken, blindingFactor, err := blindsig.Blind(rand.Reader, publicKey, token)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(blindedToken), blindingFactor
+}
+
+func unblindBallotToken(t *testing.T, publicKey *rsa.PublicKey, blindSignature string, blindingFactor []byte) string {
+	t.Helper()
+
+	decodedBlindSignature, err := base64.StdEncoding.DecodeString(blindSignature)
+	require.NoError(t, err)
+
+	signature, err := blindsig.Unblind(publicKey, decodedBlindSignature, blindingFactor)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// signBallotToken returns a base64 encoded token and signature, as if they had
+// been issued by IssueBallotToken.
+func signBallotToken(t *testing.T, privateKey *rsa.PrivateKey, token []byte) (string, string) {
+	t.Helper()
+
+	blindedToken, blindingFactor, err := blindsig.Blind(rand.Reader, &privateKey.PublicKey, token)
+	require.NoError(t, err)
+
+	blindSignature, err := blindsig.Sign(privateKey, blindedToken)
+	require.NoError(t, err)
+
+	signature, err := blindsig.Unblind(&privateKey.PublicKey, blindSignature, blindingFactor)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(token), base64.StdEncoding.EncodeToString(signature)
+}
@@ -2,19 +2,35 @@ package election
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/inklabs/cqrs"
 	"github.com/inklabs/cqrs/pkg/clock"
 
 	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/ballotreceipt"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
 	"github.com/inklabs/vote/pkg/sleep"
 )
 
 // CastVote casts a ballot for a given ElectionID. RankedProposalIDs contains the
 // ranked candidates in order of preference: first, second, third and so forth. If your
 // first choice doesn’t have a chance to win, your ballot counts for your next choice.
+// Each UserID may cast one ballot per election. A second ballot is rejected, unless the
+// election's RevotePolicy is ReplacePrevious, in which case it supersedes the earlier ballot.
+// The earlier ballot stays in the ballot chain, but only the latest ballot is counted.
+// Ballots are only accepted between the election's VotingStartsAt and VotingEndsAt, when set.
+// When the election's EligibilityPolicy is RegisteredVoters, only users on its eligibility roll
+// may vote. Elections with secret ballots are voted in with CastSecretBallot instead.
+// The ballot must follow the election's ballot rules: it must rank at least one proposal,
+// and by default may not rank a proposal twice or leave a rank blank.
+// The voter's receipt is a hash commitment over VoteID, ElectionID and RankedProposalIDs,
+// raised with VoteWasCast and returned to the voter by GetBallotReceipt. Once the election
+// closes, VerifyBallotReceipt proves the receipt was counted.
+// UserID must be the authenticated user, unless an admin casts the ballot on their behalf.
 type CastVote struct {
 	VoteID            string
 	ElectionID        string
@@ -24,42 +40,108 @@ type CastVote struct {
 
 type castVoteHandler struct {
 	repository electionrepository.Repository
+	eventStore eventstore.Store
 	clock      clock.Clock
 }
 
-func NewCastVoteHandler(repository electionrepository.Repository, clock clock.Clock) *castVoteHandler {
+func NewCastVoteHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *castVoteHandler {
 	return &castVoteHandler{
 		repository: repository,
+		eventStore: eventStore,
 		clock:      clock,
 	}
 }
 
+func (h *castVoteHandler) Verify(ctx authorization.Context, cmd CastVote) error {
+	return verifyAuthenticatedUser(ctx, cmd.UserID)
+}
+
 func (h *castVoteHandler) On(ctx context.Context, cmd CastVote, eventRaiser cqrs.EventRaiser) error {
 	ctx, span := tracer.Start(ctx, "vote.cast-vote")
 	defer span.End()
 
+	if cmd.UserID == "" {
+		return ErrMissingUserID
+	}
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	occurredAt := int(h.clock.Now().Unix())
 
 	sleep.Rand(2 * time.Millisecond)
 
-	err := h.repository.SaveVote(ctx, electionrepository.Vote{
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if election.BallotSecrecy == electionrepository.BallotSecrecySecret {
+		return ErrSecretBallot
+	}
+
+	if election.VotingStartsAt > 0 && occurredAt < election.VotingStartsAt {
+		return ErrVotingNotStarted
+	}
+
+	if election.VotingEndsAt > 0 && occurredAt >= election.VotingEndsAt {
+		return ErrVotingEnded
+	}
+
+	err = validateBallot(ctx, h.repository, election, cmd.RankedProposalIDs)
+	if err != nil {
+		return err
+	}
+
+	vote := electionrepository.Vote{
 		VoteID:            cmd.VoteID,
 		ElectionID:        cmd.ElectionID,
 		UserID:            cmd.UserID,
 		RankedProposalIDs: append([]string{}, cmd.RankedProposalIDs...),
 		SubmittedAt:       occurredAt,
-	})
+	}
+
+	if election.RevotePolicy == electionrepository.RevotePolicyReplacePrevious {
+		vote.SupersedesVoteID, err = h.repository.GetUserVoteID(ctx, cmd.ElectionID, cmd.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = h.repository.ValidateVotes(ctx, []electionrepository.Vote{vote})
 	if err != nil {
 		return err
 	}
 
-	eventRaiser.Raise(event.VoteWasCast{
-		VoteID:            cmd.VoteID,
-		ElectionID:        cmd.ElectionID,
-		UserID:            cmd.UserID,
-		RankedProposalIDs: append([]string{}, cmd.RankedProposalIDs...),
-		OccurredAt:        occurredAt,
-	})
+	events := []cqrs.Event{
+		event.VoteWasCast{
+			VoteID:            cmd.VoteID,
+			ElectionID:        cmd.ElectionID,
+			UserID:            cmd.UserID,
+			RankedProposalIDs: append([]string{}, cmd.RankedProposalIDs...),
+			Receipt:           ballotreceipt.Receipt(cmd.VoteID, cmd.ElectionID, cmd.RankedProposalIDs),
+			OccurredAt:        occurredAt,
+		},
+	}
 
-	return nil
+	if vote.SupersedesVoteID != "" {
+		events = append(events, event.VoteWasReplaced{
+			ElectionID:     cmd.ElectionID,
+			UserID:         cmd.UserID,
+			VoteID:         cmd.VoteID,
+			ReplacedVoteID: vote.SupersedesVoteID,
+			OccurredAt:     occurredAt,
+		})
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, events...)
 }
+
+var (
+	ErrVotingNotStarted = errors.New("voting has not started")
+	ErrVotingEnded      = errors.New("voting has ended")
+	ErrSecretBallot     = errors.New("election uses secret ballots; vote with CastSecretBallot")
+)
@@ -1,15 +1,27 @@
 package election_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/cqrstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/inklabs/vote/action/election"
 	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/ballotreceipt"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/rcv"
+	"github.com/inklabs/vote/internal/writein"
 	"github.com/inklabs/vote/votetest"
 )
 
@@ -23,9 +35,9 @@ func TestCastVote(t *testing.T) {
 			proposalID1 = "fe420ed3-d56e-419c-a242-522ba89f92a2"
 			proposalID2 = "6ade8319-ff51-4ea0-94bc-4b21b40cf872"
 			proposalID3 = "c7a3936f-c5d0-4f56-a85e-c1544934c00e"
-			ownerUserID = "a75f86b8-4454-4faa-af9e-19274264f621"
 			voteID      = "9e08f651-f02f-414c-bf3e-1148a8c87e0c"
 		)
+		ownerUserID := app.RegularUserID
 		election1 := electionrepository.Election{
 			ElectionID:      electionID,
 			OrganizerUserID: "09dce1e9-568a-4fb2-945d-0ee9b95f5b04",
@@ -83,29 +95,384 @@ func TestCastVote(t *testing.T) {
 			ElectionID:        electionID,
 			UserID:            ownerUserID,
 			RankedProposalIDs: rankedProposalIDs,
+			Receipt:           ballotreceipt.Receipt(voteID, electionID, rankedProposalIDs),
 			OccurredAt:        0,
 		}, app.EventDispatcher.GetEvent(0))
 
 		actualVotes, err := app.ElectionRepository.GetVotes(ctx, electionID)
 		require.NoError(t, err)
 		assert.Equal(t, []electionrepository.Vote{
-			{
+			ballotchain.Link(electionrepository.Vote{
 				VoteID:            voteID,
 				ElectionID:        electionID,
 				UserID:            ownerUserID,
 				RankedProposalIDs: rankedProposalIDs,
-			},
+			}, ""),
 		}, actualVotes)
 	})
 
+	t.Run("replaces previous vote and raises event", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID    = "2f0c7d4e-9a1b-4c3d-8e5f-6a7b8c9d0e1f"
+			proposalID1   = "b1c2d3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e"
+			proposalID2   = "c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f"
+			previousVote  = "e4f5a6b7-c8d9-4e0f-9a2b-3c4d5e6f7081"
+			replacingVote = "f5a6b7c8-d9e0-4f1a-8b3c-4d5e6f708192"
+		)
+		userID := app.RegularUserID
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: "09dce1e9-568a-4fb2-945d-0ee9b95f5b04",
+			Name:            "Election Name",
+			Description:     "Election Description",
+			RevotePolicy:    electionrepository.RevotePolicyReplacePrevious,
+		}))
+		for _, proposalID := range []string{proposalID1, proposalID2} {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "a75f86b8-4454-4faa-af9e-19274264f621",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}))
+		}
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            previousVote,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID1},
+		}))
+
+		command := election.CastVote{
+			VoteID:            replacingVote,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID2, proposalID1},
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, event.VoteWasReplaced{
+			ElectionID:     electionID,
+			UserID:         userID,
+			VoteID:         replacingVote,
+			ReplacedVoteID: previousVote,
+			OccurredAt:     0,
+		}, app.EventDispatcher.GetEvent(1))
+
+		actualVotes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		firstVote := ballotchain.Link(electionrepository.Vote{
+			VoteID:            previousVote,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID1},
+		}, "")
+		secondVote := ballotchain.Link(electionrepository.Vote{
+			VoteID:            replacingVote,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: command.RankedProposalIDs,
+			SupersedesVoteID:  previousVote,
+		}, firstVote.VoteHash)
+		assert.Equal(t, []electionrepository.Vote{firstVote, secondVote}, actualVotes)
+		assert.Equal(t, []electionrepository.Vote{secondVote}, electionrepository.CountedVotes(actualVotes))
+	})
+
+	t.Run("allows an admin to vote on behalf of a user", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedAdminContext()
+		const userID = "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8091"
+		election1 := electionrepository.Election{
+			ElectionID:      "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8091a2",
+			OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+			Name:            "Election Name",
+			Description:     "Election Description",
+		}
+		proposal1 := electionrepository.Proposal{
+			ElectionID:  election1.ElectionID,
+			ProposalID:  "8a9b0c1d-2e3f-4a4b-8c5d-6e7f8091a2b3",
+			OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		}
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+		command := election.CastVote{
+			VoteID:            "9b0c1d2e-3f4a-4b5c-9d6e-7f8091a2b3c4",
+			ElectionID:        election1.ElectionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposal1.ProposalID},
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualVotes, err := app.ElectionRepository.GetVotes(ctx, election1.ElectionID)
+		require.NoError(t, err)
+		require.Len(t, actualVotes, 1)
+		assert.Equal(t, userID, actualVotes[0].UserID)
+	})
+
+	t.Run("accepts skipped rank when election allows it", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		election1 := electionrepository.Election{
+			ElectionID:        "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			OrganizerUserID:   "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+			Name:              "Election Name",
+			Description:       "Election Description",
+			AllowSkippedRanks: true,
+		}
+		proposalIDs := saveBallotRuleProposals(t, app.ElectionRepository, election1)
+		command := election.CastVote{
+			VoteID:            "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			ElectionID:        election1.ElectionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalIDs[0], rcv.SkippedRank, proposalIDs[1]},
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualVotes, err := app.ElectionRepository.GetVotes(ctx, election1.ElectionID)
+		require.NoError(t, err)
+		require.Len(t, actualVotes, 1)
+		assert.Equal(t, command.RankedProposalIDs, actualVotes[0].RankedProposalIDs)
+	})
+
+	t.Run("accepts write-in when election allows it", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		election1 := electionrepository.Election{
+			ElectionID:      "6f7a8b9c-0d1e-4f2a-8b3c-4d5e6f7a8b9c",
+			OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+			Name:            "Election Name",
+			Description:     "Election Description",
+			AllowWriteIns:   true,
+		}
+		proposalIDs := saveBallotRuleProposals(t, app.ElectionRepository, election1)
+		command := election.CastVote{
+			VoteID:            "7a8b9c0d-1e2f-4a3b-9c4d-5e6f7a8b9c0d",
+			ElectionID:        election1.ElectionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{writein.New("Jane Doe"), proposalIDs[0]},
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualVotes, err := app.ElectionRepository.GetVotes(ctx, election1.ElectionID)
+		require.NoError(t, err)
+		require.Len(t, actualVotes, 1)
+		assert.Equal(t, []string{"write-in:Jane Doe", proposalIDs[0]}, actualVotes[0].RankedProposalIDs)
+	})
+
+	t.Run("saves concurrent votes in the same election", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedAdminContext()
+		const (
+			electionID = "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d"
+			proposalID = "1b2c3d4e-5f6a-4b7c-9d8e-9f0a1b2c3d4e"
+			voterCount = 20
+		)
+		_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.AdminUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: app.AdminUserID,
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		})
+		require.NoError(t, err)
+
+		// When
+		errs := make([]error, voterCount)
+		var wg sync.WaitGroup
+		for i := range voterCount {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = app.ExecuteCommand(ctx, election.CastVote{
+					VoteID:            fmt.Sprintf("2c3d4e5f-6a7b-4c8d-9e0f-%012d", i),
+					ElectionID:        electionID,
+					UserID:            fmt.Sprintf("3d4e5f6a-7b8c-4d9e-8f0a-%012d", i),
+					RankedProposalIDs: []string{proposalID},
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Then
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		assert.Len(t, votes, voterCount)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, voterCount+2, actualElection.Version)
+		_, isBroken := ballotchain.FindBrokenLink(votes)
+		assert.False(t, isBroken)
+	})
+
+	t.Run("rebuilds read model left behind by a failed projection", func(t *testing.T) {
+		// Given
+		var repository *failingSaveElectionRepository
+		app := votetest.NewTestApp(t, votetest.WithElectionRepositoryDecorator(func(r electionrepository.Repository) electionrepository.Repository {
+			repository = &failingSaveElectionRepository{Repository: r}
+			return repository
+		}))
+		ctx := app.GetAuthenticatedAdminContext()
+		const (
+			electionID = "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b"
+			proposalID = "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c"
+			voteID1    = "6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d"
+			voteID2    = "7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0f"
+		)
+		_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.AdminUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: app.AdminUserID,
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		})
+		require.NoError(t, err)
+		repository.failures.Store(2)
+		_, err = app.ExecuteCommand(ctx, election.CastVote{
+			VoteID:            voteID1,
+			ElectionID:        electionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.Equal(t, errSaveElection, err)
+
+		// When
+		_, err = app.ExecuteCommand(ctx, election.CastVote{
+			VoteID:            voteID2,
+			ElectionID:        electionID,
+			UserID:            app.AdminUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+
+		// Then
+		require.NoError(t, err)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+		assert.Equal(t, voteID1, votes[0].VoteID)
+		assert.Equal(t, voteID2, votes[1].VoteID)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, actualElection.Version)
+		_, err = app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+	})
+
 	t.Run("errors", func(t *testing.T) {
+		t.Run("when voter is not the authenticated user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "3b4c5d6e-7f8a-4b9c-9d0e-1f2a3b4c5d6e",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "4c5d6e7f-8a9b-4c0d-8e1f-2a3b4c5d6e7f",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			command := election.CastVote{
+				VoteID:            "5d6e7f8a-9b0c-4d1e-9f2a-3b4c5d6e7f80",
+				ElectionID:        election1.ElectionID,
+				UserID:            "19a0abe7-fdd7-49f8-a01f-4fc0e0f12480",
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when UserID is missing", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedAdminContext()
+			election1 := electionrepository.Election{
+				ElectionID:        "0f1a2b3c-4d5e-4f6a-8b7c-8d9e0f1a2b3c",
+				OrganizerUserID:   "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID: election1.ElectionID,
+				ProposalID: "1a2b3c4d-5e6f-4a7b-9c8d-9e0f1a2b3c4d",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			command := election.CastVote{
+				VoteID:            "2b3c4d5e-6f7a-4b8c-8d9e-0f1a2b3c4d5e",
+				ElectionID:        election1.ElectionID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrMissingUserID, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+			votes, err := app.ElectionRepository.GetVotes(ctx, election1.ElectionID)
+			require.NoError(t, err)
+			assert.Empty(t, votes)
+		})
+
 		t.Run("when election not found", func(t *testing.T) {
 			// Given
 			app := votetest.NewTestApp(t)
 			ctx := app.GetAuthenticatedUserContext()
 			command := election.CastVote{
 				ElectionID: "8493f7d9-1080-42a7-ae08-5d42d06941de",
-				UserID:     "19a0abe7-fdd7-49f8-a01f-4fc0e0f12480",
+				UserID:     app.RegularUserID,
 				RankedProposalIDs: []string{
 					"f6cec37d-cb86-4798-ae7d-51fd3cfc075b",
 				},
@@ -134,7 +501,7 @@ func TestCastVote(t *testing.T) {
 
 			command := election.CastVote{
 				ElectionID: election1.ElectionID,
-				UserID:     "19a0abe7-fdd7-49f8-a01f-4fc0e0f12480",
+				UserID:     app.RegularUserID,
 				RankedProposalIDs: []string{
 					unknownProposalID,
 				},
@@ -177,7 +544,7 @@ func TestCastVote(t *testing.T) {
 
 			command := election.CastVote{
 				ElectionID: election2.ElectionID,
-				UserID:     "80d3c0bf-c523-459a-af47-36a2f3a643dd",
+				UserID:     app.RegularUserID,
 				RankedProposalIDs: []string{
 					proposal1.ProposalID,
 				},
@@ -191,5 +558,397 @@ func TestCastVote(t *testing.T) {
 			require.Equal(t, expectedErr, err)
 			assert.Empty(t, app.EventDispatcher.GetEvents())
 		})
+
+		t.Run("when user has already voted", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			userID := app.RegularUserID
+			election1 := electionrepository.Election{
+				ElectionID:      "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "6e5d4c3b-2a19-4f08-b7e6-d5c4b3a29180",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            "7f6e5d4c-3b2a-4190-a8f7-e6d5c4b3a291",
+				ElectionID:        election1.ElectionID,
+				UserID:            userID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}))
+
+			command := election.CastVote{
+				VoteID:     "8a7f6e5d-4c3b-4a29-b1f0-e9d8c7b6a5f4",
+				ElectionID: election1.ElectionID,
+				UserID:     userID,
+				RankedProposalIDs: []string{
+					proposal1.ProposalID,
+				},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrDuplicateVote(election1.ElectionID, userID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when voter is not on the eligibility roll", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:        "47a36e76-03bf-4ac1-82f3-becfd0e1f2a3",
+				OrganizerUserID:   "58b47f87-14c0-4bd2-93a4-cfd0e1f2a3b4",
+				EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID: election1.ElectionID,
+				ProposalID: "69c58098-25d1-4ce3-84b5-d0e1f2a3b4c5",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			command := election.CastVote{
+				VoteID:            "7ad691a9-36e2-4df4-95c6-e1f2a3b4c5d6",
+				ElectionID:        election1.ElectionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrVoterNotEligible(election1.ElectionID, app.RegularUserID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election uses secret ballots", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "8be7a2ba-47f3-4e05-a6d7-f2a3b4c5d6e7",
+				OrganizerUserID: "9cf8b3cb-58a4-4f16-b7e8-a3b4c5d6e7f8",
+				BallotSecrecy:   electionrepository.BallotSecrecySecret,
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID: election1.ElectionID,
+				ProposalID: "ad09c4dc-69b5-4027-88f9-b4c5d6e7f809",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			command := election.CastVote{
+				VoteID:            "be1ad5ed-7ac6-4138-990a-c5d6e7f8091a",
+				ElectionID:        election1.ElectionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrSecretBallot, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election is closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			election1.IsClosed = true
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+			command := election.CastVote{
+				VoteID:     "3d4e5f6a-7b8c-4d9e-8f1a-2b3c4d5e6f7a",
+				ElectionID: election1.ElectionID,
+				UserID:     app.RegularUserID,
+				RankedProposalIDs: []string{
+					proposal1.ProposalID,
+				},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionClosed(election1.ElectionID), err)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when voting has not started", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+				VotingStartsAt:  10,
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "1b2c3d4e-5f6a-4b7c-9d8e-9f0a1b2c3d4e",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+
+			command := election.CastVote{
+				VoteID:            "2c3d4e5f-6a7b-4c8d-ae9f-0a1b2c3d4e5f",
+				ElectionID:        election1.ElectionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrVotingNotStarted, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when voting has ended", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "3d4e5f6a-7b8c-4d9e-bf0a-1b2c3d4e5f6a",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+				VotingEndsAt:    1,
+			}
+			proposal1 := electionrepository.Proposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "4e5f6a7b-8c9d-4e0f-8a1b-2c3d4e5f6a7b",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
+			_, err := app.ExecuteCommand(ctx, election.CastVote{
+				VoteID:            "5f6a7b8c-9d0e-4f1a-9b2c-3d4e5f6a7b8c",
+				ElectionID:        election1.ElectionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			})
+			require.NoError(t, err)
+
+			command := election.CastVote{
+				VoteID:            "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d",
+				ElectionID:        election1.ElectionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposal1.ProposalID},
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrVotingEnded, err)
+		})
+
+		t.Run("when election was closed after it was last projected", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			const (
+				electionID = "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e"
+				proposalID = "8c9d0e1f-2a3b-4c4d-8e5f-6a7b8c9d0e1f"
+			)
+			_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+			})
+			require.NoError(t, err)
+			_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: app.RegularUserID,
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			})
+			require.NoError(t, err)
+			require.NoError(t, app.EventStore.Append(ctx, electionID, 2, event.ElectionWasClosedByOwner{
+				ElectionID: electionID,
+				OccurredAt: 1,
+			}))
+			command := election.CastVote{
+				VoteID:            "9d0e1f2a-3b4c-4d5e-9f6a-7b8c9d0e1f2a",
+				ElectionID:        electionID,
+				UserID:            app.RegularUserID,
+				RankedProposalIDs: []string{proposalID},
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionClosed(electionID), err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 2)
+			votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+			require.NoError(t, err)
+			assert.Empty(t, votes)
+			actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+			require.NoError(t, err)
+			assert.True(t, actualElection.IsClosed)
+			assert.Equal(t, 3, actualElection.Version)
+		})
+
+		t.Run("when ballot breaks election ballot rules", func(t *testing.T) {
+			tests := []struct {
+				name              string
+				election          electionrepository.Election
+				rankedProposalIDs func(proposalIDs []string) []string
+				err               error
+			}{
+				{
+					name: "empty ballot",
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return []string{}
+					},
+					err: election.ErrEmptyBallot,
+				},
+				{
+					name: "duplicate ranking",
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return []string{proposalIDs[0], proposalIDs[1], proposalIDs[0]}
+					},
+					err: election.ErrDuplicateRanking,
+				},
+				{
+					name: "skipped rank",
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return []string{proposalIDs[0], rcv.SkippedRank, proposalIDs[1]}
+					},
+					err: rcv.ErrSkippedRank,
+				},
+				{
+					name: "write-in not allowed",
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return []string{writein.New("Jane Doe"), proposalIDs[0]}
+					},
+					err: rcv.ErrWriteInNotAllowed,
+				},
+				{
+					name:     "too many ranks",
+					election: electionrepository.Election{MaxRanks: 2},
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return proposalIDs
+					},
+					err: rcv.ErrTooManyRanks,
+				},
+				{
+					name:     "incomplete ranking",
+					election: electionrepository.Election{RequireCompleteRanking: true},
+					rankedProposalIDs: func(proposalIDs []string) []string {
+						return proposalIDs[:2]
+					},
+					err: rcv.ErrIncompleteRanking,
+				},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					// Given
+					app := votetest.NewTestApp(t)
+					ctx := app.GetAuthenticatedUserContext()
+					election1 := tt.election
+					election1.ElectionID = "3c4d5e6f-7a8b-4c9d-8e1f-2a3b4c5d6e7f"
+					election1.OrganizerUserID = "3c51a70e-14cc-4cbb-b2dc-f58317470729"
+					election1.Name = "Election Name"
+					proposalIDs := saveBallotRuleProposals(t, app.ElectionRepository, election1)
+					command := election.CastVote{
+						VoteID:            "4d5e6f7a-8b9c-4d0e-9f2a-3b4c5d6e7f8a",
+						ElectionID:        election1.ElectionID,
+						UserID:            app.RegularUserID,
+						RankedProposalIDs: tt.rankedProposalIDs(proposalIDs),
+					}
+
+					// When
+					_, err := app.ExecuteCommand(ctx, command)
+
+					// Then
+					require.ErrorIs(t, err, tt.err)
+					assert.Empty(t, app.EventDispatcher.GetEvents())
+				})
+			}
+		})
 	})
 }
+
+// saveBallotRuleProposals saves election1 with three proposals, and returns their ProposalIDs.
+func saveBallotRuleProposals(t *testing.T, repository electionrepository.Repository, election1 electionrepository.Election) []string {
+	t.Helper()
+
+	ctx := cqrstest.TimeoutContext(t)
+	require.NoError(t, repository.SaveElection(ctx, election1))
+
+	proposalIDs := []string{
+		"5e6f7a8b-9c0d-4e1f-8a3b-4c5d6e7f8a01",
+		"5e6f7a8b-9c0d-4e1f-8a3b-4c5d6e7f8a02",
+		"5e6f7a8b-9c0d-4e1f-8a3b-4c5d6e7f8a03",
+	}
+	for _, proposalID := range proposalIDs {
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  election1.ElectionID,
+			ProposalID:  proposalID,
+			OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		}))
+	}
+
+	return proposalIDs
+}
+
+var errSaveElection = errors.New("save election failed")
+
+// failingSaveElectionRepository fails the next failures calls to SaveElection.
+type failingSaveElectionRepository struct {
+	electionrepository.Repository
+	failures atomic.Int32
+}
+
+func (r *failingSaveElectionRepository) SaveElection(ctx context.Context, election electionrepository.Election) error {
+	if r.failures.Add(-1) >= 0 {
+		return errSaveElection
+	}
+
+	return r.Repository.SaveElection(ctx, election)
+}
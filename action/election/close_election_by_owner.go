@@ -2,9 +2,11 @@ package election
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
 	"github.com/inklabs/cqrs"
@@ -12,41 +14,67 @@ import (
 
 	"github.com/inklabs/vote/event"
 	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/ballotreceipt"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
 	"github.com/inklabs/vote/internal/rcv"
 	"github.com/inklabs/vote/pkg/sleep"
 )
 
 // CloseElectionByOwner is an asynchronous command that closes an election and
-// calculates a winner by using the Ranked Choice Voting (RCV) electoral system.
+// calculates a winner by using the voting method chosen when the election commenced.
+// Elections with more than one seat elect multiple winners by using the Single
+// Transferable Vote (STV) electoral system. Only each voter's latest ballot is counted.
+// The Merkle root over the receipts of the counted ballots is published with the
+// results, so voters can verify their ballot was counted with VerifyBallotReceipt.
+// Admins, including the election scheduler, may also close an election.
+//
+// An election that cannot select a winner still closes, with an outcome of NoVotes
+// when no ballots were cast, Tie when proposals are tied for the win, or NoMajority
+// when no proposal reached the votes needed to win. The outcome is reported by
+// GetElectionResults. Tie only applies to the Plurality, Approval, BordaCount,
+// Copeland and Schulze voting methods: InstantRunoff and multi-seat elections
+// resolve ties with the tie-break policy, so they end in a winner or NoMajority.
+//
+// Ballots are not tabulated while the election's ballot chain is broken, as reported by
+// VerifyElectionIntegrity. Only an admin may set AllowBrokenBallotChain to tabulate them
+// anyway.
+//
+// The election is locked while its ballots are counted, so a ballot cast during
+// tabulation waits for the close and is then rejected because the election is closed,
+// instead of being left out of the results.
 type CloseElectionByOwner struct {
-	ID         string
-	ElectionID string
+	ID                     string
+	ElectionID             string
+	AllowBrokenBallotChain bool
 }
 
 type closeElectionByOwnerHandler struct {
 	repository electionrepository.Repository
+	eventStore eventstore.Store
 	clock      clock.Clock
 }
 
 func NewCloseElectionByOwnerHandler(
 	repository electionrepository.Repository,
+	eventStore eventstore.Store,
 	clock clock.Clock,
 ) *closeElectionByOwnerHandler {
 	return &closeElectionByOwnerHandler{
 		repository: repository,
+		eventStore: eventStore,
 		clock:      clock,
 	}
 }
 
 func (h *closeElectionByOwnerHandler) Verify(ctx authorization.Context, cmd CloseElectionByOwner) error {
-	election, err := h.repository.GetElection(ctx.Context(), cmd.ElectionID)
+	err := verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
 	if err != nil {
 		return err
 	}
 
-	if ctx.UserID() != election.OrganizerUserID {
-		log.Printf("user %s does not match election organizer user %s", ctx.UserID(), election.OrganizerUserID)
+	if cmd.AllowBrokenBallotChain && !ctx.IsAdmin() {
 		return cqrs.ErrAccessDenied
 	}
 
@@ -57,13 +85,61 @@ func (h *closeElectionByOwnerHandler) On(ctx context.Context, cmd CloseElectionB
 	ctx, span := tracer.Start(ctx, "vote.close-election-by-owner")
 	defer span.End()
 
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
 	if err != nil {
 		logger.LogError("election not found: %s", cmd.ElectionID)
 		return err
 	}
 
-	winningProposalID, err := h.getWinningProposalID(ctx, cmd.ElectionID, logger)
+	if election.IsClosed {
+		logger.LogError("election already closed: %s", cmd.ElectionID)
+		return electionrepository.NewErrElectionClosed(cmd.ElectionID)
+	}
+
+	// The votes are read after the election, so any vote they are missing has moved the
+	// stream past election.Version.
+	votes, err := h.repository.GetVotes(ctx, election.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if brokenLink, ok := ballotchain.FindBrokenLink(votes); ok {
+		if !cmd.AllowBrokenBallotChain {
+			logger.LogError("ballot chain is broken at vote %s: %s", brokenLink.VoteID, brokenLink.Reason)
+			return electionrepository.NewErrBrokenBallotChain(election.ElectionID, brokenLink.VoteID)
+		}
+
+		logger.LogInfo("Tabulating broken ballot chain, allowed by admin, broken at vote %s: %s", brokenLink.VoteID, brokenLink.Reason)
+	}
+
+	chainHead := ballotchain.GetHead(votes)
+
+	votes, err = withSecretBallots(ctx, h.repository, election.ElectionID, electionrepository.CountedVotes(votes))
+	if err != nil {
+		return err
+	}
+
+	receiptRoot, err := getReceiptRoot(votes)
+	if err != nil {
+		return err
+	}
+
+	tieBreak, err := h.getTieBreak(ctx, election, receiptRoot)
+	if err != nil {
+		return err
+	}
+
+	winningProposalIDs, rounds, err := h.getWinningProposalIDs(election, votes, tieBreak, logger)
+	if outcome, ok := getOutcomeWithoutWinner(err); ok {
+		return h.closeWithoutWinner(ctx, election, outcome, rounds, receiptRoot, tieBreak.Seed, chainHead, eventRaiser, logger)
+	}
+
 	if err != nil {
 		logger.LogError("unable to get winning proposal")
 		err = fmt.Errorf("unable to get winning proposal: %w", err)
@@ -71,28 +147,86 @@ func (h *closeElectionByOwnerHandler) On(ctx context.Context, cmd CloseElectionB
 		return err
 	}
 
+	winningProposalID := winningProposalIDs[0]
+
 	selectedAt := int(h.clock.Now().Unix())
-	election.IsClosed = true
-	election.ClosedAt = selectedAt
-	election.SelectedAt = selectedAt
-	election.WinningProposalID = winningProposalID
 
-	err = h.repository.SaveElection(ctx, election)
+	err = recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election,
+		event.ElectionWasClosedByOwner{
+			ElectionID:      cmd.ElectionID,
+			BallotChainHead: chainHead.VoteHash,
+			BallotCount:     chainHead.BallotCount,
+			OccurredAt:      selectedAt,
+		},
+		event.ElectionWinnerWasSelected{
+			ElectionID:         cmd.ElectionID,
+			WinningProposalID:  winningProposalID,
+			WinningProposalIDs: winningProposalIDs,
+			TabulationRounds:   toEventTabulationRounds(rounds),
+			ReceiptRoot:        receiptRoot,
+			TieBreakSeed:       tieBreak.Seed,
+			SelectedAt:         selectedAt,
+		},
+	)
 	if err != nil {
 		return err
 	}
 
-	logger.LogInfo("Closing election with winner: %s", winningProposalID)
+	if len(winningProposalIDs) > 1 {
+		logger.LogInfo("Closing election with winners: %s", strings.Join(winningProposalIDs, ", "))
+	} else {
+		logger.LogInfo("Closing election with winner: %s", winningProposalID)
+	}
+
+	return nil
+}
+
+// closeWithoutWinner closes an election that could not select a winner, and
+// records the outcome and any tabulation rounds that were counted.
+func (h *closeElectionByOwnerHandler) closeWithoutWinner(ctx context.Context, election electionrepository.Election, outcome string, rounds []rcv.Round, receiptRoot string, tieBreakSeed int64, chainHead ballotchain.Head, eventRaiser cqrs.EventRaiser, logger cqrs.AsyncCommandLogger) error {
+	closedAt := int(h.clock.Now().Unix())
+
+	err := recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election,
+		event.ElectionWasClosedByOwner{
+			ElectionID:      election.ElectionID,
+			BallotChainHead: chainHead.VoteHash,
+			BallotCount:     chainHead.BallotCount,
+			OccurredAt:      closedAt,
+		},
+		event.ElectionClosedWithoutWinner{
+			ElectionID:       election.ElectionID,
+			Outcome:          outcome,
+			TabulationRounds: toEventTabulationRounds(rounds),
+			ReceiptRoot:      receiptRoot,
+			TieBreakSeed:     tieBreakSeed,
+			OccurredAt:       closedAt,
+		},
+	)
+	if err != nil {
+		return err
+	}
 
-	eventRaiser.Raise(event.ElectionWinnerWasSelected{
-		ElectionID:        cmd.ElectionID,
-		WinningProposalID: winningProposalID,
-		SelectedAt:        selectedAt,
-	})
+	logger.LogInfo("Closing election without winner: %s", outcome)
 
 	return nil
 }
 
+// getOutcomeWithoutWinner returns the outcome of an election whose tabulation
+// failed with err, if err means that no winner could be selected. rcv.ErrTie is
+// only returned by the voting methods that do not use a tie-break policy.
+func getOutcomeWithoutWinner(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrNoVotesFound):
+		return electionrepository.OutcomeNoVotes, true
+	case errors.Is(err, rcv.ErrTie):
+		return electionrepository.OutcomeTie, true
+	case errors.Is(err, rcv.ErrWinnerNotFound):
+		return electionrepository.OutcomeNoMajority, true
+	}
+
+	return "", false
+}
+
 func simulateProcessing(logger cqrs.AsyncCommandLogger, totalToProcess int) {
 	logger.SetTotalToProcess(totalToProcess)
 
@@ -110,29 +244,105 @@ func simulateProcessing(logger cqrs.AsyncCommandLogger, totalToProcess int) {
 	logger.Flush()
 }
 
-func (h *closeElectionByOwnerHandler) getWinningProposalID(ctx context.Context, electionID string, logger cqrs.AsyncCommandLogger) (string, error) {
-	votes, err := h.repository.GetVotes(ctx, electionID)
-	if err != nil {
-		return "", err
-	}
-
+// getWinningProposalIDs tabulates the votes with the election's voting method, or
+// a multi-winner tabulator when the election has more than one seat. Ballots are
+// normalized with the election's ballot rules first, so undervotes are not counted.
+// The rounds counted are returned even when no winner is found.
+func (h *closeElectionByOwnerHandler) getWinningProposalIDs(election electionrepository.Election, votes []electionrepository.Vote, tieBreak rcv.TieBreak, logger cqrs.AsyncCommandLogger) ([]string, []rcv.Round, error) {
 	if len(votes) == 0 {
-		logger.LogError("no votes found for election")
-		return "", ErrNoVotesFound
+		return nil, nil, ErrNoVotesFound
 	}
 
 	simulateProcessing(logger, len(votes))
 
-	tabulator := rcv.NewSingleWinner(toRankedProposalVotes(votes))
+	ballots := toRankedProposalVotes(votes).Normalize(getBallotRules(election))
+
+	if election.SeatCount > 1 {
+		tabulator := rcv.NewMultiWinner(ballots, election.SeatCount, rcv.WithTieBreak(tieBreak))
+		winningProposalIDs, err := tabulator.GetWinningProposals()
+		if err != nil {
+			return nil, tabulator.Rounds(), err
+		}
+
+		return winningProposalIDs, tabulator.Rounds(), nil
+	}
+
+	votingMethod := election.VotingMethod
+	if votingMethod == "" {
+		votingMethod = rcv.InstantRunoff
+	}
+
+	tabulator, err := rcv.NewVotingMethod(votingMethod, ballots, rcv.WithTieBreak(tieBreak))
+	if err != nil {
+		logger.LogError("unknown voting method: %s", votingMethod)
+		return nil, nil, err
+	}
+
 	winningProposalID, err := tabulator.GetWinningProposal()
+
+	var rounds []rcv.Round
+	if roundReporter, ok := tabulator.(rcv.RoundReporter); ok {
+		rounds = roundReporter.Rounds()
+	}
+
 	if err != nil {
-		if errors.Is(err, rcv.ErrWinnerNotFound) {
-			logger.LogError("winner not found")
+		return nil, rounds, err
+	}
+
+	return []string{winningProposalID}, rounds, nil
+}
+
+// getTieBreak returns the election's tie-break policy. A Random draw is seeded
+// from the receipt root, which no one can choose without changing the ballots,
+// and EarliestProposal needs the time each proposal was made.
+func (h *closeElectionByOwnerHandler) getTieBreak(ctx context.Context, election electionrepository.Election, receiptRoot string) (rcv.TieBreak, error) {
+	tieBreak := rcv.TieBreak{
+		Policy: election.TieBreakPolicy,
+	}
+
+	switch tieBreak.Policy {
+	case "":
+		tieBreak.Policy = rcv.TieBreakBorda
+
+	case rcv.TieBreakRandom:
+		tieBreak.Seed = getTieBreakSeed(receiptRoot)
+
+	case rcv.TieBreakEarliestProposal:
+		proposals, err := listAllProposals(ctx, h.repository, election.ElectionID)
+		if err != nil {
+			return rcv.TieBreak{}, err
+		}
+
+		tieBreak.ProposedAt = make(map[string]int, len(proposals))
+		for _, proposal := range proposals {
+			tieBreak.ProposedAt[proposal.ProposalID] = proposal.ProposedAt
 		}
-		return "", err
 	}
 
-	return winningProposalID, nil
+	return tieBreak, nil
+}
+
+// getTieBreakSeed returns the first 8 bytes of the receipt root as a seed.
+func getTieBreakSeed(receiptRoot string) int64 {
+	root, err := hex.DecodeString(receiptRoot)
+	if err != nil || len(root) < 8 {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(root[:8]))
+}
+
+// getReceiptRoot returns the Merkle root over the receipts of the counted votes.
+func getReceiptRoot(votes []electionrepository.Vote) (string, error) {
+	return ballotreceipt.Root(getReceipts(votes))
+}
+
+func getReceipts(votes []electionrepository.Vote) []string {
+	receipts := make([]string, len(votes))
+	for i, vote := range votes {
+		receipts[i] = ballotreceipt.Receipt(vote.VoteID, vote.ElectionID, vote.RankedProposalIDs)
+	}
+	return receipts
 }
 
 func toRankedProposalVotes(votes []electionrepository.Vote) rcv.Ballots {
@@ -146,4 +356,36 @@ func toRankedProposalVotes(votes []electionrepository.Vote) rcv.Ballots {
 	return rankedProposalVotes
 }
 
+func toEventTabulationRounds(rounds []rcv.Round) []event.TabulationRound {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	tabulationRounds := make([]event.TabulationRound, len(rounds))
+
+	for i, round := range rounds {
+		proposalCounts := make([]event.ProposalCount, len(round.ProposalCounts))
+		for j, proposalCount := range round.ProposalCounts {
+			proposalCounts[j] = event.ProposalCount{
+				ProposalID:    proposalCount.ProposalID,
+				Count:         proposalCount.Count,
+				WeightedCount: proposalCount.WeightedCount,
+			}
+		}
+
+		tabulationRounds[i] = event.TabulationRound{
+			Number:               round.Number,
+			ProposalCounts:       proposalCounts,
+			EliminatedProposalID: round.EliminatedProposalID,
+			ElectedProposalIDs:   round.ElectedProposalIDs,
+			TransferValue:        round.TransferValue,
+			UsedBordaTieBreaker:  round.UsedBordaTieBreaker,
+			TiedProposalIDs:      round.TiedProposalIDs,
+			ExhaustedBallots:     round.ExhaustedBallots,
+		}
+	}
+
+	return tabulationRounds
+}
+
 var ErrNoVotesFound = errors.New("no votes found for election")
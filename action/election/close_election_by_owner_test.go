@@ -1,7 +1,9 @@
 package election_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/inklabs/cqrs"
 	"github.com/stretchr/testify/assert"
@@ -9,7 +11,10 @@ import (
 
 	"github.com/inklabs/vote/action/election"
 	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/ballotreceipt"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/writein"
 	"github.com/inklabs/vote/votetest"
 )
 
@@ -44,14 +49,21 @@ func TestCloseElectionByOwner(t *testing.T) {
 		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
 		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, proposal1))
 		require.NoError(t, app.ElectionRepository.SaveVote(ctx, vote1))
+		savedVotes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		chainHead := ballotchain.GetHead(savedVotes)
 
 		winningProposalID := proposal1.ProposalID
+		receiptRoot, err := ballotreceipt.Root([]string{
+			ballotreceipt.Receipt(vote1.VoteID, electionID, vote1.RankedProposalIDs),
+		})
+		require.NoError(t, err)
 		commandID := "4f4442af-a4b0-43d7-acc7-f83a6fd1220c"
 		command := election.CloseElectionByOwner{
 			ID:         commandID,
 			ElectionID: electionID,
 		}
-		app.EventDispatcher.Add(1)
+		app.EventDispatcher.Add(2)
 
 		// When
 		response, err := app.EnqueueCommand(ctx, command)
@@ -65,11 +77,27 @@ func TestCloseElectionByOwner(t *testing.T) {
 		}, response)
 
 		app.EventDispatcher.Wait(ctx)
-		assert.Equal(t, event.ElectionWinnerWasSelected{
-			ElectionID:        electionID,
-			WinningProposalID: winningProposalID,
-			SelectedAt:        2,
+		assert.Equal(t, event.ElectionWasClosedByOwner{
+			ElectionID:      electionID,
+			BallotChainHead: chainHead.VoteHash,
+			BallotCount:     1,
+			OccurredAt:      2,
 		}, app.EventDispatcher.GetEvent(0))
+		assert.Equal(t, event.ElectionWinnerWasSelected{
+			ElectionID:         electionID,
+			WinningProposalID:  winningProposalID,
+			WinningProposalIDs: []string{winningProposalID},
+			TabulationRounds: []event.TabulationRound{
+				{
+					Number: 1,
+					ProposalCounts: []event.ProposalCount{
+						{ProposalID: winningProposalID, Count: 1},
+					},
+				},
+			},
+			ReceiptRoot: receiptRoot,
+			SelectedAt:  2,
+		}, app.EventDispatcher.GetEvent(1))
 
 		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
 		require.NoError(t, err)
@@ -89,18 +117,760 @@ func TestCloseElectionByOwner(t *testing.T) {
 		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
 		require.NoError(t, err)
 		assert.Equal(t, electionrepository.Election{
+			ElectionID:         electionID,
+			OrganizerUserID:    election1.OrganizerUserID,
+			Name:               election1.Name,
+			Description:        election1.Description,
+			WinningProposalID:  winningProposalID,
+			WinningProposalIDs: []string{winningProposalID},
+			Outcome:            electionrepository.OutcomeWinnerSelected,
+			IsClosed:           true,
+			CommencedAt:        0,
+			ClosedAt:           2,
+			SelectedAt:         2,
+			ReceiptRoot:        receiptRoot,
+			BallotChainHead:    chainHead.VoteHash,
+			BallotCount:        1,
+			TabulationRounds: []electionrepository.TabulationRound{
+				{
+					Number: 1,
+					ProposalCounts: []electionrepository.ProposalCount{
+						{ProposalID: winningProposalID, Count: 1},
+					},
+				},
+			},
+			Version: 2,
+		}, actualElection)
+	})
+
+	t.Run("closes multi-seat election with winners", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "6f8a53b3-7cf0-4b0b-a6cd-4e4f0d2a7f3e"
+
+		election1 := electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       2,
+		}
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+		proposalIDs := []string{
+			"0b5c0a4e-37a3-4b7c-9d1e-5d0f5a1c2b01",
+			"0b5c0a4e-37a3-4b7c-9d1e-5d0f5a1c2b02",
+			"0b5c0a4e-37a3-4b7c-9d1e-5d0f5a1c2b03",
+		}
+		for _, proposalID := range proposalIDs {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}))
+		}
+
+		rankedProposalIDs := [][]string{
+			{proposalIDs[0], proposalIDs[2]},
+			{proposalIDs[0], proposalIDs[2]},
+			{proposalIDs[0], proposalIDs[2]},
+			{proposalIDs[0], proposalIDs[2]},
+			{proposalIDs[1]},
+			{proposalIDs[1]},
+			{proposalIDs[2]},
+		}
+		var receipts []string
+		for i, ranked := range rankedProposalIDs {
+			voteID := fmt.Sprintf("4d1c6f0e-2f5b-4c1e-8b7a-3e9d2c1b0a%02d", i)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+			receipts = append(receipts, ballotreceipt.Receipt(voteID, electionID, ranked))
+		}
+		receiptRoot, err := ballotreceipt.Root(receipts)
+		require.NoError(t, err)
+
+		commandID := "a0ad6c1f-44b1-4bd2-9b4b-3a5c1b1f2e10"
+		command := election.CloseElectionByOwner{
+			ID:         commandID,
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err = app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		winningProposalIDs := []string{proposalIDs[0], proposalIDs[2]}
+		assert.Equal(t, event.ElectionWinnerWasSelected{
+			ElectionID:         electionID,
+			WinningProposalID:  proposalIDs[0],
+			WinningProposalIDs: winningProposalIDs,
+			TabulationRounds: []event.TabulationRound{
+				{
+					Number: 1,
+					ProposalCounts: []event.ProposalCount{
+						{ProposalID: proposalIDs[0], Count: 4, WeightedCount: 4},
+						{ProposalID: proposalIDs[1], Count: 2, WeightedCount: 2},
+						{ProposalID: proposalIDs[2], Count: 1, WeightedCount: 1},
+					},
+					ElectedProposalIDs: []string{proposalIDs[0]},
+					TransferValue:      0.25,
+				},
+				{
+					Number: 2,
+					ProposalCounts: []event.ProposalCount{
+						{ProposalID: proposalIDs[1], Count: 2, WeightedCount: 2},
+						{ProposalID: proposalIDs[2], Count: 5, WeightedCount: 2},
+					},
+					EliminatedProposalID: proposalIDs[1],
+					UsedBordaTieBreaker:  true,
+					TiedProposalIDs:      []string{proposalIDs[1], proposalIDs[2]},
+				},
+				{
+					Number: 3,
+					ProposalCounts: []event.ProposalCount{
+						{ProposalID: proposalIDs[2], Count: 5, WeightedCount: 2},
+					},
+					ElectedProposalIDs: []string{proposalIDs[2]},
+					ExhaustedBallots:   2,
+				},
+			},
+			ReceiptRoot: receiptRoot,
+			SelectedAt:  2,
+		}, app.EventDispatcher.GetEvent(1))
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, winningProposalIDs, actualElection.WinningProposalIDs)
+		assert.Len(t, actualElection.TabulationRounds, 3)
+		assert.True(t, actualElection.IsClosed)
+	})
+
+	t.Run("closes election using chosen voting method", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "3c2b1a09-8f7e-4d6c-9b5a-4e3d2c1b0a9f"
+
+		election1 := electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       1,
+			VotingMethod:    "Copeland",
+		}
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+		proposalIDs := []string{
+			"7e1d2c3b-4a59-4867-b5c4-d3e2f1a0b901",
+			"7e1d2c3b-4a59-4867-b5c4-d3e2f1a0b902",
+			"7e1d2c3b-4a59-4867-b5c4-d3e2f1a0b903",
+		}
+		for _, proposalID := range proposalIDs {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}))
+		}
+
+		// proposal 2 is preferred head-to-head over both other proposals,
+		// but is eliminated first by instant runoff
+		rankedProposalIDs := [][]string{
+			{proposalIDs[0], proposalIDs[1], proposalIDs[2]},
+			{proposalIDs[0], proposalIDs[1], proposalIDs[2]},
+			{proposalIDs[2], proposalIDs[1], proposalIDs[0]},
+			{proposalIDs[2], proposalIDs[1], proposalIDs[0]},
+			{proposalIDs[1], proposalIDs[0], proposalIDs[2]},
+		}
+		var receipts []string
+		for i, ranked := range rankedProposalIDs {
+			voteID := fmt.Sprintf("8b7a6c5d-4e3f-4a2b-9c1d-0e9f8a7b6c%02d", i)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+			receipts = append(receipts, ballotreceipt.Receipt(voteID, electionID, ranked))
+		}
+		receiptRoot, err := ballotreceipt.Root(receipts)
+		require.NoError(t, err)
+
+		commandID := "c4d5e6f7-0812-4a3b-9c4d-5e6f7a8b9c0d"
+		command := election.CloseElectionByOwner{
+			ID:         commandID,
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err = app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		assert.Equal(t, event.ElectionWinnerWasSelected{
+			ElectionID:         electionID,
+			WinningProposalID:  proposalIDs[1],
+			WinningProposalIDs: []string{proposalIDs[1]},
+			ReceiptRoot:        receiptRoot,
+			SelectedAt:         2,
+		}, app.EventDispatcher.GetEvent(1))
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, proposalIDs[1], actualElection.WinningProposalID)
+		assert.Empty(t, actualElection.TabulationRounds)
+	})
+
+	t.Run("breaks tie using election tie-break policy", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "6f1e2d3c-4b5a-4968-a7b6-c5d4e3f2a1b0"
+
+		election1 := electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       1,
+			VotingMethod:    "InstantRunoff",
+			TieBreakPolicy:  "EarliestProposal",
+		}
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+		proposalIDs := []string{
+			"9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c01",
+			"9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c02",
+			"9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c03",
+		}
+		for i, proposalID := range proposalIDs {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+				ProposedAt:  i,
+			}))
+		}
+
+		// proposals 2 and 3 tie on first choices and Borda count, so the later
+		// proposal 3 is eliminated instead of the lexicographically first
+		rankedProposalIDs := [][]string{
+			{proposalIDs[0], proposalIDs[1], proposalIDs[2]},
+			{proposalIDs[0], proposalIDs[2], proposalIDs[1]},
+			{proposalIDs[1], proposalIDs[0], proposalIDs[2]},
+			{proposalIDs[2], proposalIDs[0], proposalIDs[1]},
+		}
+		for i, ranked := range rankedProposalIDs {
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            fmt.Sprintf("0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d%02d", i),
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+		}
+
+		command := election.CloseElectionByOwner{
+			ID:         "2d3e4f5a-6b7c-4d8e-9f0a-1b2c3d4e5f6a",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, proposalIDs[0], actualElection.WinningProposalID)
+		assert.Equal(t, int64(0), actualElection.TieBreakSeed)
+		require.Len(t, actualElection.TabulationRounds, 2)
+		assert.Equal(t, proposalIDs[2], actualElection.TabulationRounds[0].EliminatedProposalID)
+		assert.Equal(t, []string{proposalIDs[1], proposalIDs[2]}, actualElection.TabulationRounds[0].TiedProposalIDs)
+		assert.False(t, actualElection.TabulationRounds[0].UsedBordaTieBreaker)
+	})
+
+	t.Run("counts write-ins by normalized label", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID = "8b9c0d1e-2f3a-4b4c-8d5e-6f7a8b9c0d1e"
+			proposalID = "9c0d1e2f-3a4b-4c5d-9e6f-7a8b9c0d1e2f"
+		)
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       1,
+			VotingMethod:    "InstantRunoff",
+			AllowWriteIns:   true,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		}))
+
+		rankedProposalIDs := [][]string{
+			{writein.New("Jane Doe")},
+			{writein.New(" jane  DOE"), proposalID},
+			{proposalID},
+		}
+		for i, ranked := range rankedProposalIDs {
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            fmt.Sprintf("0d1e2f3a-4b5c-4d6e-8f7a-8b9c0d1e2f%02d", i),
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("1e2f3a4b-5c6d-4e7f-9a8b-9c0d1e2f3a%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+		}
+
+		command := election.CloseElectionByOwner{
+			ID:         "2f3a4b5c-6d7e-4f8a-9b0c-1d2e3f4a5b6c",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		response, err := app.ExecuteQuery(ctx, election.GetElectionResults{
+			ElectionID: electionID,
+		})
+		require.NoError(t, err)
+		results := response.(election.GetElectionResultsResponse)
+		assert.Equal(t, "write-in:jane doe", results.WinningProposalID)
+		assert.Equal(t, []election.WriteIn{
+			{ProposalID: "write-in:jane doe", Label: "jane doe"},
+		}, results.WriteIns)
+		require.Len(t, results.Rounds, 1)
+		assert.Equal(t, []election.ProposalCount{
+			{ProposalID: "write-in:jane doe", Count: 2},
+			{ProposalID: proposalID, Count: 1},
+		}, results.Rounds[0].ProposalCounts)
+	})
+
+	t.Run("counts secret ballots with the votes", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID  = "3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c6d"
+			proposalID1 = "4b5c6d7e-8f9a-4b0c-9d1e-2f3a4b5c6d7e"
+			proposalID2 = "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e8f"
+		)
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       1,
+			VotingMethod:    "InstantRunoff",
+			BallotSecrecy:   electionrepository.BallotSecrecySecret,
+		}))
+		for _, proposalID := range []string{proposalID1, proposalID2} {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}))
+		}
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "6d7e8f9a-0b1c-4d2e-9f3a-4b5c6d7e8f9a",
 			ElectionID:        electionID,
-			OrganizerUserID:   election1.OrganizerUserID,
-			Name:              election1.Name,
-			Description:       election1.Description,
-			WinningProposalID: winningProposalID,
-			IsClosed:          true,
-			CommencedAt:       0,
-			ClosedAt:          2,
-			SelectedAt:        2,
+			UserID:            "7e8f9a0b-1c2d-4e3f-8a4b-5c6d7e8f9a0b",
+			RankedProposalIDs: []string{proposalID1},
+		}))
+		for i := 0; i < 2; i++ {
+			require.NoError(t, app.ElectionRepository.SaveSecretBallot(ctx, electionrepository.SecretBallot{
+				VoteID:            fmt.Sprintf("8f9a0b1c-2d3e-4f4a-9b5c-6d7e8f9a0b%02d", i),
+				ElectionID:        electionID,
+				RankedProposalIDs: []string{proposalID2},
+				BallotTokenHash:   fmt.Sprintf("%064d", i),
+			}))
+		}
+
+		command := election.CloseElectionByOwner{
+			ID:         "9a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		response, err := app.ExecuteQuery(ctx, election.GetElectionResults{
+			ElectionID: electionID,
+		})
+		require.NoError(t, err)
+		results := response.(election.GetElectionResultsResponse)
+		assert.Equal(t, proposalID2, results.WinningProposalID)
+		require.Len(t, results.Rounds, 1)
+		assert.Equal(t, []election.ProposalCount{
+			{ProposalID: proposalID2, Count: 2},
+			{ProposalID: proposalID1, Count: 1},
+		}, results.Rounds[0].ProposalCounts)
+	})
+
+	t.Run("tabulates broken ballot chain when allowed by admin", func(t *testing.T) {
+		// Given
+		const (
+			electionID = "1c4f7a2d-5e8b-4c1f-a3d6-9e2b5c8f1a47"
+			proposalID = "2d5a8b3e-6f9c-4d2a-b4e7-af3c6d9a2b58"
+			voteID1    = "3e6b9c4f-7a0d-4e3b-c5f8-b04d7e0b3c69"
+			voteID2    = "4f7c0d5a-8b1e-4f4c-d6a9-c15e8f1c4d7a"
+		)
+		app := votetest.NewTestApp(t, votetest.WithElectionRepositoryDecorator(tamperVote(voteID2)))
+		ctx := app.GetAuthenticatedAdminContext()
+		saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2)
+
+		commandID := "5a8d1e6b-9c2f-4a5d-e7b0-d26f9a2d5e8b"
+		command := election.CloseElectionByOwner{
+			ID:                     commandID,
+			ElectionID:             electionID,
+			AllowBrokenBallotChain: true,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+
+		logs, err := app.AsyncCommandStore.GetAsyncCommandLogs(ctx, commandID)
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, "Tabulating broken ballot chain, allowed by admin, broken at vote "+voteID2+": vote hash does not match its contents", logs[0].Message)
+	})
+
+	t.Run("closes election without winner when no votes were cast", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "4a5b6c7d-8e9f-4a0b-9c1d-2e3f4a5b6c7d"
+
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+		}))
+
+		commandID := "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e"
+		command := election.CloseElectionByOwner{
+			ID:         commandID,
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		assert.Equal(t, event.ElectionWasClosedByOwner{
+			ElectionID: electionID,
+			OccurredAt: 2,
+		}, app.EventDispatcher.GetEvent(0))
+		assert.Equal(t, event.ElectionClosedWithoutWinner{
+			ElectionID: electionID,
+			Outcome:    electionrepository.OutcomeNoVotes,
+			OccurredAt: 2,
+		}, app.EventDispatcher.GetEvent(1))
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Outcome:         electionrepository.OutcomeNoVotes,
+			IsClosed:        true,
+			ClosedAt:        2,
+			Version:         2,
 		}, actualElection)
 	})
 
+	t.Run("closes election without winner when proposals tie", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID  = "6c7d8e9f-0a1b-4c2d-9e3f-4a5b6c7d8e9f"
+			proposalID1 = "7d8e9f0a-1b2c-4d3e-8f4a-5b6c7d8e9f01"
+			proposalID2 = "7d8e9f0a-1b2c-4d3e-8f4a-5b6c7d8e9f02"
+		)
+
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			VotingMethod:    "Plurality",
+		}))
+		for _, proposalID := range []string{proposalID1, proposalID2} {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			}))
+		}
+		var receipts []string
+		for i, ranked := range [][]string{{proposalID1}, {proposalID2}} {
+			voteID := fmt.Sprintf("8e9f0a1b-2c3d-4e4f-9a5b-6c7d8e9f0a%02d", i)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("9f0a1b2c-3d4e-4f5a-8b6c-7d8e9f0a1b%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+			receipts = append(receipts, ballotreceipt.Receipt(voteID, electionID, ranked))
+		}
+		receiptRoot, err := ballotreceipt.Root(receipts)
+		require.NoError(t, err)
+
+		command := election.CloseElectionByOwner{
+			ID:         "0a1b2c3d-4e5f-4a6b-9c7d-8e9f0a1b2c3d",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err = app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		assert.Equal(t, event.ElectionClosedWithoutWinner{
+			ElectionID:  electionID,
+			Outcome:     electionrepository.OutcomeTie,
+			ReceiptRoot: receiptRoot,
+			OccurredAt:  2,
+		}, app.EventDispatcher.GetEvent(1))
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.True(t, actualElection.IsClosed)
+		assert.Equal(t, electionrepository.OutcomeTie, actualElection.Outcome)
+		assert.Equal(t, "", actualElection.WinningProposalID)
+	})
+
+	t.Run("breaks instant runoff tie with tie-break policy instead of closing with tie", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID  = "6d7e8f9a-0b1c-4d2e-8f3a-4b5c6d7e8f9a"
+			proposalID1 = "7e8f9a0b-1c2d-4e3f-9a4b-5c6d7e8f9a01"
+			proposalID2 = "7e8f9a0b-1c2d-4e3f-9a4b-5c6d7e8f9a02"
+		)
+
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			VotingMethod:    "InstantRunoff",
+			TieBreakPolicy:  "Lexicographic",
+		}))
+		for _, proposalID := range []string{proposalID1, proposalID2} {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			}))
+		}
+
+		// the proposals tie in every count, so only the tie-break policy decides
+		for i, ranked := range [][]string{{proposalID1, proposalID2}, {proposalID2, proposalID1}} {
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            fmt.Sprintf("8f9a0b1c-2d3e-4f4a-8b5c-6d7e8f9a0b%02d", i),
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("9a0b1c2d-3e4f-4a5b-9c6d-7e8f9a0b1c%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+		}
+
+		command := election.CloseElectionByOwner{
+			ID:         "0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d3e",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.OutcomeWinnerSelected, actualElection.Outcome)
+		assert.NotEmpty(t, actualElection.WinningProposalID)
+		require.NotEmpty(t, actualElection.TabulationRounds)
+		assert.Equal(t, []string{proposalID1, proposalID2}, actualElection.TabulationRounds[0].TiedProposalIDs)
+	})
+
+	t.Run("closes election without winner when no proposal reaches a majority", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID  = "1b2c3d4e-5f6a-4b7c-8d8e-9f0a1b2c3d4e"
+			proposalID1 = "2c3d4e5f-6a7b-4c8d-9e9f-0a1b2c3d4e01"
+			proposalID2 = "2c3d4e5f-6a7b-4c8d-9e9f-0a1b2c3d4e02"
+		)
+
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			VotingMethod:    "InstantRunoff",
+		}))
+		for _, proposalID := range []string{proposalID1, proposalID2} {
+			require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+				ElectionID:  electionID,
+				ProposalID:  proposalID,
+				OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			}))
+		}
+
+		// each ballot ranks a single proposal, so the ballot for the eliminated
+		// proposal is exhausted and the remaining proposal lacks a majority
+		for i, ranked := range [][]string{{proposalID1}, {proposalID2}} {
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            fmt.Sprintf("3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f%02d", i),
+				ElectionID:        electionID,
+				UserID:            fmt.Sprintf("4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a%02d", i),
+				RankedProposalIDs: ranked,
+			}))
+		}
+
+		command := election.CloseElectionByOwner{
+			ID:         "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c",
+			ElectionID: electionID,
+		}
+		app.EventDispatcher.Add(2)
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		app.EventDispatcher.Wait(ctx)
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.True(t, actualElection.IsClosed)
+		assert.Equal(t, electionrepository.OutcomeNoMajority, actualElection.Outcome)
+		assert.Equal(t, "", actualElection.WinningProposalID)
+		require.Len(t, actualElection.TabulationRounds, 2)
+		assert.Equal(t, 1, actualElection.TabulationRounds[1].ExhaustedBallots)
+	})
+
+	t.Run("counts a vote recorded after the election was last projected", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID = "9d0e1f2a-3b4c-4d5e-8f6a-7b8c9d0e1f2b"
+			proposalID = "0e1f2a3b-4c5d-4e6f-9a7b-8c9d0e1f2a3c"
+		)
+		_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: app.RegularUserID,
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		})
+		require.NoError(t, err)
+		require.NoError(t, app.EventStore.Append(ctx, electionID, 2, event.VoteWasCast{
+			VoteID:            "1f2a3b4c-5d6e-4f7a-8b8c-9d0e1f2a3b4d",
+			ElectionID:        electionID,
+			UserID:            "2a3b4c5d-6e7f-4a8b-9c9d-0e1f2a3b4c5e",
+			RankedProposalIDs: []string{proposalID},
+			OccurredAt:        1,
+		}))
+
+		commandID := "3b4c5d6e-7f8a-4b9c-8d0e-1f2a3b4c5d6f"
+		command := election.CloseElectionByOwner{
+			ID:         commandID,
+			ElectionID: electionID,
+		}
+
+		// When
+		_, err = app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.True(t, actualElection.IsClosed)
+		assert.Equal(t, proposalID, actualElection.WinningProposalID)
+		assert.Equal(t, 5, actualElection.Version)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		assert.Len(t, votes, 1)
+	})
+
 	t.Run("errors", func(t *testing.T) {
 		t.Run("when election not found during authorization", func(t *testing.T) {
 			// Given
@@ -148,5 +918,103 @@ func TestCloseElectionByOwner(t *testing.T) {
 			// Then
 			require.Equal(t, cqrs.ErrAccessDenied, err)
 		})
+
+		t.Run("when election is already closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			const electionID = "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e"
+
+			election1 := electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+				IsClosed:        true,
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+			commandID := "8c9d0e1f-2a3b-4c4d-ae5f-6a7b8c9d0e1f"
+			command := election.CloseElectionByOwner{
+				ID:         commandID,
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+				return err == nil && status.IsFinished
+			}, time.Second, 10*time.Millisecond)
+
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+			require.NoError(t, err)
+			assert.False(t, status.IsSuccess)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when ballot chain is broken", func(t *testing.T) {
+			// Given
+			const (
+				electionID = "6b9e2f7c-0d3a-4b6e-f8c1-e37a0b3e6f9c"
+				proposalID = "7c0f3a8d-1e4b-4c7f-a9d2-f48b1c4f7a0d"
+				voteID1    = "8d1a4b9e-2f5c-4d8a-bae3-a59c2d5a8b1e"
+				voteID2    = "9e2b5c0f-3a6d-4e9b-cbf4-b6ad3e6b9c2f"
+			)
+			app := votetest.NewTestApp(t, votetest.WithElectionRepositoryDecorator(tamperVote(voteID2)))
+			ctx := app.GetAuthenticatedUserContext()
+			saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2)
+
+			commandID := "af3c6d1a-4b7e-4fac-dc05-c7be4f7cad3a"
+			command := election.CloseElectionByOwner{
+				ID:         commandID,
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+				return err == nil && status.IsFinished
+			}, time.Second, 10*time.Millisecond)
+
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+			require.NoError(t, err)
+			assert.False(t, status.IsSuccess)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+
+			actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+			require.NoError(t, err)
+			assert.False(t, actualElection.IsClosed)
+		})
+
+		t.Run("when organizer allows broken ballot chain", func(t *testing.T) {
+			// Given
+			const (
+				electionID = "b04d7e2b-5c8f-4a0d-ed16-d8cf5a8dbe4b"
+				proposalID = "c15e8f3c-6d9a-4b1e-fe27-e9da6b9ecf5c"
+			)
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID)
+
+			command := election.CloseElectionByOwner{
+				ID:                     "d26f9a4d-7e0b-4c2f-a038-faeb7cafd06d",
+				ElectionID:             electionID,
+				AllowBrokenBallotChain: true,
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
 	})
 }
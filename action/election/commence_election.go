@@ -2,59 +2,211 @@ package election
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/inklabs/cqrs"
 	"github.com/inklabs/cqrs/pkg/clock"
 
 	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/rcv"
 	"github.com/inklabs/vote/pkg/sleep"
 )
 
 // CommenceElection instantiates a new open election that is ready for proposals and voting.
+// SeatCount is the number of winning proposals to elect, defaulting to 1. Elections with
+// more than one seat are tabulated using the Single Transferable Vote (STV) electoral system.
+// VotingMethod selects the single winner tabulator, defaulting to InstantRunoff.
+// TieBreakPolicy selects how InstantRunoff and STV break a tie for elimination: Borda, then
+// the previous rounds' counts (Borda), the first ProposalID (Lexicographic), a seeded
+// draw (Random), or the earliest proposal (EarliestProposal), defaulting to Borda. STV
+// also uses it to choose which of the proposals tied for election is elected first.
+// RevotePolicy determines whether a voter's second ballot is rejected (RejectDuplicate),
+// or replaces their earlier ballot (ReplacePrevious), defaulting to RejectDuplicate.
+// EligibilityPolicy determines whether any user may vote (Open), or only users on the
+// election's eligibility roll (RegisteredVoters), defaulting to Open.
+// BallotSecrecy determines whether ballots are stored with the UserID that cast them
+// (Public), or without any link to the voter (Secret), defaulting to Public. Secret
+// ballots are cast with IssueBallotToken and CastSecretBallot, and cannot be replaced.
+// MaxRanks limits how many proposals a ballot may rank, with no limit by default.
+// RequireCompleteRanking rejects ballots that do not rank every proposal, up to MaxRanks.
+// AllowDuplicateRanks and AllowSkippedRanks accept ballots that rank a proposal more than
+// once, or leave a rank blank, which are otherwise rejected. AllowWriteIns lets voters rank
+// a candidate that is not a proposal, as "write-in:" followed by the candidate's name.
+// ModerateProposals holds new proposals as pending until the organizer approves them.
+// ProposalDeadline, VotingStartsAt, and VotingEndsAt are optional Unix timestamps that
+// schedule the election phases. The election is closed automatically once VotingEndsAt passes.
+// OrganizerUserID must be the authenticated user, unless an admin commences the election.
 type CommenceElection struct {
-	ElectionID      string
-	OrganizerUserID string
-	Name            string
-	Description     string
+	ElectionID             string
+	OrganizerUserID        string
+	Name                   string
+	Description            string
+	SeatCount              *int
+	VotingMethod           *string
+	TieBreakPolicy         *string
+	RevotePolicy           *string
+	EligibilityPolicy      *string
+	BallotSecrecy          *string
+	MaxRanks               *int
+	RequireCompleteRanking bool
+	AllowDuplicateRanks    bool
+	AllowSkippedRanks      bool
+	AllowWriteIns          bool
+	ModerateProposals      bool
+	ProposalDeadline       *int
+	VotingStartsAt         *int
+	VotingEndsAt           *int
+}
+
+func (c CommenceElection) ValidationRules() cqrs.ValidationRuleMap {
+	return cqrs.ValidationRuleMap{
+		"SeatCount":      cqrs.OptionalValidMinRange(1),
+		"MaxRanks":       cqrs.OptionalValidMinRange(1),
+		"VotingMethod":   cqrs.OptionalValidValues(rcv.VotingMethods...),
+		"TieBreakPolicy": cqrs.OptionalValidValues(rcv.TieBreakPolicies...),
+		"RevotePolicy": cqrs.OptionalValidValues(
+			electionrepository.RevotePolicyRejectDuplicate,
+			electionrepository.RevotePolicyReplacePrevious,
+		),
+		"EligibilityPolicy": cqrs.OptionalValidValues(
+			electionrepository.EligibilityPolicyOpen,
+			electionrepository.EligibilityPolicyRegisteredVoters,
+		),
+		"BallotSecrecy": cqrs.OptionalValidValues(
+			electionrepository.BallotSecrecyPublic,
+			electionrepository.BallotSecrecySecret,
+		),
+	}
 }
 
 type commenceElectionHandler struct {
 	repository electionrepository.Repository
+	eventStore eventstore.Store
 	clock      clock.Clock
 }
 
-func NewCommenceElectionHandler(repository electionrepository.Repository, clock clock.Clock) *commenceElectionHandler {
+func NewCommenceElectionHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *commenceElectionHandler {
 	return &commenceElectionHandler{
 		repository: repository,
+		eventStore: eventStore,
 		clock:      clock,
 	}
 }
 
+func (h *commenceElectionHandler) Verify(ctx authorization.Context, cmd CommenceElection) error {
+	return verifyAuthenticatedUser(ctx, cmd.OrganizerUserID)
+}
+
 func (h *commenceElectionHandler) On(ctx context.Context, cmd CommenceElection, eventRaiser cqrs.EventRaiser) error {
 	occurredAt := int(h.clock.Now().Unix())
 
+	seatCount := 1
+	if cmd.SeatCount != nil {
+		seatCount = *cmd.SeatCount
+	}
+
+	votingMethod := rcv.InstantRunoff
+	if cmd.VotingMethod != nil {
+		votingMethod = *cmd.VotingMethod
+	}
+
+	tieBreakPolicy := rcv.TieBreakBorda
+	if cmd.TieBreakPolicy != nil {
+		tieBreakPolicy = *cmd.TieBreakPolicy
+	}
+
+	revotePolicy := electionrepository.RevotePolicyRejectDuplicate
+	if cmd.RevotePolicy != nil {
+		revotePolicy = *cmd.RevotePolicy
+	}
+
+	eligibilityPolicy := electionrepository.EligibilityPolicyOpen
+	if cmd.EligibilityPolicy != nil {
+		eligibilityPolicy = *cmd.EligibilityPolicy
+	}
+
+	ballotSecrecy := electionrepository.BallotSecrecyPublic
+	if cmd.BallotSecrecy != nil {
+		ballotSecrecy = *cmd.BallotSecrecy
+	}
+
+	if seatCount > 1 && votingMethod != rcv.InstantRunoff {
+		return ErrMultiSeatVotingMethod
+	}
+
+	if ballotSecrecy == electionrepository.BallotSecrecySecret && revotePolicy == electionrepository.RevotePolicyReplacePrevious {
+		return ErrSecretBallotRevote
+	}
+
+	maxRanks := valueOrZero(cmd.MaxRanks)
+	proposalDeadline := valueOrZero(cmd.ProposalDeadline)
+	votingStartsAt := valueOrZero(cmd.VotingStartsAt)
+	votingEndsAt := valueOrZero(cmd.VotingEndsAt)
+
+	if votingEndsAt > 0 && votingEndsAt <= max(votingStartsAt, occurredAt) {
+		return ErrInvalidVotingWindow
+	}
+
 	sleep.Rand(2 * time.Millisecond)
 
-	err := h.repository.SaveElection(ctx, electionrepository.Election{
-		ElectionID:      cmd.ElectionID,
-		OrganizerUserID: cmd.OrganizerUserID,
-		Name:            cmd.Name,
-		Description:     cmd.Description,
-		CommencedAt:     occurredAt,
-	})
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
 	if err != nil {
 		return err
 	}
+	defer unlock()
+
+	// A new election's stream is empty, so an ElectionID already in use fails
+	// with ErrVersionConflict.
+	newElection := electionrepository.Election{
+		ElectionID: cmd.ElectionID,
+	}
 
-	eventRaiser.Raise(event.ElectionHasCommenced{
-		ElectionID:      cmd.ElectionID,
-		OrganizerUserID: cmd.OrganizerUserID,
-		Name:            cmd.Name,
-		Description:     cmd.Description,
-		OccurredAt:      occurredAt,
+	err = recordEvents(ctx, h.eventStore, h.repository, eventRaiser, newElection, event.ElectionHasCommenced{
+		ElectionID:             cmd.ElectionID,
+		OrganizerUserID:        cmd.OrganizerUserID,
+		Name:                   cmd.Name,
+		Description:            cmd.Description,
+		SeatCount:              seatCount,
+		VotingMethod:           votingMethod,
+		TieBreakPolicy:         tieBreakPolicy,
+		RevotePolicy:           revotePolicy,
+		EligibilityPolicy:      eligibilityPolicy,
+		BallotSecrecy:          ballotSecrecy,
+		MaxRanks:               maxRanks,
+		RequireCompleteRanking: cmd.RequireCompleteRanking,
+		AllowDuplicateRanks:    cmd.AllowDuplicateRanks,
+		AllowSkippedRanks:      cmd.AllowSkippedRanks,
+		AllowWriteIns:          cmd.AllowWriteIns,
+		ModerateProposals:      cmd.ModerateProposals,
+		ProposalDeadline:       proposalDeadline,
+		VotingStartsAt:         votingStartsAt,
+		VotingEndsAt:           votingEndsAt,
+		OccurredAt:             occurredAt,
 	})
+	if err != nil {
+		return err
+	}
+
+	if ballotSecrecy == electionrepository.BallotSecrecySecret {
+		return saveNewBallotKey(ctx, h.repository, cmd.ElectionID)
+	}
 
 	return nil
 }
+
+func valueOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+var ErrInvalidVotingWindow = errors.New("voting must end after it starts and after the election commences")
+
+var ErrMultiSeatVotingMethod = errors.New("elections with more than one seat require the InstantRunoff voting method")
+
+var ErrSecretBallotRevote = errors.New("secret ballots cannot be replaced; use the RejectDuplicate revote policy")
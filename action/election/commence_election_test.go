@@ -10,6 +10,7 @@ import (
 	"github.com/inklabs/vote/action/election"
 	"github.com/inklabs/vote/event"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
 	"github.com/inklabs/vote/votetest"
 )
 
@@ -21,7 +22,7 @@ func TestCommenceElection(t *testing.T) {
 		const electionID = "6c194c91-bb68-4933-a6ba-7c5867a5f54d"
 		command := election.CommenceElection{
 			ElectionID:      electionID,
-			OrganizerUserID: "73adf147-ce92-4c9f-9f9c-5464210e68da",
+			OrganizerUserID: app.RegularUserID,
 			Name:            "Election Name",
 			Description:     "Election Description",
 		}
@@ -35,11 +36,17 @@ func TestCommenceElection(t *testing.T) {
 			Status: "OK",
 		}, response)
 		assert.Equal(t, event.ElectionHasCommenced{
-			ElectionID:      electionID,
-			OrganizerUserID: command.OrganizerUserID,
-			Name:            command.Name,
-			Description:     command.Description,
-			OccurredAt:      0,
+			ElectionID:        electionID,
+			OrganizerUserID:   command.OrganizerUserID,
+			Name:              command.Name,
+			Description:       command.Description,
+			SeatCount:         1,
+			VotingMethod:      "InstantRunoff",
+			TieBreakPolicy:    "Borda",
+			RevotePolicy:      "RejectDuplicate",
+			EligibilityPolicy: "Open",
+			BallotSecrecy:     "Public",
+			OccurredAt:        0,
 		}, app.EventDispatcher.GetEvent(0))
 
 		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
@@ -49,10 +56,276 @@ func TestCommenceElection(t *testing.T) {
 			OrganizerUserID:   command.OrganizerUserID,
 			Name:              command.Name,
 			Description:       command.Description,
+			SeatCount:         1,
+			VotingMethod:      "InstantRunoff",
+			TieBreakPolicy:    "Borda",
+			RevotePolicy:      "RejectDuplicate",
+			EligibilityPolicy: "Open",
+			BallotSecrecy:     "Public",
 			CommencedAt:       0,
 			WinningProposalID: "",
 			IsClosed:          false,
 			ClosedAt:          0,
+			Version:           1,
 		}, actualElection)
 	})
+
+	t.Run("saves seat count for multi-winner election", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "1a3f2d6c-6d58-4d0b-9a43-2b8b8c4e5f71"
+		command := election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Board Election",
+			Description:     "Elect 3 board members",
+			SeatCount:       cqrs.Int(3),
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, actualElection.SeatCount)
+	})
+
+	t.Run("saves voting method", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "0e9a4b1c-3f7d-4c2a-9d8e-6b5a4c3d2e1f"
+		command := election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			VotingMethod:    cqrs.String("Schulze"),
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, "Schulze", actualElection.VotingMethod)
+	})
+
+	t.Run("saves ballot rules", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "7b9d1f3a-5c7e-4a9b-8d1f-3a5c7e9b1d2f"
+		command := election.CommenceElection{
+			ElectionID:             electionID,
+			OrganizerUserID:        app.RegularUserID,
+			Name:                   "Election Name",
+			Description:            "Election Description",
+			MaxRanks:               cqrs.Int(3),
+			RequireCompleteRanking: true,
+			AllowSkippedRanks:      true,
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, actualElection.MaxRanks)
+		assert.True(t, actualElection.RequireCompleteRanking)
+		assert.False(t, actualElection.AllowDuplicateRanks)
+		assert.True(t, actualElection.AllowSkippedRanks)
+	})
+
+	t.Run("saves tie-break policy", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "9a3c5e7f-1b2d-4f6a-8c0e-2d4f6a8c0e1b"
+		command := election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			TieBreakPolicy:  cqrs.String("Random"),
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, "Random", actualElection.TieBreakPolicy)
+	})
+
+	t.Run("saves schedule", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "4e5f6a7b-8c9d-4e0f-a1b2-c3d4e5f6a7b8"
+		command := election.CommenceElection{
+			ElectionID:       electionID,
+			OrganizerUserID:  app.RegularUserID,
+			Name:             "Election Name",
+			Description:      "Election Description",
+			ProposalDeadline: cqrs.Int(100),
+			VotingStartsAt:   cqrs.Int(200),
+			VotingEndsAt:     cqrs.Int(300),
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, 100, actualElection.ProposalDeadline)
+		assert.Equal(t, 200, actualElection.VotingStartsAt)
+		assert.Equal(t, 300, actualElection.VotingEndsAt)
+	})
+
+	t.Run("generates ballot key for secret ballot election", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+		command := election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+			BallotSecrecy:   cqrs.String("Secret"),
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, "Secret", actualElection.BallotSecrecy)
+		ballotKey, err := app.ElectionRepository.GetBallotKey(ctx, electionID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, ballotKey)
+		assert.Equal(t, "Secret", app.EventDispatcher.GetEvent(0).(event.ElectionHasCommenced).BallotSecrecy)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when organizer is not the authenticated user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.CommenceElection{
+				ElectionID:      "0e1f2a3b-4c5d-4e6f-8a7b-8c9d0e1f2a3b",
+				OrganizerUserID: "73adf147-ce92-4c9f-9f9c-5464210e68da",
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when multi-seat election does not use instant runoff", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.CommenceElection{
+				ElectionID:      "5b7c9d2e-8a4f-4e6b-b1c3-d5e7f9a1b2c3",
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Board Election",
+				Description:     "Elect 3 board members",
+				SeatCount:       cqrs.Int(3),
+				VotingMethod:    cqrs.String("Plurality"),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrMultiSeatVotingMethod, err)
+		})
+
+		t.Run("when voting ends before it starts", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.CommenceElection{
+				ElectionID:      "6c7d8e9f-0a1b-4c2d-b3e4-f5a6b7c8d9e0",
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+				VotingStartsAt:  cqrs.Int(300),
+				VotingEndsAt:    cqrs.Int(200),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrInvalidVotingWindow, err)
+		})
+
+		t.Run("when secret ballots may be replaced", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.CommenceElection{
+				ElectionID:      "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+				RevotePolicy:    cqrs.String("ReplacePrevious"),
+				BallotSecrecy:   cqrs.String("Secret"),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrSecretBallotRevote, err)
+		})
+
+		t.Run("when election already commenced", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.CommenceElection{
+				ElectionID:      "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+			_, err := app.ExecuteCommand(ctx, command)
+			require.NoError(t, err)
+
+			// When
+			_, err = app.ExecuteCommand(ctx, election.CommenceElection{
+				ElectionID:      command.ElectionID,
+				OrganizerUserID: app.RegularUserID,
+				Name:            "Another Election Name",
+			})
+
+			// Then
+			require.Equal(t, eventstore.NewErrVersionConflict(command.ElectionID, 0, 1), err)
+			actualElection, err := app.ElectionRepository.GetElection(ctx, command.ElectionID)
+			require.NoError(t, err)
+			assert.Equal(t, command.Name, actualElection.Name)
+		})
+	})
 }
@@ -0,0 +1,152 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/inklabs/cqrs"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/inklabs/vote/internal/cvr"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+const (
+	CVRFormatJSON = "json"
+	CVRFormatCSV  = "csv"
+)
+
+// ExportBallots returns every counted ballot from a closed election as a Cast Vote Record,
+// so auditors can re-run the tabulation with third-party tools such as RCTab.
+// Format "json" (default) is the NIST CVR Common Data Format, and "csv" has one
+// row per ballot and one rank per column. Voter identities are not exported.
+type ExportBallots struct {
+	ElectionID string
+	Format     *string
+}
+
+func (q ExportBallots) ValidationRules() cqrs.ValidationRuleMap {
+	return cqrs.ValidationRuleMap{
+		"Format": cqrs.OptionalValidValues(CVRFormatJSON, CVRFormatCSV),
+	}
+}
+
+type ExportBallotsResponse struct {
+	ElectionID   string
+	Format       string
+	FileName     string
+	ContentType  string
+	TotalBallots int
+	Content      string
+}
+
+type exportBallotsHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewExportBallotsHandler(repository electionrepository.Repository) *exportBallotsHandler {
+	return &exportBallotsHandler{
+		repository: repository,
+	}
+}
+
+func (h *exportBallotsHandler) On(ctx context.Context, query ExportBallots) (ExportBallotsResponse, error) {
+	ctx, span := tracer.Start(ctx, "vote.export-ballots")
+	defer span.End()
+
+	format := CVRFormatJSON
+	if query.Format != nil {
+		format = *query.Format
+	}
+
+	span.SetAttributes(attribute.String("format", format))
+
+	election, err := h.repository.GetElection(ctx, query.ElectionID)
+	if err != nil {
+		return ExportBallotsResponse{}, err
+	}
+
+	if !election.IsClosed {
+		return ExportBallotsResponse{}, ErrElectionNotClosed
+	}
+
+	votes, err := getCountedBallots(ctx, h.repository, query.ElectionID)
+	if err != nil {
+		return ExportBallotsResponse{}, err
+	}
+
+	ballots := toExportBallots(votes)
+
+	var content []byte
+	var contentType string
+	switch format {
+	case CVRFormatCSV:
+		contentType = "text/csv"
+		content, err = cvr.EncodeCSV(ballots)
+	default:
+		contentType = "application/json"
+		var candidates []cvr.Candidate
+		candidates, err = h.getCandidates(ctx, query.ElectionID)
+		if err != nil {
+			return ExportBallotsResponse{}, err
+		}
+
+		content, err = cvr.EncodeJSON(cvr.Contest{
+			ElectionID:      election.ElectionID,
+			Name:            election.Name,
+			NumberOfWinners: election.SeatCount,
+			Candidates:      candidates,
+			GeneratedAt:     time.Unix(int64(election.SelectedAt), 0),
+		}, ballots)
+	}
+	if err != nil {
+		cqrs.RecordSpanError(span, err)
+		return ExportBallotsResponse{}, err
+	}
+
+	return ExportBallotsResponse{
+		ElectionID:   election.ElectionID,
+		Format:       format,
+		FileName:     "cvr-" + election.ElectionID + "." + format,
+		ContentType:  contentType,
+		TotalBallots: len(ballots),
+		Content:      string(content),
+	}, nil
+}
+
+func (h *exportBallotsHandler) getCandidates(ctx context.Context, electionID string) ([]cvr.Candidate, error) {
+	proposals, err := listAllProposals(ctx, h.repository, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]cvr.Candidate, len(proposals))
+	for i, proposal := range proposals {
+		candidates[i] = cvr.Candidate{
+			ProposalID: proposal.ProposalID,
+			Name:       proposal.Name,
+		}
+	}
+
+	return candidates, nil
+}
+
+// toExportBallots orders ballots by VoteID rather than by submission time, so
+// the position of a ballot in the export cannot be linked back to a voter.
+func toExportBallots(votes []electionrepository.Vote) [][]string {
+	sortedVotes := append([]electionrepository.Vote{}, votes...)
+	sort.Slice(sortedVotes, func(i, j int) bool {
+		return sortedVotes[i].VoteID < sortedVotes[j].VoteID
+	})
+
+	ballots := make([][]string, len(sortedVotes))
+	for i, vote := range sortedVotes {
+		ballots[i] = vote.RankedProposalIDs
+	}
+
+	return ballots
+}
+
+var ErrElectionNotClosed = errors.New("election has not closed")
@@ -0,0 +1,172 @@
+package election_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestExportBallots(t *testing.T) {
+	const (
+		electionID  = "2e7f9a1b-3c4d-4e5f-8a6b-7c8d9e0f1a2b"
+		proposalID1 = "3f8a0b2c-4d5e-4f6a-9b7c-8d9e0f1a2b3c"
+		proposalID2 = "4a9b1c3d-5e6f-4a7b-8c8d-9e0f1a2b3c4d"
+		voteID1     = "b5c6d7e8-f9a0-4b1c-8d2e-3f4a5b6c7d8e"
+		voteID2     = "a4b5c6d7-e8f9-4a0b-9c1d-2e3f4a5b6c7d"
+	)
+
+	seedClosedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository) {
+		election1 := electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: "e8f9a0b1-c2d3-4e4f-9a5b-6c7d8e9f0a1b",
+			Name:            "Election Name",
+			Description:     "Election Description",
+			SeatCount:       1,
+		}
+		require.NoError(t, repository.SaveElection(ctx, election1))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID1,
+			Name:       "Proposal Name 1",
+			ProposedAt: 1,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID2,
+			Name:       "Proposal Name 2",
+			ProposedAt: 2,
+		}))
+		require.NoError(t, repository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            voteID1,
+			ElectionID:        electionID,
+			UserID:            "c6d7e8f9-a0b1-4c2d-9e3f-4a5b6c7d8e9f",
+			RankedProposalIDs: []string{proposalID1, proposalID2},
+			SubmittedAt:       1,
+		}))
+		require.NoError(t, repository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            voteID2,
+			ElectionID:        electionID,
+			UserID:            "d7e8f9a0-b1c2-4d3e-8f4a-5b6c7d8e9f0a",
+			RankedProposalIDs: []string{proposalID2},
+			SubmittedAt:       2,
+		}))
+
+		election1.IsClosed = true
+		election1.ClosedAt = 3
+		election1.SelectedAt = 3
+		election1.WinningProposalID = proposalID2
+		require.NoError(t, repository.SaveElection(ctx, election1))
+	}
+
+	t.Run("exports ballots as csv", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedClosedElection(t, ctx, app.ElectionRepository)
+		query := election.ExportBallots{
+			ElectionID: electionID,
+			Format:     cqrs.String(election.CVRFormatCSV),
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.ExportBallotsResponse{
+			ElectionID:   electionID,
+			Format:       "csv",
+			FileName:     "cvr-" + electionID + ".csv",
+			ContentType:  "text/csv",
+			TotalBallots: 2,
+			Content: "BallotID,Rank 1,Rank 2\n" +
+				"1," + proposalID2 + ",\n" +
+				"2," + proposalID1 + "," + proposalID2 + "\n",
+		}, response)
+	})
+
+	t.Run("exports ballots as NIST CVR json by default", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedClosedElection(t, ctx, app.ElectionRepository)
+		query := election.ExportBallots{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		exportResponse := response.(election.ExportBallotsResponse)
+		assert.Equal(t, "json", exportResponse.Format)
+		assert.Equal(t, "cvr-"+electionID+".json", exportResponse.FileName)
+		assert.Equal(t, "application/json", exportResponse.ContentType)
+		assert.Equal(t, 2, exportResponse.TotalBallots)
+
+		var report struct {
+			Election []struct {
+				Candidate []struct {
+					ID   string `json:"@id"`
+					Name string
+				}
+			}
+			CVR []struct {
+				ElectionId string
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(exportResponse.Content), &report))
+		require.Len(t, report.Election, 1)
+		require.Len(t, report.Election[0].Candidate, 2)
+		assert.Equal(t, proposalID1, report.Election[0].Candidate[0].ID)
+		assert.Equal(t, "Proposal Name 1", report.Election[0].Candidate[0].Name)
+		require.Len(t, report.CVR, 2)
+		assert.Equal(t, electionID, report.CVR[0].ElectionId)
+		assert.NotContains(t, exportResponse.Content, voteID1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when election is not closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID: electionID,
+				Name:       "Election Name",
+			}))
+			query := election.ExportBallots{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, election.ErrElectionNotClosed, err)
+		})
+
+		t.Run("when election is not found", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			query := election.ExportBallots{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionNotFound(electionID), err)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// GetBallotReceipt returns the Receipt for a ballot cast with CastVote, to be kept by the
+// voter and checked with VerifyBallotReceipt once the election closes. Only the voter who
+// cast the ballot, or an admin, may request its receipt.
+type GetBallotReceipt struct {
+	ElectionID string
+	VoteID     string
+}
+
+type GetBallotReceiptResponse struct {
+	ElectionID string
+	VoteID     string
+	Receipt    string
+}
+
+type getBallotReceiptHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewGetBallotReceiptHandler(repository electionrepository.Repository) *getBallotReceiptHandler {
+	return &getBallotReceiptHandler{
+		repository: repository,
+	}
+}
+
+func (h *getBallotReceiptHandler) Verify(ctx authorization.Context, query GetBallotReceipt) error {
+	if ctx.IsAdmin() {
+		return nil
+	}
+
+	vote, err := h.repository.GetVote(ctx.Context(), query.ElectionID, query.VoteID)
+	if err != nil {
+		return err
+	}
+
+	if vote.UserID == "" || vote.UserID != ctx.UserID() {
+		return cqrs.ErrAccessDenied
+	}
+
+	return nil
+}
+
+func (h *getBallotReceiptHandler) On(ctx context.Context, query GetBallotReceipt) (GetBallotReceiptResponse, error) {
+	vote, err := h.repository.GetVote(ctx, query.ElectionID, query.VoteID)
+	if err != nil {
+		return GetBallotReceiptResponse{}, err
+	}
+
+	return GetBallotReceiptResponse{
+		ElectionID: vote.ElectionID,
+		VoteID:     vote.VoteID,
+		Receipt:    ballotreceipt.Receipt(vote.VoteID, vote.ElectionID, vote.RankedProposalIDs),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestGetBallotReceipt(t *testing.T) {
+	const (
+		electionID = "5e2a7c1d-3b4f-4a6e-9c8d-7f1e2a3b4c5d"
+		proposalID = "6f3b8d2e-4c5a-4b7f-8d9e-8a2f3b4c5d6e"
+		voteID     = "7a4c9e3f-5d6b-4c8a-9e0f-9b3a4c5d6e7f"
+	)
+
+	saveElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: "1b207fbf-9797-4bfa-91e3-6b5eef1b9fc0",
+			Name:            "Election Name",
+			Description:     "Election Description",
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		}))
+	}
+
+	t.Run("returns receipt to the voter", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		saveElection(t, ctx, app.ElectionRepository)
+		_, err := app.ExecuteCommand(ctx, election.CastVote{
+			VoteID:            voteID,
+			ElectionID:        electionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.NoError(t, err)
+		voteWasCast := app.EventDispatcher.GetEvent(0).(event.VoteWasCast)
+		query := election.GetBallotReceipt{
+			ElectionID: electionID,
+			VoteID:     voteID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.GetBallotReceiptResponse{
+			ElectionID: electionID,
+			VoteID:     voteID,
+			Receipt:    voteWasCast.Receipt,
+		}, response)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when vote was cast by another user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveElection(t, ctx, app.ElectionRepository)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            voteID,
+				ElectionID:        electionID,
+				UserID:            "2e1d4b5f-8063-4c2c-9b7f-0d3e5f607b02",
+				RankedProposalIDs: []string{proposalID},
+			}))
+			query := election.GetBallotReceipt{
+				ElectionID: electionID,
+				VoteID:     voteID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+
+		t.Run("when vote is not found", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveElection(t, ctx, app.ElectionRepository)
+			query := election.GetBallotReceipt{
+				ElectionID: electionID,
+				VoteID:     voteID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrVoteNotFound(voteID), err)
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package election
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// GetBallotToken returns the base64 encoded PublicKey (PKIX, ASN.1 DER) used to blind
+// ballot tokens for an election with secret ballots, along with the BlindSignature
+// issued to UserID by IssueBallotToken. BlindSignature is empty until a token has been
+// issued. UserID must be the authenticated user, unless an admin requests the token.
+type GetBallotToken struct {
+	ElectionID string
+	UserID     string
+}
+
+type GetBallotTokenResponse struct {
+	ElectionID     string
+	PublicKey      string
+	BlindSignature string
+}
+
+type getBallotTokenHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewGetBallotTokenHandler(repository electionrepository.Repository) *getBallotTokenHandler {
+	return &getBallotTokenHandler{
+		repository: repository,
+	}
+}
+
+func (h *getBallotTokenHandler) Verify(ctx authorization.Context, query GetBallotToken) error {
+	return verifyAuthenticatedUser(ctx, query.UserID)
+}
+
+func (h *getBallotTokenHandler) On(ctx context.Context, query GetBallotToken) (GetBallotTokenResponse, error) {
+	election, err := h.repository.GetElection(ctx, query.ElectionID)
+	if err != nil {
+		return GetBallotTokenResponse{}, err
+	}
+
+	if election.BallotSecrecy != electionrepository.BallotSecrecySecret {
+		return GetBallotTokenResponse{}, ErrNotSecretBallot
+	}
+
+	privateKey, err := getBallotKey(ctx, h.repository, query.ElectionID)
+	if err != nil {
+		return GetBallotTokenResponse{}, err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return GetBallotTokenResponse{}, fmt.Errorf("unable to encode ballot public key: %w", err)
+	}
+
+	response := GetBallotTokenResponse{
+		ElectionID: query.ElectionID,
+		PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+	}
+
+	ballotToken, err := h.repository.GetBallotToken(ctx, query.ElectionID, query.UserID)
+	if err != nil {
+		var errBallotTokenNotFound *electionrepository.ErrBallotTokenNotFound
+		if errors.As(err, &errBallotTokenNotFound) {
+			return response, nil
+		}
+
+		return GetBallotTokenResponse{}, err
+	}
+
+	response.BlindSignature = base64.StdEncoding.EncodeToString(ballotToken.BlindSignature)
+
+	return response, nil
+}
@@ -0,0 +1,111 @@
+package election_test
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/blindsig"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestGetBallotToken(t *testing.T) {
+	const electionID = "9d6ecf5a-8b1c-4d4e-8f6a-9b2c5d8e1f4a"
+
+	t.Run("returns public key before token is issued", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		privateKey := saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+		query := election.GetBallotToken{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, election.GetBallotTokenResponse{
+			ElectionID: electionID,
+			PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+		}, response)
+	})
+
+	t.Run("returns issued blind signature", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		privateKey := saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+		blindedToken, _, err := blindsig.Blind(rand.Reader, &privateKey.PublicKey, []byte("ae7fd06b9c2d4e5f9a7b0c3d6e9f2a5b"))
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.IssueBallotToken{
+			ElectionID:   electionID,
+			UserID:       app.RegularUserID,
+			BlindedToken: base64.StdEncoding.EncodeToString(blindedToken),
+		})
+		require.NoError(t, err)
+		query := election.GetBallotToken{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		expectedBlindSignature, err := blindsig.Sign(privateKey, blindedToken)
+		require.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(expectedBlindSignature), response.(election.GetBallotTokenResponse).BlindSignature)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not the authenticated user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			query := election.GetBallotToken{
+				ElectionID: electionID,
+				UserID:     "bf80e17c-ad3e-4f6a-8b8c-1d4e7f0a3b6c",
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+
+		t.Run("when election does not use secret ballots", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+			}))
+			query := election.GetBallotToken{
+				ElectionID: electionID,
+				UserID:     app.RegularUserID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, election.ErrNotSecretBallot, err)
+		})
+	})
+}
@@ -12,15 +12,31 @@ type GetElection struct {
 }
 
 type GetElectionResponse struct {
-	ElectionID        string
-	OrganizerUserID   string
-	Name              string
-	Description       string
-	WinningProposalID string
-	IsClosed          bool
-	CommencedAt       int
-	ClosedAt          int
-	SelectedAt        int
+	ElectionID             string
+	OrganizerUserID        string
+	Name                   string
+	Description            string
+	SeatCount              int
+	VotingMethod           string
+	MaxRanks               int
+	RequireCompleteRanking bool
+	AllowDuplicateRanks    bool
+	AllowSkippedRanks      bool
+	AllowWriteIns          bool
+	ModerateProposals      bool
+	ProposalDeadline       int
+	VotingStartsAt         int
+	VotingEndsAt           int
+	WinningProposalID      string
+	WinningProposalIDs     []string
+	Outcome                string
+	IsClosed               bool
+	CommencedAt            int
+	ClosedAt               int
+	SelectedAt             int
+	IsCancelled            bool
+	CancelledAt            int
+	CancellationReason     string
 }
 
 type getElectionHandler struct {
@@ -40,14 +56,30 @@ func (h *getElectionHandler) On(ctx context.Context, query GetElection) (GetElec
 	}
 
 	return GetElectionResponse{
-		ElectionID:        election.ElectionID,
-		OrganizerUserID:   election.OrganizerUserID,
-		Name:              election.Name,
-		Description:       election.Description,
-		WinningProposalID: election.WinningProposalID,
-		IsClosed:          election.IsClosed,
-		CommencedAt:       election.CommencedAt,
-		ClosedAt:          election.ClosedAt,
-		SelectedAt:        election.SelectedAt,
+		ElectionID:             election.ElectionID,
+		OrganizerUserID:        election.OrganizerUserID,
+		Name:                   election.Name,
+		Description:            election.Description,
+		SeatCount:              election.SeatCount,
+		VotingMethod:           election.VotingMethod,
+		MaxRanks:               election.MaxRanks,
+		RequireCompleteRanking: election.RequireCompleteRanking,
+		AllowDuplicateRanks:    election.AllowDuplicateRanks,
+		AllowSkippedRanks:      election.AllowSkippedRanks,
+		AllowWriteIns:          election.AllowWriteIns,
+		ModerateProposals:      election.ModerateProposals,
+		ProposalDeadline:       election.ProposalDeadline,
+		VotingStartsAt:         election.VotingStartsAt,
+		VotingEndsAt:           election.VotingEndsAt,
+		WinningProposalID:      election.WinningProposalID,
+		WinningProposalIDs:     election.WinningProposalIDs,
+		Outcome:                election.Outcome,
+		IsClosed:               election.IsClosed,
+		CommencedAt:            election.CommencedAt,
+		ClosedAt:               election.ClosedAt,
+		SelectedAt:             election.SelectedAt,
+		IsCancelled:            election.IsCancelled,
+		CancelledAt:            election.CancelledAt,
+		CancellationReason:     election.CancellationReason,
 	}, nil
 }
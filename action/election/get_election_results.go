@@ -2,19 +2,64 @@ package election
 
 import (
 	"context"
+	"sort"
 
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/writein"
 )
 
-// GetElectionResults returns the results of an election.
+// GetElectionResults returns the results of an election, including the
+// round-by-round tabulation used to select the winning proposal, and the
+// ReceiptRoot over every counted ballot receipt. TieBreakPolicy and TieBreakSeed
+// are enough to reproduce how each tie for elimination was broken. Write-in
+// candidates are counted by their normalized label, and listed in WriteIns.
+// Outcome is WinnerSelected, or why a closed election has no winner: NoVotes,
+// NoMajority, or Tie.
 type GetElectionResults struct {
 	ElectionID string
 }
 
 type GetElectionResultsResponse struct {
-	ElectionID        string
-	WinningProposalID string
-	SelectedAt        int
+	ElectionID         string
+	VotingMethod       string
+	TieBreakPolicy     string
+	TieBreakSeed       int64
+	WinningProposalID  string
+	WinningProposalIDs []string
+	Outcome            string
+	SelectedAt         int
+	ReceiptRoot        string
+	Rounds             []TabulationRound
+	WriteIns           []WriteIn
+}
+
+// WriteIn is a write-in candidate that received votes. ProposalID is how the
+// write-in appears in Rounds and the winning ProposalIDs.
+type WriteIn struct {
+	ProposalID string
+	Label      string
+}
+
+// TabulationRound reports the vote count of each remaining proposal in a
+// single round, and which proposal was eliminated before the next round.
+// TiedProposalIDs lists the proposals tied for elimination, if any. Elections
+// with more than one seat also report the proposals elected in the round, the
+// surplus TransferValue of their ballots, and each proposal's WeightedCount.
+type TabulationRound struct {
+	Number               int
+	ProposalCounts       []ProposalCount
+	EliminatedProposalID string
+	ElectedProposalIDs   []string
+	TransferValue        float64
+	UsedBordaTieBreaker  bool
+	TiedProposalIDs      []string
+	ExhaustedBallots     int
+}
+
+type ProposalCount struct {
+	ProposalID    string
+	Count         int
+	WeightedCount float64
 }
 
 type getElectionResultsHandler struct {
@@ -34,8 +79,81 @@ func (h *getElectionResultsHandler) On(ctx context.Context, query GetElectionRes
 	}
 
 	return GetElectionResultsResponse{
-		ElectionID:        election.ElectionID,
-		WinningProposalID: election.WinningProposalID,
-		SelectedAt:        election.SelectedAt,
+		ElectionID:         election.ElectionID,
+		VotingMethod:       election.VotingMethod,
+		TieBreakPolicy:     election.TieBreakPolicy,
+		TieBreakSeed:       election.TieBreakSeed,
+		WinningProposalID:  election.WinningProposalID,
+		WinningProposalIDs: election.WinningProposalIDs,
+		Outcome:            election.Outcome,
+		SelectedAt:         election.SelectedAt,
+		ReceiptRoot:        election.ReceiptRoot,
+		Rounds:             ToTabulationRounds(election.TabulationRounds),
+		WriteIns:           toWriteIns(election),
 	}, nil
 }
+
+// toWriteIns returns the write-in candidates in the tabulation rounds or among
+// the winners, sorted by label.
+func toWriteIns(election electionrepository.Election) []WriteIn {
+	isWriteIn := make(map[string]struct{})
+	var writeIns []WriteIn
+
+	addWriteIn := func(proposalID string) {
+		if _, ok := isWriteIn[proposalID]; ok || !writein.Is(proposalID) {
+			return
+		}
+
+		isWriteIn[proposalID] = struct{}{}
+		writeIns = append(writeIns, WriteIn{
+			ProposalID: proposalID,
+			Label:      writein.Label(proposalID),
+		})
+	}
+
+	for _, proposalID := range election.WinningProposalIDs {
+		addWriteIn(proposalID)
+	}
+
+	for _, round := range election.TabulationRounds {
+		for _, proposalCount := range round.ProposalCounts {
+			addWriteIn(proposalCount.ProposalID)
+		}
+	}
+
+	sort.Slice(writeIns, func(i, j int) bool {
+		return writeIns[i].Label < writeIns[j].Label
+	})
+
+	return writeIns
+}
+
+func ToTabulationRounds(repoRounds []electionrepository.TabulationRound) []TabulationRound {
+	rounds := make([]TabulationRound, len(repoRounds))
+	for i := range repoRounds {
+		rounds[i] = ToTabulationRound(repoRounds[i])
+	}
+	return rounds
+}
+
+func ToTabulationRound(round electionrepository.TabulationRound) TabulationRound {
+	proposalCounts := make([]ProposalCount, len(round.ProposalCounts))
+	for i, proposalCount := range round.ProposalCounts {
+		proposalCounts[i] = ProposalCount{
+			ProposalID:    proposalCount.ProposalID,
+			Count:         proposalCount.Count,
+			WeightedCount: proposalCount.WeightedCount,
+		}
+	}
+
+	return TabulationRound{
+		Number:               round.Number,
+		ProposalCounts:       proposalCounts,
+		EliminatedProposalID: round.EliminatedProposalID,
+		ElectedProposalIDs:   round.ElectedProposalIDs,
+		TransferValue:        round.TransferValue,
+		UsedBordaTieBreaker:  round.UsedBordaTieBreaker,
+		TiedProposalIDs:      round.TiedProposalIDs,
+		ExhaustedBallots:     round.ExhaustedBallots,
+	}
+}
@@ -19,6 +19,8 @@ func TestGetElectionResults(t *testing.T) {
 		const (
 			electionID        = "ef18565e-eba3-43ed-964e-40d872568f0a"
 			winningProposalID = "35d414ea-4b5f-430a-9f57-ef48bce34ef2"
+			losingProposalID1 = "0f0d4bd9-3f4c-4a26-9a49-1c7e7b0c2f19"
+			losingProposalID2 = "c8f6b2a1-1f5e-4f0e-8d63-7d3a4f8f3b2e"
 		)
 
 		election1 := electionrepository.Election{
@@ -31,6 +33,27 @@ func TestGetElectionResults(t *testing.T) {
 			CommencedAt:       0,
 			SelectedAt:        1,
 			ClosedAt:          1,
+			ReceiptRoot:       "5967f3060a78ffa92094f0988eb474d0e624ba8fb6a8acb7498fba6093991599",
+			TieBreakPolicy:    "Random",
+			TieBreakSeed:      6442384999247839145,
+			TabulationRounds: []electionrepository.TabulationRound{
+				{
+					Number: 1,
+					ProposalCounts: []electionrepository.ProposalCount{
+						{ProposalID: winningProposalID, Count: 2},
+						{ProposalID: losingProposalID1, Count: 2},
+						{ProposalID: losingProposalID2, Count: 1},
+					},
+					EliminatedProposalID: losingProposalID2,
+				},
+				{
+					Number: 2,
+					ProposalCounts: []electionrepository.ProposalCount{
+						{ProposalID: winningProposalID, Count: 3},
+						{ProposalID: losingProposalID1, Count: 2},
+					},
+				},
+			},
 		}
 		require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
 
@@ -46,7 +69,58 @@ func TestGetElectionResults(t *testing.T) {
 		assert.Equal(t, election.GetElectionResultsResponse{
 			ElectionID:        electionID,
 			WinningProposalID: winningProposalID,
+			TieBreakPolicy:    "Random",
+			TieBreakSeed:      election1.TieBreakSeed,
 			SelectedAt:        1,
+			ReceiptRoot:       election1.ReceiptRoot,
+			Rounds: []election.TabulationRound{
+				{
+					Number: 1,
+					ProposalCounts: []election.ProposalCount{
+						{ProposalID: winningProposalID, Count: 2},
+						{ProposalID: losingProposalID1, Count: 2},
+						{ProposalID: losingProposalID2, Count: 1},
+					},
+					EliminatedProposalID: losingProposalID2,
+				},
+				{
+					Number: 2,
+					ProposalCounts: []election.ProposalCount{
+						{ProposalID: winningProposalID, Count: 3},
+						{ProposalID: losingProposalID1, Count: 2},
+					},
+				},
+			},
+		}, response)
+	})
+
+	t.Run("returns outcome of election closed without winner", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const electionID = "a4b5c6d7-e8f9-4a0b-8c1d-2e3f4a5b6c7d"
+
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: "1b207fbf-9797-4bfa-91e3-6b5eef1b9fc0",
+			Outcome:         electionrepository.OutcomeNoVotes,
+			IsClosed:        true,
+			ClosedAt:        1,
+		}))
+
+		query := election.GetElectionResults{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.GetElectionResultsResponse{
+			ElectionID: electionID,
+			Outcome:    electionrepository.OutcomeNoVotes,
+			Rounds:     []election.TabulationRound{},
 		}, response)
 	})
 
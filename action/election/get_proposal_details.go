@@ -6,18 +6,22 @@ import (
 	"github.com/inklabs/vote/internal/electionrepository"
 )
 
-// GetProposalDetails returns the full details of a Proposal.
+// GetProposalDetails returns the full details of a Proposal, including proposals
+// that are withdrawn or awaiting moderation.
 type GetProposalDetails struct {
 	ProposalID string
 }
 
 type GetProposalDetailsResponse struct {
-	ElectionID  string
-	ProposalID  string
-	OwnerUserID string
-	Name        string
-	Description string
-	ProposedAt  int
+	ElectionID       string
+	ProposalID       string
+	OwnerUserID      string
+	Name             string
+	Description      string
+	ProposedAt       int
+	IsWithdrawn      bool
+	ModerationStatus string
+	RejectionReason  string
 }
 
 type getProposalDetailsHandler struct {
@@ -37,11 +41,14 @@ func (h *getProposalDetailsHandler) On(ctx context.Context, query GetProposalDet
 	}
 
 	return GetProposalDetailsResponse{
-		ElectionID:  proposal.ElectionID,
-		ProposalID:  proposal.ProposalID,
-		OwnerUserID: proposal.OwnerUserID,
-		Name:        proposal.Name,
-		Description: proposal.Description,
-		ProposedAt:  proposal.ProposedAt,
+		ElectionID:       proposal.ElectionID,
+		ProposalID:       proposal.ProposalID,
+		OwnerUserID:      proposal.OwnerUserID,
+		Name:             proposal.Name,
+		Description:      proposal.Description,
+		ProposedAt:       proposal.ProposedAt,
+		IsWithdrawn:      proposal.IsWithdrawn,
+		ModerationStatus: proposal.ModerationStatus,
+		RejectionReason:  proposal.RejectionReason,
 	}, nil
 }
@@ -48,12 +48,13 @@ func TestGetProposalDetails(t *testing.T) {
 		// Then
 		require.NoError(t, err)
 		assert.Equal(t, election.GetProposalDetailsResponse{
-			ElectionID:  proposal1.ElectionID,
-			ProposalID:  proposal1.ProposalID,
-			OwnerUserID: proposal1.OwnerUserID,
-			Name:        proposal1.Name,
-			Description: proposal1.Description,
-			ProposedAt:  proposal1.ProposedAt,
+			ElectionID:       proposal1.ElectionID,
+			ProposalID:       proposal1.ProposalID,
+			OwnerUserID:      proposal1.OwnerUserID,
+			Name:             proposal1.Name,
+			Description:      proposal1.Description,
+			ProposedAt:       proposal1.ProposedAt,
+			ModerationStatus: electionrepository.ModerationStatusApproved,
 		}, response)
 	})
 
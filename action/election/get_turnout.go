@@ -0,0 +1,63 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// GetTurnout returns the number of users on an election's eligibility roll and
+// the number of ballots cast. TurnoutPercentage is only reported for elections
+// whose EligibilityPolicy is RegisteredVoters, as any user may vote in an Open
+// election.
+type GetTurnout struct {
+	ElectionID string
+}
+
+type GetTurnoutResponse struct {
+	ElectionID        string
+	EligibilityPolicy string
+	TotalEligible     int
+	TotalVoted        int
+	TurnoutPercentage float64
+}
+
+type getTurnoutHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewGetTurnoutHandler(repository electionrepository.Repository) *getTurnoutHandler {
+	return &getTurnoutHandler{
+		repository: repository,
+	}
+}
+
+func (h *getTurnoutHandler) On(ctx context.Context, query GetTurnout) (GetTurnoutResponse, error) {
+	election, err := h.repository.GetElection(ctx, query.ElectionID)
+	if err != nil {
+		return GetTurnoutResponse{}, err
+	}
+
+	turnout, err := h.repository.GetTurnout(ctx, query.ElectionID)
+	if err != nil {
+		return GetTurnoutResponse{}, err
+	}
+
+	eligibilityPolicy := election.EligibilityPolicy
+	if eligibilityPolicy == "" {
+		eligibilityPolicy = electionrepository.EligibilityPolicyOpen
+	}
+
+	var turnoutPercentage float64
+	if eligibilityPolicy == electionrepository.EligibilityPolicyRegisteredVoters && turnout.TotalEligible > 0 {
+		turnoutPercentage = 100 * float64(turnout.TotalVoted) / float64(turnout.TotalEligible)
+	}
+
+	return GetTurnoutResponse{
+		ElectionID:        turnout.ElectionID,
+		EligibilityPolicy: eligibilityPolicy,
+		TotalEligible:     turnout.TotalEligible,
+		TotalVoted:        turnout.TotalVoted,
+		TurnoutPercentage: turnoutPercentage,
+	}, nil
+}
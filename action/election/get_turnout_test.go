@@ -0,0 +1,126 @@
+package election_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestGetTurnout(t *testing.T) {
+	const (
+		electionID = "f25e1921-be6a-4b7c-9dae-6f7a8b9cadbe"
+		proposalID = "036f2a32-cf7b-4c8d-8ebf-7a8b9cadbecf"
+		userID1    = "14703b43-d08c-4d9e-9fc0-8b9cadbecfd0"
+		userID2    = "25814c54-e19d-4eaf-80d1-9cadbecfd0e1"
+	)
+
+	t.Run("returns eligible and voted counts", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveEligibleVoters(ctx, []electionrepository.EligibleVoter{
+			{ElectionID: electionID, UserID: userID1},
+			{ElectionID: electionID, UserID: userID2},
+		}))
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "36925d65-f2ae-4fb0-91e2-adbecfd0e1f2",
+			ElectionID:        electionID,
+			UserID:            userID1,
+			RankedProposalIDs: []string{proposalID},
+		}))
+		query := election.GetTurnout{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.GetTurnoutResponse{
+			ElectionID:        electionID,
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+			TotalEligible:     2,
+			TotalVoted:        1,
+			TurnoutPercentage: 50,
+		}, response)
+	})
+
+	t.Run("counts every imported ballot and one ballot per voter", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveVotes(ctx, []electionrepository.Vote{
+			{
+				VoteID:            "47a36e76-03bf-4ac1-82f3-becfd0e1f2a4",
+				ElectionID:        electionID,
+				RankedProposalIDs: []string{proposalID},
+				IsImported:        true,
+			},
+			{
+				VoteID:            "58b47f87-14c0-4bd2-93a4-cfd0e1f2a3b5",
+				ElectionID:        electionID,
+				RankedProposalIDs: []string{proposalID},
+				IsImported:        true,
+			},
+		}))
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "69c58098-25d1-4ce3-84b5-d0e1f2a3b4c6",
+			ElectionID:        electionID,
+			RankedProposalIDs: []string{proposalID},
+		}))
+		err := app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "7ad691a9-36e2-4df4-95c6-e1f2a3b4c5d7",
+			ElectionID:        electionID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.Equal(t, electionrepository.NewErrDuplicateVote(electionID, ""), err)
+		query := election.GetTurnout{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 3, response.(election.GetTurnoutResponse).TotalVoted)
+	})
+
+	t.Run("errors when election not found", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		query := election.GetTurnout{
+			ElectionID: electionID,
+		}
+
+		// When
+		_, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.Equal(t, electionrepository.NewErrElectionNotFound(electionID), err)
+	})
+}
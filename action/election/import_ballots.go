@@ -0,0 +1,265 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/cvr"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/rcv"
+	"github.com/inklabs/vote/internal/writein"
+)
+
+// ImportBallots is an asynchronous command that imports ballots cast outside
+// of this application, such as paper ballots, into an existing election so they
+// can be tabulated. Content is a CSV file, or a NIST CVR Common Data Format JSON
+// file (default), in the formats returned by ExportBallots. Candidates may be
+// given by proposal name, ProposalID, or as a write-in. Each ballot is validated
+// with the same rules as CastVote, and invalid ballots are reported in the command
+// logs and skipped. Valid ballots are saved together as anonymous votes with
+// IsImported set, which exempts them from the eligibility roll and the one ballot
+// per UserID rule. Only the election organizer, or an admin, may import ballots.
+type ImportBallots struct {
+	ID         string
+	ElectionID string
+	Format     *string
+	Content    string
+}
+
+func (c ImportBallots) ValidationRules() cqrs.ValidationRuleMap {
+	return cqrs.ValidationRuleMap{
+		"Format": cqrs.OptionalValidValues(CVRFormatJSON, CVRFormatCSV),
+	}
+}
+
+type importBallotsHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewImportBallotsHandler(
+	repository electionrepository.Repository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+) *importBallotsHandler {
+	return &importBallotsHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *importBallotsHandler) Verify(ctx authorization.Context, cmd ImportBallots) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *importBallotsHandler) On(ctx context.Context, cmd ImportBallots, eventRaiser cqrs.EventRaiser, logger cqrs.AsyncCommandLogger) error {
+	ctx, span := tracer.Start(ctx, "vote.import-ballots")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		logger.LogError("election not found: %s", cmd.ElectionID)
+		return err
+	}
+
+	if election.IsClosed {
+		logger.LogError("election already closed: %s", cmd.ElectionID)
+		return electionrepository.NewErrElectionClosed(cmd.ElectionID)
+	}
+
+	if election.VotingStartsAt > 0 && occurredAt < election.VotingStartsAt {
+		logger.LogError("voting has not started")
+		return ErrVotingNotStarted
+	}
+
+	if election.VotingEndsAt > 0 && occurredAt >= election.VotingEndsAt {
+		logger.LogError("voting has ended")
+		return ErrVotingEnded
+	}
+
+	ballots, err := decodeBallots(cmd)
+	if err != nil {
+		logger.LogError("unable to read ballots: %s", err)
+		cqrs.RecordSpanError(span, err)
+		return err
+	}
+
+	proposals, err := listAllProposals(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	proposalIDs := newProposalIDLookup(proposals)
+	ballotRules := getBallotRules(election)
+
+	logger.SetTotalToProcess(len(ballots))
+
+	var votes []electionrepository.Vote
+	for _, ballot := range ballots {
+		rankedProposalIDs, err := proposalIDs.rankedProposalIDs(ballot.Choices, ballotRules.AllowDuplicateRanks)
+		if err == nil {
+			err = ballotRules.Validate(rankedProposalIDs, getProposalIDs(proposals))
+		}
+		logger.IncrementTotalProcessed()
+		if err != nil {
+			logger.LogError("row %d: %s", ballot.Row, err)
+			continue
+		}
+
+		votes = append(votes, electionrepository.Vote{
+			VoteID:            uuid.NewString(),
+			ElectionID:        cmd.ElectionID,
+			RankedProposalIDs: rankedProposalIDs,
+			SubmittedAt:       occurredAt,
+			IsImported:        true,
+		})
+	}
+
+	logger.Flush()
+
+	if len(votes) == 0 {
+		logger.LogError("no valid ballots found")
+		return ErrNoValidBallots
+	}
+
+	err = h.repository.ValidateVotes(ctx, votes)
+	if err != nil {
+		logger.LogError("unable to save ballots: %s", err)
+		cqrs.RecordSpanError(span, err)
+		return err
+	}
+
+	events := make([]cqrs.Event, len(votes))
+	for i, vote := range votes {
+		events[i] = event.VoteWasCast{
+			VoteID:            vote.VoteID,
+			ElectionID:        vote.ElectionID,
+			RankedProposalIDs: append([]string{}, vote.RankedProposalIDs...),
+			Receipt:           ballotreceipt.Receipt(vote.VoteID, vote.ElectionID, vote.RankedProposalIDs),
+			IsImported:        true,
+			OccurredAt:        occurredAt,
+		}
+	}
+
+	err = recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, events...)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo("Imported %d of %d ballots", len(votes), len(ballots))
+
+	return nil
+}
+
+func decodeBallots(cmd ImportBallots) ([]cvr.Ballot, error) {
+	if cmd.Format != nil && *cmd.Format == CVRFormatCSV {
+		return cvr.DecodeCSV([]byte(cmd.Content))
+	}
+
+	return cvr.DecodeJSON([]byte(cmd.Content))
+}
+
+// proposalIDLookup maps candidate names and ProposalIDs to the ProposalIDs of an
+// election. Names are matched without regard to case or surrounding whitespace.
+type proposalIDLookup struct {
+	byProposalID   map[string]struct{}
+	byName         map[string]string
+	ambiguousNames map[string]struct{}
+}
+
+func newProposalIDLookup(proposals []electionrepository.Proposal) proposalIDLookup {
+	lookup := proposalIDLookup{
+		byProposalID:   make(map[string]struct{}, len(proposals)),
+		byName:         make(map[string]string, len(proposals)),
+		ambiguousNames: make(map[string]struct{}),
+	}
+
+	for _, proposal := range proposals {
+		lookup.byProposalID[proposal.ProposalID] = struct{}{}
+
+		name := normalizeCandidateName(proposal.Name)
+		if _, ok := lookup.byName[name]; ok {
+			lookup.ambiguousNames[name] = struct{}{}
+		}
+		lookup.byName[name] = proposal.ProposalID
+	}
+
+	return lookup
+}
+
+// rankedProposalIDs returns the ProposalIDs for the ranked candidates on a ballot.
+// A candidate ranked more than once is rejected, unless allowDuplicateRanks.
+func (l proposalIDLookup) rankedProposalIDs(choices []string, allowDuplicateRanks bool) ([]string, error) {
+	if len(choices) == 0 {
+		return nil, ErrEmptyBallot
+	}
+
+	rankedProposalIDs := make([]string, 0, len(choices))
+	isRanked := make(map[string]struct{}, len(choices))
+
+	for _, choice := range choices {
+		proposalID, err := l.proposalID(choice)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := isRanked[proposalID]; ok && !allowDuplicateRanks {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateRanking, choice)
+		}
+
+		isRanked[proposalID] = struct{}{}
+		rankedProposalIDs = append(rankedProposalIDs, proposalID)
+	}
+
+	return rankedProposalIDs, nil
+}
+
+func (l proposalIDLookup) proposalID(choice string) (string, error) {
+	if _, ok := l.byProposalID[choice]; ok || writein.Is(choice) {
+		return choice, nil
+	}
+
+	name := normalizeCandidateName(choice)
+	if _, ok := l.ambiguousNames[name]; ok {
+		return "", fmt.Errorf("%w: %s", ErrAmbiguousCandidate, choice)
+	}
+
+	proposalID, ok := l.byName[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCandidate, choice)
+	}
+
+	return proposalID, nil
+}
+
+func normalizeCandidateName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+var (
+	ErrNoValidBallots     = errors.New("no valid ballots found")
+	ErrEmptyBallot        = rcv.ErrEmptyBallot
+	ErrDuplicateRanking   = rcv.ErrDuplicateRanking
+	ErrUnknownCandidate   = errors.New("candidate is not a proposal in this election")
+	ErrAmbiguousCandidate = errors.New("candidate name matches more than one proposal")
+)
@@ -0,0 +1,293 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestImportBallots(t *testing.T) {
+	const (
+		electionID  = "5b1c7d2e-8f3a-4b9c-8d0e-1f2a3b4c5d6e"
+		proposalID1 = "6c2d8e3f-9a4b-4cad-9e1f-2a3b4c5d6e7f"
+		proposalID2 = "7d3e9f4a-0b5c-4dbe-8f2a-3b4c5d6e7f80"
+	)
+
+	seedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, organizerUserID string) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID1,
+			Name:       "Proposal Name 1",
+			ProposedAt: 1,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID2,
+			Name:       "Proposal Name 2",
+			ProposedAt: 2,
+		}))
+	}
+
+	t.Run("imports valid csv rows and logs invalid rows", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		app.EventDispatcher.Add(2)
+		command := election.ImportBallots{
+			ID:         "8e4f0a5b-1c6d-4ecf-9a3b-4c5d6e7f8091",
+			ElectionID: electionID,
+			Format:     cqrs.String(election.CVRFormatCSV),
+			Content: "BallotID,Rank 1,Rank 2\n" +
+				"1,Proposal Name 1,proposal name 2\n" +
+				"2," + proposalID2 + ",\n" +
+				"3,Unknown Proposal,\n" +
+				"4,Proposal Name 1,Proposal Name 1\n" +
+				"5,,\n",
+		}
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+		assert.Equal(t, 5, status.TotalToProcess)
+		assert.Equal(t, 5, status.TotalProcessed)
+
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+		assert.Equal(t, []string{proposalID1, proposalID2}, votes[0].RankedProposalIDs)
+		assert.Equal(t, []string{proposalID2}, votes[1].RankedProposalIDs)
+		assert.Equal(t, "", votes[0].UserID)
+		assert.True(t, votes[0].IsImported)
+
+		app.EventDispatcher.Wait(ctx)
+		assert.Equal(t, event.VoteWasCast{
+			VoteID:            votes[0].VoteID,
+			ElectionID:        electionID,
+			RankedProposalIDs: []string{proposalID1, proposalID2},
+			Receipt:           ballotreceipt.Receipt(votes[0].VoteID, electionID, []string{proposalID1, proposalID2}),
+			IsImported:        true,
+			OccurredAt:        votes[0].SubmittedAt,
+		}, app.EventDispatcher.GetEvent(0))
+
+		logs, err := app.AsyncCommandStore.GetAsyncCommandLogs(ctx, command.ID)
+		require.NoError(t, err)
+		var messages []string
+		for _, log := range logs {
+			messages = append(messages, log.Type+": "+log.Message)
+		}
+		assert.Equal(t, []string{
+			"ERROR: row 3: candidate is not a proposal in this election: Unknown Proposal",
+			"ERROR: row 4: candidate is ranked more than once: Proposal Name 1",
+			"ERROR: row 5: ballot does not rank any candidates",
+			"INFO: Imported 2 of 5 ballots",
+		}, messages)
+	})
+
+	t.Run("imports ballots exported as NIST CVR json", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const exportedElectionID = "9f5a1b6c-2d7e-4fa0-8b4c-5d6e7f8091a2"
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID: exportedElectionID,
+			Name:       "Exported Election",
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: exportedElectionID,
+			ProposalID: "a06b2c7d-3e8f-4ab1-9c5d-6e7f8091a2b3",
+			Name:       "Proposal Name 2",
+		}))
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "b17c3d8e-4f9a-4bc2-8d6e-7f8091a2b3c4",
+			ElectionID:        exportedElectionID,
+			RankedProposalIDs: []string{"a06b2c7d-3e8f-4ab1-9c5d-6e7f8091a2b3"},
+			IsImported:        true,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID: exportedElectionID,
+			Name:       "Exported Election",
+			IsClosed:   true,
+		}))
+		exportResponse, err := app.ExecuteQuery(ctx, election.ExportBallots{
+			ElectionID: exportedElectionID,
+		})
+		require.NoError(t, err)
+
+		command := election.ImportBallots{
+			ID:         "c28d4e9f-5a0b-4cd3-9e7f-8091a2b3c4d5",
+			ElectionID: electionID,
+			Content:    exportResponse.(election.ExportBallotsResponse).Content,
+		}
+
+		// When
+		_, err = app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		require.Len(t, votes, 1)
+		assert.Equal(t, []string{proposalID2}, votes[0].RankedProposalIDs)
+	})
+
+	t.Run("imports ballots into an election with an eligibility roll", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			Name:              "Election Name",
+			Description:       "Election Description",
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+		}))
+		command := election.ImportBallots{
+			ID:         "0b1c2d3e-4f5a-4b6c-9d7e-8f9a0b1c2d3e",
+			ElectionID: electionID,
+			Format:     cqrs.String(election.CVRFormatCSV),
+			Content: "BallotID,Rank 1\n" +
+				"1,Proposal Name 1\n" +
+				"2,Proposal Name 1\n",
+		}
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+		assert.True(t, votes[0].IsImported)
+		assert.True(t, votes[1].IsImported)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when no ballots are valid", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			command := election.ImportBallots{
+				ID:         "d39e5f0a-6b1c-4de4-8f80-91a2b3c4d5e6",
+				ElectionID: electionID,
+				Format:     cqrs.String(election.CVRFormatCSV),
+				Content:    "Rank 1\nUnknown Proposal\n",
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+				return err == nil && status.IsFinished
+			}, time.Second, 10*time.Millisecond)
+
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			require.NoError(t, err)
+			assert.False(t, status.IsSuccess)
+			logs, err := app.AsyncCommandStore.GetAsyncCommandLogs(ctx, command.ID)
+			require.NoError(t, err)
+			require.NotEmpty(t, logs)
+			assert.Equal(t, "no valid ballots found", logs[len(logs)-1].Message)
+		})
+
+		t.Run("when election is closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: app.RegularUserID,
+				IsClosed:        true,
+			}))
+			command := election.ImportBallots{
+				ID:         "e4af601b-7c2d-4ef5-9091-a2b3c4d5e6f7",
+				ElectionID: electionID,
+				Format:     cqrs.String(election.CVRFormatCSV),
+				Content:    "Rank 1\nProposal Name 1\n",
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+				return err == nil && status.IsFinished
+			}, time.Second, 10*time.Millisecond)
+
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			require.NoError(t, err)
+			assert.False(t, status.IsSuccess)
+		})
+
+		t.Run("when not authorized", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, "f5b0712c-8d3e-4f06-8a12-b3c4d5e6f708")
+			command := election.ImportBallots{
+				ID:         "06c1823d-9e4f-4a17-9b23-c4d5e6f70819",
+				ElectionID: electionID,
+				Format:     cqrs.String(election.CVRFormatCSV),
+				Content:    "Rank 1\nProposal Name 1\n",
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+	})
+}
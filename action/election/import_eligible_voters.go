@@ -0,0 +1,123 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// ImportEligibleVoters is an asynchronous command that adds many users to the
+// eligibility roll of an open election at once, such as a membership list.
+// Blank and repeated UserIDs are reported in the command logs and skipped. Users
+// already on the roll are left unchanged. Only the election organizer, or an
+// admin, may manage the roll.
+type ImportEligibleVoters struct {
+	ID         string
+	ElectionID string
+	UserIDs    []string
+}
+
+type importEligibleVotersHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewImportEligibleVotersHandler(
+	repository electionrepository.Repository,
+	eventStore eventstore.Store,
+	clock clock.Clock,
+) *importEligibleVotersHandler {
+	return &importEligibleVotersHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *importEligibleVotersHandler) Verify(ctx authorization.Context, cmd ImportEligibleVoters) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *importEligibleVotersHandler) On(ctx context.Context, cmd ImportEligibleVoters, eventRaiser cqrs.EventRaiser, logger cqrs.AsyncCommandLogger) error {
+	ctx, span := tracer.Start(ctx, "vote.import-eligible-voters")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	election, err := getEligibilityRollElection(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		logger.LogError("unable to import eligible voters: %s", err)
+		return err
+	}
+
+	logger.SetTotalToProcess(len(cmd.UserIDs))
+
+	var eligibleVoters []electionrepository.EligibleVoter
+	isImported := make(map[string]struct{}, len(cmd.UserIDs))
+
+	for i, userID := range cmd.UserIDs {
+		userID = strings.TrimSpace(userID)
+		logger.IncrementTotalProcessed()
+
+		if userID == "" {
+			logger.LogError("entry %d: %s", i+1, ErrMissingUserID)
+			continue
+		}
+
+		if _, ok := isImported[userID]; ok {
+			logger.LogError("entry %d: duplicate UserID %s", i+1, userID)
+			continue
+		}
+		isImported[userID] = struct{}{}
+
+		eligibleVoters = append(eligibleVoters, electionrepository.EligibleVoter{
+			ElectionID:   cmd.ElectionID,
+			UserID:       userID,
+			RegisteredAt: occurredAt,
+		})
+	}
+
+	logger.Flush()
+
+	if len(eligibleVoters) == 0 {
+		logger.LogError("no valid eligible voters found")
+		return ErrNoEligibleVoters
+	}
+
+	events := make([]cqrs.Event, len(eligibleVoters))
+	for i, eligibleVoter := range eligibleVoters {
+		events[i] = event.EligibleVoterWasRegistered{
+			ElectionID: eligibleVoter.ElectionID,
+			UserID:     eligibleVoter.UserID,
+			OccurredAt: occurredAt,
+		}
+	}
+
+	err = recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, events...)
+	if err != nil {
+		logger.LogError("unable to save eligible voters: %s", err)
+		cqrs.RecordSpanError(span, err)
+		return err
+	}
+
+	logger.LogInfo("Imported %d of %d eligible voters", len(eligibleVoters), len(cmd.UserIDs))
+
+	return nil
+}
+
+var ErrNoEligibleVoters = errors.New("no valid eligible voters found")
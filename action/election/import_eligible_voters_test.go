@@ -0,0 +1,83 @@
+package election_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestImportEligibleVoters(t *testing.T) {
+	const (
+		electionID = "be1ad5ed-7a2c-4d3e-9f6a-2b3c4d5e6f7a"
+		userID1    = "cf2be6fe-8b3d-4e4f-8a7b-3c4d5e6f7a8b"
+		userID2    = "d03cf70f-9c4e-4f5a-9b8c-4d5e6f7a8b9c"
+	)
+
+	t.Run("adds users to the roll and logs invalid entries", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+		}))
+		app.EventDispatcher.Add(2)
+		command := election.ImportEligibleVoters{
+			ID:         "e14d0810-ad5f-4a6b-8c9d-5e6f7a8b9cad",
+			ElectionID: electionID,
+			UserIDs:    []string{userID1, " ", userID2, userID1},
+		}
+
+		// When
+		_, err := app.EnqueueCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, command.ID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+		assert.Equal(t, 4, status.TotalToProcess)
+		assert.Equal(t, 4, status.TotalProcessed)
+
+		turnout, err := app.ElectionRepository.GetTurnout(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, turnout.TotalEligible)
+
+		app.EventDispatcher.Wait(ctx)
+		assert.Equal(t, event.EligibleVoterWasRegistered{
+			ElectionID: electionID,
+			UserID:     userID1,
+			OccurredAt: 2,
+		}, app.EventDispatcher.GetEvent(0))
+		assert.Equal(t, event.EligibleVoterWasRegistered{
+			ElectionID: electionID,
+			UserID:     userID2,
+			OccurredAt: 2,
+		}, app.EventDispatcher.GetEvent(1))
+
+		logs, err := app.AsyncCommandStore.GetAsyncCommandLogs(ctx, command.ID)
+		require.NoError(t, err)
+		var messages []string
+		for _, log := range logs {
+			messages = append(messages, log.Type+": "+log.Message)
+		}
+		assert.Equal(t, []string{
+			"ERROR: entry 2: missing UserID",
+			"ERROR: entry 4: duplicate UserID " + userID1,
+			"INFO: Imported 2 of 4 eligible voters",
+		}, messages)
+	})
+}
@@ -0,0 +1,114 @@
+package election
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/blindsig"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// IssueBallotToken blind-signs a ballot token for UserID in an election with secret
+// ballots. The voter generates a random token, blinds it with the election's PublicKey
+// from GetBallotToken, and submits the base64 encoded BlindedToken. The signature is
+// then retrieved with GetBallotToken, unblinded, and spent with CastSecretBallot. The
+// election never sees the token itself, so the ballot cannot be linked back to UserID.
+// Each user is issued one token per election, and when the election's EligibilityPolicy
+// is RegisteredVoters, only users on its eligibility roll are issued a token.
+// UserID must be the authenticated user, unless an admin requests the token on their behalf.
+type IssueBallotToken struct {
+	ElectionID   string
+	UserID       string
+	BlindedToken string
+}
+
+type issueBallotTokenHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewIssueBallotTokenHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *issueBallotTokenHandler {
+	return &issueBallotTokenHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *issueBallotTokenHandler) Verify(ctx authorization.Context, cmd IssueBallotToken) error {
+	return verifyAuthenticatedUser(ctx, cmd.UserID)
+}
+
+func (h *issueBallotTokenHandler) On(ctx context.Context, cmd IssueBallotToken, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.issue-ballot-token")
+	defer span.End()
+
+	if cmd.UserID == "" {
+		return ErrMissingUserID
+	}
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = verifySecretBallotElection(election, occurredAt)
+	if err != nil {
+		return err
+	}
+
+	blindedToken, err := base64.StdEncoding.DecodeString(cmd.BlindedToken)
+	if err != nil || len(blindedToken) == 0 {
+		return ErrInvalidBlindedToken
+	}
+
+	err = h.repository.ValidateBallotToken(ctx, electionrepository.BallotToken{
+		ElectionID: cmd.ElectionID,
+		UserID:     cmd.UserID,
+		IssuedAt:   occurredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := getBallotKey(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	blindSignature, err := blindsig.Sign(privateKey, blindedToken)
+	if err != nil {
+		if errors.Is(err, blindsig.ErrInvalidLength) {
+			return ErrInvalidBlindedToken
+		}
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.BallotTokenWasIssued{
+		ElectionID:     cmd.ElectionID,
+		UserID:         cmd.UserID,
+		BlindSignature: blindSignature,
+		OccurredAt:     occurredAt,
+	})
+}
+
+var (
+	ErrNotSecretBallot     = errors.New("election does not use secret ballots")
+	ErrInvalidBlindedToken = errors.New("blinded token must be base64 encoded and the size of the election's public key")
+)
@@ -0,0 +1,186 @@
+package election_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/blindsig"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestIssueBallotToken(t *testing.T) {
+	const electionID = "2c9d5e8f-1a4b-4c7d-9e0f-3a6b9c2d5e8f"
+
+	t.Run("blind signs token and raises event", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		privateKey := saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+		blindedToken, _, err := blindsig.Blind(rand.Reader, &privateKey.PublicKey, []byte("3d0e6f9a2b5c8d1e4f7a0b3c6d9e2f5a"))
+		require.NoError(t, err)
+		command := election.IssueBallotToken{
+			ElectionID:   electionID,
+			UserID:       app.RegularUserID,
+			BlindedToken: base64.StdEncoding.EncodeToString(blindedToken),
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		expectedBlindSignature, err := blindsig.Sign(privateKey, blindedToken)
+		require.NoError(t, err)
+		assert.Equal(t, event.BallotTokenWasIssued{
+			ElectionID:     electionID,
+			UserID:         app.RegularUserID,
+			BlindSignature: expectedBlindSignature,
+			OccurredAt:     0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		ballotToken, err := app.ElectionRepository.GetBallotToken(ctx, electionID, app.RegularUserID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedBlindSignature, ballotToken.BlindSignature)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not the authenticated user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				UserID:       "4e1f7a0b-3c6d-4e9f-8a1b-4c7d0e3f6a9b",
+				BlindedToken: base64.StdEncoding.EncodeToString([]byte("blinded")),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when UserID is missing", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedAdminContext()
+			saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				BlindedToken: base64.StdEncoding.EncodeToString([]byte("blinded")),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrMissingUserID, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when token has already been issued", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			privateKey := saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			blindedToken, _, err := blindsig.Blind(rand.Reader, &privateKey.PublicKey, []byte("5f2a8b1c4d7e0f3a6b9c2d5e8f1a4b7c"))
+			require.NoError(t, err)
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				UserID:       app.RegularUserID,
+				BlindedToken: base64.StdEncoding.EncodeToString(blindedToken),
+			}
+			_, err = app.ExecuteCommand(ctx, command)
+			require.NoError(t, err)
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrBallotTokenAlreadyIssued(electionID, app.RegularUserID), err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+
+		t.Run("when voter is not on the eligibility roll", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   "6a3b9c2d-5e8f-4a1b-8c3d-6e9f2a5b8c1d",
+				EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+				BallotSecrecy:     electionrepository.BallotSecrecySecret,
+			}))
+			privateKey := saveBallotKey(t, ctx, app.ElectionRepository, electionID)
+			blindedToken, _, err := blindsig.Blind(rand.Reader, &privateKey.PublicKey, []byte("7b4cad3e6f9a2b5c8d1e4f7a0b3c6d9e"))
+			require.NoError(t, err)
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				UserID:       app.RegularUserID,
+				BlindedToken: base64.StdEncoding.EncodeToString(blindedToken),
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrVoterNotEligible(electionID, app.RegularUserID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when blinded token does not match the key size", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveSecretBallotElection(t, ctx, app.ElectionRepository, electionID)
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				UserID:       app.RegularUserID,
+				BlindedToken: base64.StdEncoding.EncodeToString([]byte("blinded")),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrInvalidBlindedToken, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election does not use secret ballots", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: "8c5dbe4f-7a0b-4c3d-9e5f-8a1b4c7d0e3f",
+			}))
+			command := election.IssueBallotToken{
+				ElectionID:   electionID,
+				UserID:       app.RegularUserID,
+				BlindedToken: base64.StdEncoding.EncodeToString([]byte("blinded")),
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrNotSecretBallot, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// ListElections returns a paginated result of every election, including closed
+// and cancelled elections with their winners. Results can be filtered by Status
+// (Open, Closed, or Cancelled), organizer, inclusive commenced and closed date
+// ranges, and a case-insensitive search of the election name.
+type ListElections struct {
+	Status          *string
+	OrganizerUserID *string
+	CommencedFrom   *int
+	CommencedTo     *int
+	ClosedFrom      *int
+	ClosedTo        *int
+	Name            *string
+	Page            *int
+	ItemsPerPage    *int
+	SortBy          *string
+	SortDirection   *string
+}
+
+func (q ListElections) ValidationRules() cqrs.ValidationRuleMap {
+	return cqrs.ValidationRuleMap{
+		"Status": cqrs.OptionalValidValues(
+			electionrepository.ElectionStatusOpen,
+			electionrepository.ElectionStatusClosed,
+			electionrepository.ElectionStatusCancelled,
+		),
+		"SortBy": cqrs.OptionalValidValues(
+			"Name",
+			"CommencedAt",
+			"ClosedAt",
+		),
+		"SortDirection": cqrs.OptionalValidSortDirection(),
+		"Page":          cqrs.OptionalValidMinRange(1),
+		"ItemsPerPage":  cqrs.OptionalValidRange(1, 50),
+	}
+}
+
+type ListElectionsResponse struct {
+	Elections    []ElectionSummary
+	TotalResults int
+}
+
+type ElectionSummary struct {
+	ElectionID         string
+	OrganizerUserID    string
+	Name               string
+	Description        string
+	Status             string
+	Outcome            string
+	WinningProposalID  string
+	WinningProposalIDs []string
+	CommencedAt        int
+	ClosedAt           int
+}
+
+type listElectionsHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewListElectionsHandler(repository electionrepository.Repository) *listElectionsHandler {
+	return &listElectionsHandler{
+		repository: repository,
+	}
+}
+
+func (h *listElectionsHandler) On(ctx context.Context, query ListElections) (ListElectionsResponse, error) {
+	ctx, span := tracer.Start(ctx, "vote.list-elections")
+	defer span.End()
+
+	page, itemsPerPage := cqrs.DefaultPagination(query.Page, query.ItemsPerPage, electionrepository.DefaultItemsPerPage)
+	span.SetAttributes(
+		attribute.Int("page", page),
+		attribute.Int("itemsPerPage", itemsPerPage),
+	)
+
+	totalResults, elections, err := h.repository.ListElections(ctx,
+		toElectionFilter(query),
+		page,
+		itemsPerPage,
+		query.SortBy,
+		query.SortDirection,
+	)
+	if err != nil {
+		return ListElectionsResponse{}, err
+	}
+
+	return ListElectionsResponse{
+		Elections:    ToElectionSummaries(elections),
+		TotalResults: totalResults,
+	}, nil
+}
+
+func toElectionFilter(query ListElections) electionrepository.ElectionFilter {
+	var filter electionrepository.ElectionFilter
+
+	if query.Status != nil {
+		filter.Status = *query.Status
+	}
+
+	if query.OrganizerUserID != nil {
+		filter.OrganizerUserID = *query.OrganizerUserID
+	}
+
+	if query.CommencedFrom != nil {
+		filter.CommencedFrom = *query.CommencedFrom
+	}
+
+	if query.CommencedTo != nil {
+		filter.CommencedTo = *query.CommencedTo
+	}
+
+	if query.ClosedFrom != nil {
+		filter.ClosedFrom = *query.ClosedFrom
+	}
+
+	if query.ClosedTo != nil {
+		filter.ClosedTo = *query.ClosedTo
+	}
+
+	if query.Name != nil {
+		filter.Name = *query.Name
+	}
+
+	return filter
+}
+
+func ToElectionSummaries(elections []electionrepository.Election) []ElectionSummary {
+	electionSummaries := make([]ElectionSummary, len(elections))
+	for i := range elections {
+		electionSummaries[i] = ToElectionSummary(elections[i])
+	}
+	return electionSummaries
+}
+
+func ToElectionSummary(election electionrepository.Election) ElectionSummary {
+	return ElectionSummary{
+		ElectionID:         election.ElectionID,
+		OrganizerUserID:    election.OrganizerUserID,
+		Name:               election.Name,
+		Description:        election.Description,
+		Status:             election.Status(),
+		Outcome:            election.Outcome,
+		WinningProposalID:  election.WinningProposalID,
+		WinningProposalIDs: election.WinningProposalIDs,
+		CommencedAt:        election.CommencedAt,
+		ClosedAt:           election.ClosedAt,
+	}
+}
@@ -0,0 +1,202 @@
+package election_test
+
+import (
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestListElections(t *testing.T) {
+	// Given
+	app := votetest.NewTestApp(t)
+	ctx := app.GetAuthenticatedUserContext()
+	const (
+		organizerUserID1 = "1f4e7b2a-5c8d-4e0f-9a3b-6c9d2e5f8a1b"
+		organizerUserID2 = "2a5f8c3b-6d9e-4f1a-8b4c-7d0e3f6a9b2c"
+	)
+	openElection := electionrepository.Election{
+		ElectionID:      "3b6a9d4c-7e0f-4a2b-9c5d-8e1f4a7b0c3d",
+		OrganizerUserID: organizerUserID1,
+		Name:            "Budget 2025",
+		Description:     "Election Description 1",
+		CommencedAt:     1,
+	}
+	closedElection := electionrepository.Election{
+		ElectionID:         "4c7b0e5d-8f1a-4b3c-8d6e-9f2a5b8c1d4e",
+		OrganizerUserID:    organizerUserID2,
+		Name:               "Board Election",
+		Description:        "Election Description 2",
+		CommencedAt:        2,
+		IsClosed:           true,
+		ClosedAt:           10,
+		Outcome:            electionrepository.OutcomeWinnerSelected,
+		WinningProposalID:  "5d8c1f6e-9a2b-4c4d-9e7f-0a3b6c9d2e5f",
+		WinningProposalIDs: []string{"5d8c1f6e-9a2b-4c4d-9e7f-0a3b6c9d2e5f"},
+	}
+	cancelledElection := electionrepository.Election{
+		ElectionID:      "6e9d2a7f-0b3c-4d5e-8f8a-1b4c7d0e3f6a",
+		OrganizerUserID: organizerUserID1,
+		Name:            "Budget 2026",
+		Description:     "Election Description 3",
+		CommencedAt:     3,
+		IsClosed:        true,
+		ClosedAt:        5,
+		IsCancelled:     true,
+		CancelledAt:     5,
+	}
+
+	openSummary := election.ToElectionSummary(openElection)
+	closedSummary := election.ToElectionSummary(closedElection)
+	cancelledSummary := election.ToElectionSummary(cancelledElection)
+
+	require.NoError(t, app.ElectionRepository.SaveElection(ctx, openElection))
+	require.NoError(t, app.ElectionRepository.SaveElection(ctx, closedElection))
+	require.NoError(t, app.ElectionRepository.SaveElection(ctx, cancelledElection))
+
+	t.Run("returns every election with default pagination and sorting", func(t *testing.T) {
+		// Given
+		query := election.ListElections{}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.ListElectionsResponse{
+			Elections: []election.ElectionSummary{
+				openSummary,
+				closedSummary,
+				cancelledSummary,
+			},
+			TotalResults: 3,
+		}, response)
+		assert.Equal(t, electionrepository.ElectionStatusOpen, openSummary.Status)
+		assert.Equal(t, electionrepository.ElectionStatusClosed, closedSummary.Status)
+		assert.Equal(t, electionrepository.ElectionStatusCancelled, cancelledSummary.Status)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    election.ListElections
+			expected []election.ElectionSummary
+		}{
+			{
+				name:     "by open status",
+				query:    election.ListElections{Status: cqrs.String("Open")},
+				expected: []election.ElectionSummary{openSummary},
+			},
+			{
+				name:     "by closed status",
+				query:    election.ListElections{Status: cqrs.String("Closed")},
+				expected: []election.ElectionSummary{closedSummary},
+			},
+			{
+				name:     "by cancelled status",
+				query:    election.ListElections{Status: cqrs.String("Cancelled")},
+				expected: []election.ElectionSummary{cancelledSummary},
+			},
+			{
+				name:     "by organizer",
+				query:    election.ListElections{OrganizerUserID: cqrs.String(organizerUserID1)},
+				expected: []election.ElectionSummary{openSummary, cancelledSummary},
+			},
+			{
+				name: "by commenced date range",
+				query: election.ListElections{
+					CommencedFrom: cqrs.Int(2),
+					CommencedTo:   cqrs.Int(3),
+				},
+				expected: []election.ElectionSummary{closedSummary, cancelledSummary},
+			},
+			{
+				name: "by closed date range",
+				query: election.ListElections{
+					ClosedFrom: cqrs.Int(6),
+					ClosedTo:   cqrs.Int(10),
+				},
+				expected: []election.ElectionSummary{closedSummary},
+			},
+			{
+				name:     "by name ignoring case",
+				query:    election.ListElections{Name: cqrs.String("budget")},
+				expected: []election.ElectionSummary{openSummary, cancelledSummary},
+			},
+			{
+				name: "by name and status",
+				query: election.ListElections{
+					Name:   cqrs.String("budget"),
+					Status: cqrs.String("Open"),
+				},
+				expected: []election.ElectionSummary{openSummary},
+			},
+			{
+				name:     "without matches",
+				query:    election.ListElections{Name: cqrs.String("Unknown")},
+				expected: []election.ElectionSummary{},
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				// When
+				response, err := app.ExecuteQuery(ctx, tc.query)
+
+				// Then
+				require.NoError(t, err)
+				assert.Equal(t, election.ListElectionsResponse{
+					Elections:    tc.expected,
+					TotalResults: len(tc.expected),
+				}, response)
+			})
+		}
+	})
+
+	t.Run("sorted by ClosedAt descending", func(t *testing.T) {
+		// Given
+		query := election.ListElections{
+			SortBy:        cqrs.String("ClosedAt"),
+			SortDirection: cqrs.String("descending"),
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.ListElectionsResponse{
+			Elections: []election.ElectionSummary{
+				closedSummary,
+				cancelledSummary,
+				openSummary,
+			},
+			TotalResults: 3,
+		}, response)
+	})
+
+	t.Run("second page default sort", func(t *testing.T) {
+		// Given
+		query := election.ListElections{
+			Page:         cqrs.Int(2),
+			ItemsPerPage: cqrs.Int(2),
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.ListElectionsResponse{
+			Elections: []election.ElectionSummary{
+				cancelledSummary,
+			},
+			TotalResults: 3,
+		}, response)
+	})
+}
@@ -0,0 +1,72 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// ListPendingProposals returns a paginated result of the proposals awaiting
+// moderation in an election. Only the election organizer, or an admin, may list
+// pending proposals.
+type ListPendingProposals struct {
+	ElectionID   string
+	Page         *int
+	ItemsPerPage *int
+}
+
+func (q ListPendingProposals) ValidationRules() cqrs.ValidationRuleMap {
+	return cqrs.ValidationRuleMap{
+		"Page":         cqrs.OptionalValidMinRange(1),
+		"ItemsPerPage": cqrs.OptionalValidRange(1, 10),
+	}
+}
+
+type ListPendingProposalsResponse struct {
+	Proposals    []Proposal
+	TotalResults int
+}
+
+type listPendingProposalsHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewListPendingProposalsHandler(repository electionrepository.Repository) *listPendingProposalsHandler {
+	return &listPendingProposalsHandler{
+		repository: repository,
+	}
+}
+
+func (h *listPendingProposalsHandler) Verify(ctx authorization.Context, query ListPendingProposals) error {
+	return verifyElectionOrganizer(ctx, h.repository, query.ElectionID)
+}
+
+func (h *listPendingProposalsHandler) On(ctx context.Context, query ListPendingProposals) (ListPendingProposalsResponse, error) {
+	ctx, span := tracer.Start(ctx, "vote.list-pending-proposals")
+	defer span.End()
+
+	page, itemsPerPage := cqrs.DefaultPagination(query.Page, query.ItemsPerPage, electionrepository.DefaultItemsPerPage)
+
+	span.SetAttributes(
+		attribute.Int("page", page),
+		attribute.Int("itemsPerPage", itemsPerPage),
+	)
+
+	totalResults, proposals, err := h.repository.ListPendingProposals(ctx,
+		query.ElectionID,
+		page,
+		itemsPerPage,
+	)
+	if err != nil {
+		return ListPendingProposalsResponse{}, err
+	}
+
+	return ListPendingProposalsResponse{
+		Proposals:    ToProposals(proposals),
+		TotalResults: totalResults,
+	}, nil
+}
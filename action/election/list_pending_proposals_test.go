@@ -0,0 +1,93 @@
+package election_test
+
+import (
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestListPendingProposals(t *testing.T) {
+	const electionID = "a3b4c5d6-e7f8-4a9b-8c0d-2e3f4a5b6c7d"
+
+	pendingProposal := electionrepository.Proposal{
+		ElectionID:       electionID,
+		ProposalID:       "b4c5d6e7-f8a9-4b0c-9d1e-3f4a5b6c7d8e",
+		OwnerUserID:      "c5d6e7f8-a9b0-4c1d-8e2f-4a5b6c7d8e9f",
+		Name:             "Pending Proposal",
+		ProposedAt:       1,
+		ModerationStatus: electionrepository.ModerationStatusPending,
+	}
+
+	t.Run("returns only pending proposals", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			ModerateProposals: true,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, pendingProposal))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       "d6e7f8a9-b0c1-4d2e-9f3a-5b6c7d8e9f0a",
+			ProposedAt:       2,
+			ModerationStatus: electionrepository.ModerationStatusApproved,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       "e7f8a9b0-c1d2-4e3f-8a4b-6c7d8e9f0a1b",
+			ProposedAt:       3,
+			ModerationStatus: electionrepository.ModerationStatusRejected,
+			RejectionReason:  "Spam",
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       "f8a9b0c1-d2e3-4f4a-9b5c-7d8e9f0a1b2c",
+			ProposedAt:       4,
+			IsWithdrawn:      true,
+			ModerationStatus: electionrepository.ModerationStatusPending,
+		}))
+		query := election.ListPendingProposals{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.ListPendingProposalsResponse{
+			Proposals:    []election.Proposal{election.ToProposal(pendingProposal)},
+			TotalResults: 1,
+		}, response)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not the election organizer", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   pendingProposal.OwnerUserID,
+				ModerateProposals: true,
+			}))
+			query := election.ListPendingProposals{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+	})
+}
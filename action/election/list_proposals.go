@@ -9,7 +9,8 @@ import (
 	"github.com/inklabs/vote/internal/electionrepository"
 )
 
-// ListProposals returns a paginated result of election proposals.
+// ListProposals returns a paginated result of election proposals. Withdrawn
+// proposals are not listed.
 // Sortable options are omitted for this example.
 type ListProposals struct {
 	ElectionID   string
@@ -36,6 +37,7 @@ type Proposal struct {
 	Name        string
 	Description string
 	ProposedAt  int
+	UpdatedAt   int
 }
 
 type listProposalsHandler struct {
@@ -90,5 +92,27 @@ func ToProposal(proposal electionrepository.Proposal) Proposal {
 		Name:        proposal.Name,
 		Description: proposal.Description,
 		ProposedAt:  proposal.ProposedAt,
+		UpdatedAt:   proposal.UpdatedAt,
+	}
+}
+
+const allProposalsPerPage = 100
+
+// listAllProposals returns every proposal in an election by reading each page
+// of ListProposals.
+func listAllProposals(ctx context.Context, repository electionrepository.Repository, electionID string) ([]electionrepository.Proposal, error) {
+	var allProposals []electionrepository.Proposal
+
+	for page := 1; ; page++ {
+		totalResults, proposals, err := repository.ListProposals(ctx, electionID, page, allProposalsPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		allProposals = append(allProposals, proposals...)
+
+		if len(proposals) == 0 || len(allProposals) >= totalResults {
+			return allProposals, nil
+		}
 	}
 }
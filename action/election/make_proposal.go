@@ -2,17 +2,23 @@ package election
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/inklabs/cqrs"
 	"github.com/inklabs/cqrs/pkg/clock"
 
 	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
 	"github.com/inklabs/vote/pkg/sleep"
 )
 
-// MakeProposal instantiates a new proposal for a given ElectionID.
+// MakeProposal instantiates a new proposal for a given ElectionID. Proposals are
+// rejected once the election's ProposalDeadline has passed. In elections that
+// moderate proposals, the proposal is pending until the organizer approves it.
+// OwnerUserID must be the authenticated user, unless an admin makes the proposal.
 type MakeProposal struct {
 	ElectionID  string
 	ProposalID  string
@@ -23,34 +29,47 @@ type MakeProposal struct {
 
 type makeProposalHandler struct {
 	repository electionrepository.Repository
+	eventStore eventstore.Store
 	clock      clock.Clock
 }
 
-func NewMakeProposalHandler(repository electionrepository.Repository, clock clock.Clock) *makeProposalHandler {
+func NewMakeProposalHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *makeProposalHandler {
 	return &makeProposalHandler{
 		repository: repository,
+		eventStore: eventStore,
 		clock:      clock,
 	}
 }
 
+func (h *makeProposalHandler) Verify(ctx authorization.Context, cmd MakeProposal) error {
+	return verifyAuthenticatedUser(ctx, cmd.OwnerUserID)
+}
+
 func (h *makeProposalHandler) On(ctx context.Context, cmd MakeProposal, eventRaiser cqrs.EventRaiser) error {
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	proposedAt := int(h.clock.Now().Unix())
 
 	sleep.Rand(2 * time.Millisecond)
 
-	err := h.repository.SaveProposal(ctx, electionrepository.Proposal{
-		ElectionID:  cmd.ElectionID,
-		ProposalID:  cmd.ProposalID,
-		OwnerUserID: cmd.OwnerUserID,
-		Name:        cmd.Name,
-		Description: cmd.Description,
-		ProposedAt:  proposedAt,
-	})
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
 	if err != nil {
 		return err
 	}
 
-	eventRaiser.Raise(event.ProposalWasMade{
+	if election.ProposalDeadline > 0 && proposedAt > election.ProposalDeadline {
+		return ErrProposalDeadlinePassed
+	}
+
+	if election.IsClosed {
+		return electionrepository.NewErrElectionClosed(cmd.ElectionID)
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ProposalWasMade{
 		ElectionID:  cmd.ElectionID,
 		ProposalID:  cmd.ProposalID,
 		OwnerUserID: cmd.OwnerUserID,
@@ -58,6 +77,6 @@ func (h *makeProposalHandler) On(ctx context.Context, cmd MakeProposal, eventRai
 		Description: cmd.Description,
 		ProposedAt:  proposedAt,
 	})
-
-	return nil
 }
+
+var ErrProposalDeadlinePassed = errors.New("proposal deadline has passed")
@@ -6,6 +6,8 @@ import (
 	"github.com/inklabs/cqrs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/inklabs/vote/action/election"
 	"github.com/inklabs/vote/event"
@@ -35,7 +37,7 @@ func TestMakeProposal(t *testing.T) {
 		command := election.MakeProposal{
 			ElectionID:  electionID,
 			ProposalID:  proposalID,
-			OwnerUserID: "5ee66ff6-060b-4722-8574-0b298628b3be",
+			OwnerUserID: app.RegularUserID,
 			Name:        "Proposal Name",
 			Description: "Proposal Description",
 		}
@@ -60,12 +62,151 @@ func TestMakeProposal(t *testing.T) {
 		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID)
 		require.NoError(t, err)
 		assert.Equal(t, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       proposalID,
+			OwnerUserID:      command.OwnerUserID,
+			Name:             command.Name,
+			Description:      command.Description,
+			ProposedAt:       0,
+			ModerationStatus: electionrepository.ModerationStatusApproved,
+		}, actualProposal)
+	})
+
+	t.Run("holds proposal as pending when election moderates proposals", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		const (
+			electionID = "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+			proposalID = "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e"
+		)
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   "c3d4e5f6-a7b8-4c9d-8e0f-2a3b4c5d6e7f",
+			ModerateProposals: true,
+		}))
+		command := election.MakeProposal{
 			ElectionID:  electionID,
 			ProposalID:  proposalID,
-			OwnerUserID: command.OwnerUserID,
-			Name:        command.Name,
-			Description: command.Description,
-			ProposedAt:  0,
-		}, actualProposal)
+			OwnerUserID: app.RegularUserID,
+			Name:        "Proposal Name",
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.ModerationStatusPending, actualProposal.ModerationStatus)
+
+		totalProposals, _, err := app.ElectionRepository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, totalProposals)
+
+		err = app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f8a",
+			ElectionID:        electionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.Equal(t, electionrepository.NewErrProposalNotApproved(proposalID), err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when owner is not the authenticated user", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "1f2a3b4c-5d6e-4f7a-9b8c-9d0e1f2a3b4c",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+			command := election.MakeProposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "2a3b4c5d-6e7f-4a8b-8c9d-0e1f2a3b4c5d",
+				OwnerUserID: "2c3bfc60-ad8c-4f70-bb2b-94a2b9d98464",
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election is closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			election1 := electionrepository.Election{
+				ElectionID:      "5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9",
+				OrganizerUserID: "3c51a70e-14cc-4cbb-b2dc-f58317470729",
+				Name:            "Election Name",
+				Description:     "Election Description",
+				IsClosed:        true,
+			}
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, election1))
+
+			command := election.MakeProposal{
+				ElectionID:  election1.ElectionID,
+				ProposalID:  "6f7a8b9c-0d1e-4f2a-b3c4-d5e6f7a8b9c0",
+				OwnerUserID: app.RegularUserID,
+				Name:        "Proposal Name",
+				Description: "Proposal Description",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionClosed(election1.ElectionID), err)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when proposal deadline has passed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			const electionID = "7d8e9f0a-1b2c-4d3e-a4f5-a6b7c8d9e0f1"
+			_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+				ElectionID:       electionID,
+				OrganizerUserID:  app.RegularUserID,
+				Name:             "Election Name",
+				Description:      "Election Description",
+				ProposalDeadline: cqrs.Int(1),
+			})
+			require.NoError(t, err)
+			_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+				ElectionID:  electionID,
+				ProposalID:  "9f0a1b2c-3d4e-4f5a-b6b7-c8d9e0f1a2b3",
+				OwnerUserID: app.RegularUserID,
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+			})
+			require.NoError(t, err)
+
+			command := election.MakeProposal{
+				ElectionID:  electionID,
+				ProposalID:  "8e9f0a1b-2c3d-4e4f-b5a6-b7c8d9e0f1a2",
+				OwnerUserID: app.RegularUserID,
+				Name:        "Proposal Name 2",
+				Description: "Proposal Description 2",
+			}
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrProposalDeadlinePassed, err)
+		})
 	})
 }
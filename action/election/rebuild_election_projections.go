@@ -0,0 +1,62 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/projection"
+)
+
+// RebuildElectionProjections is an asynchronous command that drops the election
+// read models used by GetElection, ListOpenElections, and ListProposals, and rebuilds
+// them by replaying every event in the event store in order. Only admins may rebuild
+// projections. Commands executed while the rebuild is running may be lost.
+type RebuildElectionProjections struct {
+	ID string
+}
+
+type rebuildElectionProjectionsHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+}
+
+func NewRebuildElectionProjectionsHandler(
+	repository electionrepository.Repository,
+	eventStore eventstore.Store,
+) *rebuildElectionProjectionsHandler {
+	return &rebuildElectionProjectionsHandler{
+		repository: repository,
+		eventStore: eventStore,
+	}
+}
+
+func (h *rebuildElectionProjectionsHandler) Verify(ctx authorization.Context, _ RebuildElectionProjections) error {
+	if !ctx.IsAdmin() {
+		return cqrs.ErrAccessDenied
+	}
+
+	return nil
+}
+
+func (h *rebuildElectionProjectionsHandler) On(ctx context.Context, _ RebuildElectionProjections, _ cqrs.EventRaiser, logger cqrs.AsyncCommandLogger) error {
+	ctx, span := tracer.Start(ctx, "vote.rebuild-election-projections")
+	defer span.End()
+
+	logger.LogInfo("Rebuilding election projections")
+
+	err := projection.Replay(ctx, h.eventStore, logger, projection.NewElectionProjection(h.repository))
+	logger.Flush()
+	if err != nil {
+		logger.LogError("unable to rebuild election projections")
+		cqrs.RecordSpanError(span, err)
+		return err
+	}
+
+	logger.LogInfo("Rebuilt election projections")
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package election_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestRebuildElectionProjections(t *testing.T) {
+	t.Run("rebuilds read models from the event store", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		adminCtx := app.GetAuthenticatedAdminContext()
+		const (
+			electionID      = "5a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+			staleElectionID = "6b1c2d3e-4f5a-4b6c-9d7e-8f9a0b1c2d3e"
+			proposalID      = "7c2d3e4f-5a6b-4c7d-8e8f-9a0b1c2d3e4f"
+			voteID          = "8d3e4f5a-6b7c-4d8e-9f9a-0b1c2d3e4f5a"
+		)
+		_, err := app.ExecuteCommand(ctx, election.CommenceElection{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+			Name:            "Election Name",
+			Description:     "Election Description",
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.MakeProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: app.RegularUserID,
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		})
+		require.NoError(t, err)
+		_, err = app.ExecuteCommand(ctx, election.CastVote{
+			VoteID:            voteID,
+			ElectionID:        electionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.NoError(t, err)
+
+		expectedElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		expectedVotes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+
+		require.NoError(t, app.ElectionRepository.DeleteAll(ctx))
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID: staleElectionID,
+			Name:       "Stale Election",
+		}))
+
+		commandID := "9e4f5a6b-7c8d-4e9f-8a0b-1c2d3e4f5a6b"
+		command := election.RebuildElectionProjections{
+			ID: commandID,
+		}
+
+		// When
+		response, err := app.EnqueueCommand(adminCtx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.AsyncCommandResponse{
+			ID:            commandID,
+			Status:        "QUEUED",
+			HasBeenQueued: true,
+		}, response)
+		require.Eventually(t, func() bool {
+			status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+			return err == nil && status.IsFinished
+		}, time.Second, 10*time.Millisecond)
+
+		status, err := app.AsyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+		require.NoError(t, err)
+		assert.True(t, status.IsSuccess)
+
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedElection, actualElection)
+
+		actualVotes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedVotes, actualVotes)
+
+		totalProposals, _, err := app.ElectionRepository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalProposals)
+
+		_, err = app.ElectionRepository.GetElection(ctx, staleElectionID)
+		require.Equal(t, electionrepository.NewErrElectionNotFound(staleElectionID), err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when not an admin", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			command := election.RebuildElectionProjections{
+				ID: "0f5a6b7c-8d9e-4f0a-9b1c-2d3e4f5a6b7c",
+			}
+
+			// When
+			_, err := app.EnqueueCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+	})
+}
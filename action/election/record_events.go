@@ -0,0 +1,94 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/internal/electionaggregate"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/projection"
+)
+
+// lockElection holds the election's stream until unlock is called, so commands on the
+// same election are decided, recorded and projected one at a time instead of failing
+// on each other's writes. If the stream is ahead of the read model, such as after a
+// failed projection, the election's read models are rebuilt from the stream first, so
+// the command is decided against every recorded event.
+func lockElection(ctx context.Context, store eventstore.Store, repository electionrepository.Repository, electionID string) (unlock func(), err error) {
+	unlock, err = store.Lock(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = catchUpElection(ctx, store, repository, electionID)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+// catchUpElection rebuilds the election's read models if its stream holds events
+// the read model does not reflect.
+func catchUpElection(ctx context.Context, store eventstore.Store, repository electionrepository.Repository, electionID string) error {
+	version, err := store.Version(ctx, electionID)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	election, err := repository.GetElection(ctx, electionID)
+	var notFound *electionrepository.ErrElectionNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return err
+	}
+
+	if err == nil && election.Version >= version {
+		return nil
+	}
+
+	return rebuildElection(ctx, store, repository, electionID)
+}
+
+func rebuildElection(ctx context.Context, store eventstore.Store, repository electionrepository.Repository, electionID string) error {
+	aggregate, err := electionaggregate.Load(ctx, store, electionID)
+	if err != nil {
+		return err
+	}
+
+	return projection.NewElectionProjection(repository).Rebuild(ctx, aggregate)
+}
+
+// recordEvents appends events to the election's stream in the event store, then
+// projects them into the read models and raises them. The events are appended
+// expecting the stream to still be at the version of the election read model the
+// command was decided against. Commands hold the election with lockElection, so the
+// check only fails for a writer that does not. If the projection fails once the
+// events are recorded, the election's read models are rebuilt from its stream.
+func recordEvents(ctx context.Context, store eventstore.Store, repository electionrepository.Repository, eventRaiser cqrs.EventRaiser, election electionrepository.Election, events ...cqrs.Event) error {
+	err := store.Append(ctx, election.ElectionID, election.Version, events...)
+	if err != nil {
+		return err
+	}
+
+	err = projection.NewElectionProjection(repository).ProjectEvents(ctx, election, events...)
+	if err != nil {
+		err = rebuildElection(ctx, store, repository, election.ElectionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range events {
+		eventRaiser.Raise(e)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// RegisterEligibleVoter adds UserID to the eligibility roll of an open election
+// whose EligibilityPolicy is RegisteredVoters. Registering a user who is already
+// on the roll has no effect. Only the election organizer, or an admin, may manage
+// the roll.
+type RegisterEligibleVoter struct {
+	ElectionID string
+	UserID     string
+}
+
+type registerEligibleVoterHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewRegisterEligibleVoterHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *registerEligibleVoterHandler {
+	return &registerEligibleVoterHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *registerEligibleVoterHandler) Verify(ctx authorization.Context, cmd RegisterEligibleVoter) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *registerEligibleVoterHandler) On(ctx context.Context, cmd RegisterEligibleVoter, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.register-eligible-voter")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	if cmd.UserID == "" {
+		return ErrMissingUserID
+	}
+
+	election, err := getEligibilityRollElection(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.EligibleVoterWasRegistered{
+		ElectionID: cmd.ElectionID,
+		UserID:     cmd.UserID,
+		OccurredAt: occurredAt,
+	})
+}
+
+// getEligibilityRollElection returns an election whose eligibility roll may be
+// changed. Changes are rejected once the election is closed, or if it is open to
+// all users.
+func getEligibilityRollElection(ctx context.Context, repository electionrepository.Repository, electionID string) (electionrepository.Election, error) {
+	election, err := repository.GetElection(ctx, electionID)
+	if err != nil {
+		return electionrepository.Election{}, err
+	}
+
+	if election.EligibilityPolicy != electionrepository.EligibilityPolicyRegisteredVoters {
+		return electionrepository.Election{}, ErrNoEligibilityRoll
+	}
+
+	if election.IsClosed {
+		return electionrepository.Election{}, electionrepository.NewErrElectionClosed(electionID)
+	}
+
+	return election, nil
+}
+
+var (
+	ErrMissingUserID     = errors.New("missing UserID")
+	ErrNoEligibilityRoll = errors.New("election is open to all users and does not have an eligibility roll")
+)
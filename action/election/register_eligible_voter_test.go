@@ -0,0 +1,108 @@
+package election_test
+
+import (
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestRegisterEligibleVoter(t *testing.T) {
+	const (
+		electionID = "0f5c8a3e-6b1d-4e2f-8a9b-1c2d3e4f5a6b"
+		proposalID = "1a6d9b4f-7c2e-4f3a-9bac-2d3e4f5a6b7c"
+	)
+
+	t.Run("adds user to the roll so they may vote", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   app.RegularUserID,
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+		}))
+		require.NoError(t, app.ElectionRepository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+		}))
+		command := election.RegisterEligibleVoter{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.EligibleVoterWasRegistered{
+			ElectionID: electionID,
+			UserID:     app.RegularUserID,
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		_, err = app.ExecuteCommand(ctx, election.CastVote{
+			VoteID:            "2b7eac5a-8d3f-4a4b-8cbd-3e4f5a6b7c8d",
+			ElectionID:        electionID,
+			UserID:            app.RegularUserID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when election is open to all users", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   app.RegularUserID,
+				EligibilityPolicy: electionrepository.EligibilityPolicyOpen,
+			}))
+			command := election.RegisterEligibleVoter{
+				ElectionID: electionID,
+				UserID:     "3c8fbd6b-9e4a-4b5c-9dce-4f5a6b7c8d9e",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrNoEligibilityRoll, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when not the election organizer", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:        electionID,
+				OrganizerUserID:   "4d9ace7c-0f5b-4c6d-8edf-5a6b7c8d9e0f",
+				EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+			}))
+			command := election.RegisterEligibleVoter{
+				ElectionID: electionID,
+				UserID:     app.RegularUserID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// RejectProposal turns down a pending proposal in an election that moderates
+// proposals, such as spam or a duplicate. The Reason is kept with the proposal.
+// Only the election organizer, or an admin, may moderate proposals.
+type RejectProposal struct {
+	ElectionID string
+	ProposalID string
+	Reason     string
+}
+
+type rejectProposalHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewRejectProposalHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *rejectProposalHandler {
+	return &rejectProposalHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *rejectProposalHandler) Verify(ctx authorization.Context, cmd RejectProposal) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *rejectProposalHandler) On(ctx context.Context, cmd RejectProposal, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.reject-proposal")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	if cmd.Reason == "" {
+		return ErrMissingRejectionReason
+	}
+
+	proposal, err := getPendingProposal(ctx, h.repository, cmd.ElectionID, cmd.ProposalID)
+	if err != nil {
+		return err
+	}
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	proposal.ModerationStatus = electionrepository.ModerationStatusRejected
+	proposal.RejectionReason = cmd.Reason
+	proposal.ModeratedAt = occurredAt
+
+	err = h.repository.ValidateProposalUpdate(ctx, proposal)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ProposalWasRejected{
+		ElectionID: cmd.ElectionID,
+		ProposalID: cmd.ProposalID,
+		Reason:     cmd.Reason,
+		OccurredAt: occurredAt,
+	})
+}
+
+var ErrMissingRejectionReason = errors.New("missing rejection Reason")
@@ -0,0 +1,115 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestRejectProposal(t *testing.T) {
+	const (
+		electionID  = "d0e1f2a3-b4c5-4d6e-9f7a-9b0c1d2e3f4a"
+		proposalID  = "e1f2a3b4-c5d6-4e7f-8a8b-0c1d2e3f4a5b"
+		ownerUserID = "f2a3b4c5-d6e7-4f8a-9b9c-1d2e3f4a5b6c"
+	)
+
+	seedPendingProposal := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, organizerUserID string) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   organizerUserID,
+			ModerateProposals: true,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:       electionID,
+			ProposalID:       proposalID,
+			OwnerUserID:      ownerUserID,
+			ModerationStatus: electionrepository.ModerationStatusPending,
+		}))
+	}
+
+	t.Run("rejects pending proposal with reason", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedPendingProposal(t, ctx, app.ElectionRepository, app.RegularUserID)
+		command := election.RejectProposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+			Reason:     "Duplicate proposal",
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ProposalWasRejected{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+			Reason:     command.Reason,
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		detailsResponse, err := app.ExecuteQuery(ctx, election.GetProposalDetails{
+			ProposalID: proposalID,
+		})
+		require.NoError(t, err)
+		details := detailsResponse.(election.GetProposalDetailsResponse)
+		assert.Equal(t, electionrepository.ModerationStatusRejected, details.ModerationStatus)
+		assert.Equal(t, command.Reason, details.RejectionReason)
+
+		totalPendingProposals, _, err := app.ElectionRepository.ListPendingProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, totalPendingProposals)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when reason is missing", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedPendingProposal(t, ctx, app.ElectionRepository, app.RegularUserID)
+			command := election.RejectProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrMissingRejectionReason, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when user is not the election organizer", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedPendingProposal(t, ctx, app.ElectionRepository, ownerUserID)
+			command := election.RejectProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+				Reason:     "Duplicate proposal",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
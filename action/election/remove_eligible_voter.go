@@ -0,0 +1,69 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// RemoveEligibleVoter removes UserID from the eligibility roll of an open election.
+// A user who has already voted cannot be removed, so that turnout always counts
+// the ballots accepted from the roll. Only the election organizer, or an admin,
+// may manage the roll.
+type RemoveEligibleVoter struct {
+	ElectionID string
+	UserID     string
+}
+
+type removeEligibleVoterHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewRemoveEligibleVoterHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *removeEligibleVoterHandler {
+	return &removeEligibleVoterHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *removeEligibleVoterHandler) Verify(ctx authorization.Context, cmd RemoveEligibleVoter) error {
+	return verifyElectionOrganizer(ctx, h.repository, cmd.ElectionID)
+}
+
+func (h *removeEligibleVoterHandler) On(ctx context.Context, cmd RemoveEligibleVoter, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.remove-eligible-voter")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	election, err := getEligibilityRollElection(ctx, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = h.repository.ValidateEligibleVoterRemoval(ctx, cmd.ElectionID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.EligibleVoterWasRemoved{
+		ElectionID: cmd.ElectionID,
+		UserID:     cmd.UserID,
+		OccurredAt: occurredAt,
+	})
+}
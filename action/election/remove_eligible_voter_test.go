@@ -0,0 +1,112 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestRemoveEligibleVoter(t *testing.T) {
+	const (
+		electionID = "5eabdf8d-1a6c-4d7e-9f0a-6b7c8d9e0f1a"
+		proposalID = "6fbce09e-2b7d-4e8f-8a1b-7c8d9e0f1a2b"
+		userID     = "7acdf1af-3c8e-4f9a-9b2c-8d9e0f1a2b3c"
+	)
+
+	seedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, organizerUserID string) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   organizerUserID,
+			EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID: electionID,
+			ProposalID: proposalID,
+		}))
+		require.NoError(t, repository.SaveEligibleVoters(ctx, []electionrepository.EligibleVoter{
+			{ElectionID: electionID, UserID: userID},
+		}))
+	}
+
+	t.Run("removes user from the roll so they may not vote", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		command := election.RemoveEligibleVoter{
+			ElectionID: electionID,
+			UserID:     userID,
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, event.EligibleVoterWasRemoved{
+			ElectionID: electionID,
+			UserID:     userID,
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		err = app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "8bdea2ba-4d9f-4a0b-8c3d-9e0f1a2b3c4d",
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID},
+		})
+		require.Equal(t, electionrepository.NewErrVoterNotEligible(electionID, userID), err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not on the roll", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			const otherUserID = "9cefb3cb-5e0a-4b1c-9d4e-0f1a2b3c4d5e"
+			command := election.RemoveEligibleVoter{
+				ElectionID: electionID,
+				UserID:     otherUserID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrVoterNotEligible(electionID, otherUserID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when user has already voted", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            "ad0fc4dc-6f1b-4c2d-8e5f-1a2b3c4d5e6f",
+				ElectionID:        electionID,
+				UserID:            userID,
+				RankedProposalIDs: []string{proposalID},
+			}))
+			command := election.RemoveEligibleVoter{
+				ElectionID: electionID,
+				UserID:     userID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrEligibleVoterHasVoted(electionID, userID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
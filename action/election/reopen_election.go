@@ -0,0 +1,85 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// ReopenElection resumes voting in a closed or cancelled election. The winners,
+// tabulation rounds, and receipt root from its closing are cleared, and are
+// selected again the next time the election is closed. Ballots already cast are
+// kept. VotingEndsAt optionally sets a new end to the voting window; otherwise a
+// voting window that has already ended is removed, so the scheduler does not close
+// the election again straight away. Only an admin may reopen an election.
+type ReopenElection struct {
+	ElectionID   string
+	VotingEndsAt *int
+}
+
+type reopenElectionHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewReopenElectionHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *reopenElectionHandler {
+	return &reopenElectionHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *reopenElectionHandler) Verify(ctx authorization.Context, _ ReopenElection) error {
+	if !ctx.IsAdmin() {
+		return cqrs.ErrAccessDenied
+	}
+
+	return nil
+}
+
+func (h *reopenElectionHandler) On(ctx context.Context, cmd ReopenElection, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.reopen-election")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	election, err := h.repository.GetElection(ctx, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if !election.IsClosed {
+		return ErrElectionNotClosed
+	}
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	votingEndsAt := election.VotingEndsAt
+	if cmd.VotingEndsAt != nil {
+		if *cmd.VotingEndsAt <= occurredAt {
+			return ErrInvalidVotingWindow
+		}
+
+		votingEndsAt = *cmd.VotingEndsAt
+	} else if votingEndsAt <= occurredAt {
+		votingEndsAt = 0
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ElectionWasReopened{
+		ElectionID:   cmd.ElectionID,
+		VotingEndsAt: votingEndsAt,
+		OccurredAt:   occurredAt,
+	})
+}
@@ -0,0 +1,181 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestReopenElection(t *testing.T) {
+	const (
+		electionID      = "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d"
+		proposalID      = "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e"
+		organizerUserID = "9c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f"
+		voterUserID     = "0d1e2f3a-4b5c-4d6e-9f7a-8b9c0d1e2f3a"
+	)
+
+	seedClosedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+			Name:            "Election Name",
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: organizerUserID,
+		}))
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:         electionID,
+			OrganizerUserID:    organizerUserID,
+			Name:               "Election Name",
+			IsClosed:           true,
+			ClosedAt:           1,
+			SelectedAt:         1,
+			WinningProposalID:  proposalID,
+			WinningProposalIDs: []string{proposalID},
+			ReceiptRoot:        "ab12",
+		}))
+	}
+
+	t.Run("clears winner and resumes voting", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedAdminContext()
+		seedClosedElection(t, ctx, app.ElectionRepository)
+		votingEndsAt := 100
+		command := election.ReopenElection{
+			ElectionID:   electionID,
+			VotingEndsAt: &votingEndsAt,
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ElectionWasReopened{
+			ElectionID:   electionID,
+			VotingEndsAt: 100,
+			OccurredAt:   0,
+		}, app.EventDispatcher.GetEvent(0))
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+			Name:            "Election Name",
+			VotingEndsAt:    100,
+			Version:         1,
+		}, actualElection)
+
+		listResponse, err := app.ExecuteQuery(ctx, election.ListOpenElections{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, listResponse.(election.ListOpenElectionsResponse).TotalResults)
+
+		require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "1e2f3a4b-5c6d-4e7f-8a8b-9c0d1e2f3a4b",
+			ElectionID:        electionID,
+			UserID:            voterUserID,
+			RankedProposalIDs: []string{proposalID},
+		}))
+	})
+
+	t.Run("reopens cancelled election", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedAdminContext()
+		require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:         electionID,
+			OrganizerUserID:    organizerUserID,
+			IsClosed:           true,
+			IsCancelled:        true,
+			CancellationReason: "Wrong voting method",
+		}))
+		command := election.ReopenElection{
+			ElectionID: electionID,
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualElection, err := app.ElectionRepository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+			Version:         1,
+		}, actualElection)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user is not an admin", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedClosedElection(t, ctx, app.ElectionRepository)
+			command := election.ReopenElection{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when election is not closed", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedAdminContext()
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+			}))
+			command := election.ReopenElection{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrElectionNotClosed, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when voting ends before the election reopens", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedAdminContext()
+			seedClosedElection(t, ctx, app.ElectionRepository)
+			votingEndsAt := 0
+			command := election.ReopenElection{
+				ElectionID:   electionID,
+				VotingEndsAt: &votingEndsAt,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrInvalidVotingWindow, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
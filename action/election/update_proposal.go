@@ -0,0 +1,120 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// UpdateProposal replaces the Name and Description of a proposal. Proposals may
+// only be changed before the election's VotingStartsAt, and never once a ballot
+// ranks them, so that no vote is cast for a proposal that later changed. Only the
+// proposal owner, the election organizer, or an admin may update a proposal.
+// In elections that moderate proposals, an updated proposal is pending again until
+// the organizer approves its new content.
+type UpdateProposal struct {
+	ElectionID  string
+	ProposalID  string
+	Name        string
+	Description string
+}
+
+type updateProposalHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewUpdateProposalHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *updateProposalHandler {
+	return &updateProposalHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *updateProposalHandler) Verify(ctx authorization.Context, cmd UpdateProposal) error {
+	return verifyProposalOwner(ctx, h.repository, cmd.ProposalID)
+}
+
+func (h *updateProposalHandler) On(ctx context.Context, cmd UpdateProposal, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.update-proposal")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	proposal, election, err := getChangeableProposal(ctx, h.repository, cmd.ElectionID, cmd.ProposalID, occurredAt)
+	if err != nil {
+		return err
+	}
+
+	proposal.Name = cmd.Name
+	proposal.Description = cmd.Description
+	proposal.UpdatedAt = occurredAt
+
+	if election.ModerateProposals {
+		proposal.ModerationStatus = electionrepository.ModerationStatusPending
+		proposal.RejectionReason = ""
+	}
+
+	err = h.repository.ValidateProposalUpdate(ctx, proposal)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ProposalWasUpdated{
+		ElectionID:  cmd.ElectionID,
+		ProposalID:  cmd.ProposalID,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		OccurredAt:  occurredAt,
+	})
+}
+
+// getChangeableProposal returns a proposal that may still be updated or
+// withdrawn: it has not been withdrawn, and its election is open and voting has
+// not started. The repository rejects the change if a ballot already ranks it.
+func getChangeableProposal(ctx context.Context, repository electionrepository.Repository, electionID, proposalID string, occurredAt int) (electionrepository.Proposal, electionrepository.Election, error) {
+	proposal, err := repository.GetProposal(ctx, proposalID)
+	if err != nil {
+		return electionrepository.Proposal{}, electionrepository.Election{}, err
+	}
+
+	if proposal.ElectionID != electionID {
+		return electionrepository.Proposal{}, electionrepository.Election{}, electionrepository.NewErrInvalidElectionProposal(proposalID, electionID)
+	}
+
+	if proposal.IsWithdrawn {
+		return electionrepository.Proposal{}, electionrepository.Election{}, electionrepository.NewErrProposalWithdrawn(proposalID)
+	}
+
+	election, err := repository.GetElection(ctx, electionID)
+	if err != nil {
+		return electionrepository.Proposal{}, electionrepository.Election{}, err
+	}
+
+	if election.IsClosed {
+		return electionrepository.Proposal{}, electionrepository.Election{}, electionrepository.NewErrElectionClosed(electionID)
+	}
+
+	if election.VotingStartsAt > 0 && occurredAt >= election.VotingStartsAt {
+		return electionrepository.Proposal{}, electionrepository.Election{}, ErrVotingStarted
+	}
+
+	return proposal, election, nil
+}
+
+var ErrVotingStarted = errors.New("proposals cannot be changed once voting has started")
@@ -0,0 +1,238 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestUpdateProposal(t *testing.T) {
+	const (
+		electionID      = "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d"
+		proposalID      = "1b2c3d4e-5f6a-4b7c-9d8e-9f0a1b2c3d4e"
+		otherUserID     = "2c3d4e5f-6a7b-4c8d-8e9f-0a1b2c3d4e5f"
+		organizerUserID = "3d4e5f6a-7b8c-4d9e-9f0a-1b2c3d4e5f6a"
+	)
+
+	seedProposal := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, election1 electionrepository.Election, ownerUserID string) electionrepository.Proposal {
+		proposal := electionrepository.Proposal{
+			ElectionID:       election1.ElectionID,
+			ProposalID:       proposalID,
+			OwnerUserID:      ownerUserID,
+			Name:             "Proposal Name",
+			Description:      "Proposal Description",
+			ModerationStatus: electionrepository.ModerationStatusApproved,
+		}
+		require.NoError(t, repository.SaveElection(ctx, election1))
+		require.NoError(t, repository.SaveProposal(ctx, proposal))
+		return proposal
+	}
+
+	t.Run("updates proposal as owner", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		proposal := seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+		}, app.RegularUserID)
+		command := election.UpdateProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			Name:        "Updated Name",
+			Description: "Updated Description",
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ProposalWasUpdated{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			Name:        command.Name,
+			Description: command.Description,
+			OccurredAt:  0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+		proposal.Name = command.Name
+		proposal.Description = command.Description
+		assert.Equal(t, proposal, actualProposal)
+	})
+
+	t.Run("updates proposal as election organizer", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: app.RegularUserID,
+		}, otherUserID)
+		command := election.UpdateProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			Name:        "Updated Name",
+			Description: "Updated Description",
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated Name", actualProposal.Name)
+	})
+
+	t.Run("returns approved proposal to pending in moderated election", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+			ElectionID:        electionID,
+			OrganizerUserID:   organizerUserID,
+			ModerateProposals: true,
+		}, app.RegularUserID)
+		command := election.UpdateProposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			Name:        "Updated Name",
+			Description: "Updated Description",
+		}
+
+		// When
+		_, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.ModerationStatusPending, actualProposal.ModerationStatus)
+
+		totalProposals, _, err := app.ElectionRepository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, totalProposals)
+		totalPending, _, err := app.ElectionRepository.ListPendingProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalPending)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user neither owns proposal nor organizes election", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+			}, otherUserID)
+			command := election.UpdateProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+				Name:       "Updated Name",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when proposal belongs to another election", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+			}, app.RegularUserID)
+			const otherElectionID = "4e5f6a7b-8c9d-4e0f-8a1b-2c3d4e5f6a7b"
+			require.NoError(t, app.ElectionRepository.SaveElection(ctx, electionrepository.Election{
+				ElectionID:      otherElectionID,
+				OrganizerUserID: organizerUserID,
+			}))
+			command := election.UpdateProposal{
+				ElectionID: otherElectionID,
+				ProposalID: proposalID,
+				Name:       "Updated Name",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrInvalidElectionProposal(proposalID, otherElectionID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when voting has started", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+				VotingStartsAt:  1,
+			}, app.RegularUserID)
+			command := election.UpdateProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+				Name:       "Updated Name",
+			}
+			_, err := app.ExecuteCommand(ctx, command)
+			require.NoError(t, err)
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, election.ErrVotingStarted, err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+
+		t.Run("when a ballot ranks the proposal", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedProposal(t, ctx, app.ElectionRepository, electionrepository.Election{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+			}, app.RegularUserID)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            "5f6a7b8c-9d0e-4f1a-9b2c-3d4e5f6a7b8c",
+				ElectionID:        electionID,
+				UserID:            otherUserID,
+				RankedProposalIDs: []string{proposalID},
+			}))
+			command := election.UpdateProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+				Name:       "Updated Name",
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrProposalHasVotes(proposalID), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+	})
+}
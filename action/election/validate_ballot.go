@@ -0,0 +1,43 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/rcv"
+)
+
+// validateBallot rejects rankedProposalIDs that break the ballot rules chosen when
+// the election commenced. The election's proposals are only listed when every
+// proposal must be ranked.
+func validateBallot(ctx context.Context, repository electionrepository.Repository, election electionrepository.Election, rankedProposalIDs []string) error {
+	var proposalIDs []string
+	if election.RequireCompleteRanking {
+		proposals, err := listAllProposals(ctx, repository, election.ElectionID)
+		if err != nil {
+			return err
+		}
+
+		proposalIDs = getProposalIDs(proposals)
+	}
+
+	return getBallotRules(election).Validate(rankedProposalIDs, proposalIDs)
+}
+
+func getBallotRules(election electionrepository.Election) rcv.BallotRules {
+	return rcv.BallotRules{
+		MaxRanks:               election.MaxRanks,
+		RequireCompleteRanking: election.RequireCompleteRanking,
+		AllowDuplicateRanks:    election.AllowDuplicateRanks,
+		AllowSkippedRanks:      election.AllowSkippedRanks,
+		AllowWriteIns:          election.AllowWriteIns,
+	}
+}
+
+func getProposalIDs(proposals []electionrepository.Proposal) []string {
+	proposalIDs := make([]string, len(proposals))
+	for i, proposal := range proposals {
+		proposalIDs[i] = proposal.ProposalID
+	}
+	return proposalIDs
+}
@@ -0,0 +1,25 @@
+package election
+
+import (
+	"log"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/internal/authorization"
+)
+
+// verifyAuthenticatedUser rejects commands that act on behalf of a user other
+// than the authenticated user, such as casting a vote as someone else. Admins
+// may act on behalf of any user.
+func verifyAuthenticatedUser(ctx authorization.Context, userID string) error {
+	if ctx.IsAdmin() {
+		return nil
+	}
+
+	if ctx.UserID() != userID {
+		log.Printf("user %s does not match command user %s", ctx.UserID(), userID)
+		return cqrs.ErrAccessDenied
+	}
+
+	return nil
+}
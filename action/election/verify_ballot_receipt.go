@@ -0,0 +1,89 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// VerifyBallotReceipt returns an inclusion proof showing that a ballot Receipt, as raised
+// with VoteWasCast or SecretBallotWasCast, was counted in a closed election. The Proof leads from the receipt to
+// the ReceiptRoot published with the election results, and can be checked offline with
+// the verify-receipt CLI command.
+type VerifyBallotReceipt struct {
+	ElectionID string
+	Receipt    string
+}
+
+type VerifyBallotReceiptResponse struct {
+	ElectionID  string
+	Receipt     string
+	ReceiptRoot string
+	Proof       []ReceiptProofStep
+}
+
+// ReceiptProofStep is a sibling hash on the path from a receipt to the ReceiptRoot.
+// IsLeft reports whether the sibling is hashed to the left of the path.
+type ReceiptProofStep struct {
+	Hash   string
+	IsLeft bool
+}
+
+type verifyBallotReceiptHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewVerifyBallotReceiptHandler(repository electionrepository.Repository) *verifyBallotReceiptHandler {
+	return &verifyBallotReceiptHandler{
+		repository: repository,
+	}
+}
+
+func (h *verifyBallotReceiptHandler) On(ctx context.Context, query VerifyBallotReceipt) (VerifyBallotReceiptResponse, error) {
+	election, err := h.repository.GetElection(ctx, query.ElectionID)
+	if err != nil {
+		return VerifyBallotReceiptResponse{}, err
+	}
+
+	if !election.IsClosed || election.ReceiptRoot == "" {
+		return VerifyBallotReceiptResponse{}, ErrReceiptRootNotPublished
+	}
+
+	votes, err := getCountedBallots(ctx, h.repository, query.ElectionID)
+	if err != nil {
+		return VerifyBallotReceiptResponse{}, err
+	}
+
+	proof, err := ballotreceipt.Proof(getReceipts(votes), query.Receipt)
+	if err != nil {
+		if errors.Is(err, ballotreceipt.ErrReceiptNotFound) || errors.Is(err, ballotreceipt.ErrInvalidReceipt) {
+			return VerifyBallotReceiptResponse{}, ErrBallotReceiptNotFound
+		}
+		return VerifyBallotReceiptResponse{}, err
+	}
+
+	return VerifyBallotReceiptResponse{
+		ElectionID:  query.ElectionID,
+		Receipt:     query.Receipt,
+		ReceiptRoot: election.ReceiptRoot,
+		Proof:       ToReceiptProofSteps(proof),
+	}, nil
+}
+
+func ToReceiptProofSteps(proof []ballotreceipt.ProofStep) []ReceiptProofStep {
+	steps := make([]ReceiptProofStep, len(proof))
+	for i, step := range proof {
+		steps[i] = ReceiptProofStep{
+			Hash:   step.Hash,
+			IsLeft: step.IsLeft,
+		}
+	}
+	return steps
+}
+
+var (
+	ErrReceiptRootNotPublished = errors.New("ballot receipts are published when the election closes")
+	ErrBallotReceiptNotFound   = errors.New("receipt does not match a counted ballot")
+)
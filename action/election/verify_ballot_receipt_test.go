@@ -0,0 +1,156 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestVerifyBallotReceipt(t *testing.T) {
+	const (
+		electionID = "0b9f3d7e-2f7a-4c71-9f39-4f5b3c2a6d10"
+		proposalID = "7c1e5a0b-6b8f-4f3e-a6f4-3d2e1c0b9a87"
+	)
+
+	votes := []electionrepository.Vote{
+		{
+			VoteID:            "a6c0e0f4-53d4-4a5f-9b43-1e8b5d6f7a01",
+			ElectionID:        electionID,
+			UserID:            "1d0c3a4e-7f52-4b1b-8a6e-9c2d4e5f6a01",
+			RankedProposalIDs: []string{proposalID},
+		},
+		{
+			VoteID:            "b7d1f105-64e5-4b6a-8c54-2f9c6e708b02",
+			ElectionID:        electionID,
+			UserID:            "2e1d4b5f-8063-4c2c-9b7f-0d3e5f607b02",
+			RankedProposalIDs: []string{proposalID},
+		},
+		{
+			VoteID:            "c8e20216-75f6-4c7b-9d65-30ad7f819c03",
+			ElectionID:        electionID,
+			UserID:            "3f2e5c60-9174-4d3d-ac80-1e4f60718c03",
+			RankedProposalIDs: []string{proposalID},
+		},
+	}
+
+	var receipts []string
+	for _, vote := range votes {
+		receipts = append(receipts, ballotreceipt.Receipt(vote.VoteID, electionID, vote.RankedProposalIDs))
+	}
+
+	receiptRoot, err := ballotreceipt.Root(receipts)
+	require.NoError(t, err)
+
+	saveElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, isClosed bool) {
+		election1 := electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: "1b207fbf-9797-4bfa-91e3-6b5eef1b9fc0",
+			Name:            "Election Name",
+			Description:     "Election Description",
+		}
+		require.NoError(t, repository.SaveElection(ctx, election1))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID,
+			OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+			Name:        "Proposal Name",
+			Description: "Proposal Description",
+		}))
+		for _, vote := range votes {
+			require.NoError(t, repository.SaveVote(ctx, vote))
+		}
+
+		if isClosed {
+			election1.IsClosed = true
+			election1.WinningProposalID = proposalID
+			election1.ReceiptRoot = receiptRoot
+			require.NoError(t, repository.SaveElection(ctx, election1))
+		}
+	}
+
+	t.Run("returns inclusion proof for receipt", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		saveElection(t, ctx, app.ElectionRepository, true)
+		query := election.VerifyBallotReceipt{
+			ElectionID: electionID,
+			Receipt:    receipts[1],
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		actual := response.(election.VerifyBallotReceiptResponse)
+		assert.Equal(t, electionID, actual.ElectionID)
+		assert.Equal(t, receipts[1], actual.Receipt)
+		assert.Equal(t, receiptRoot, actual.ReceiptRoot)
+
+		var proof []ballotreceipt.ProofStep
+		for _, step := range actual.Proof {
+			proof = append(proof, ballotreceipt.ProofStep{Hash: step.Hash, IsLeft: step.IsLeft})
+		}
+		assert.NoError(t, ballotreceipt.Verify(actual.Receipt, proof, receiptRoot))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when election is still open", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveElection(t, ctx, app.ElectionRepository, false)
+			query := election.VerifyBallotReceipt{
+				ElectionID: electionID,
+				Receipt:    receipts[0],
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, election.ErrReceiptRootNotPublished, err)
+		})
+
+		t.Run("when receipt was not counted", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			saveElection(t, ctx, app.ElectionRepository, true)
+			query := election.VerifyBallotReceipt{
+				ElectionID: electionID,
+				Receipt:    ballotreceipt.Receipt("d9f31327-8607-4d8c-ae76-41be8092ad04", electionID, []string{proposalID}),
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, election.ErrBallotReceiptNotFound, err)
+		})
+
+		t.Run("when election is not found", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			query := election.VerifyBallotReceipt{
+				ElectionID: electionID,
+				Receipt:    receipts[0],
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionNotFound(electionID), err)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// VerifyElectionIntegrity recomputes the hash chain linking an election's ballots
+// in the order they were saved, and reports the first broken link. A broken chain
+// means ballots were edited, inserted, or removed outside the application. Once the
+// election is closed and tabulated, the chain is also checked against the
+// BallotChainHead and BallotCount recorded at close, which detects ballots added to
+// or removed from the end of the chain. Secret ballots are not part of the chain and
+// are not checked.
+type VerifyElectionIntegrity struct {
+	ElectionID string
+}
+
+type VerifyElectionIntegrityResponse struct {
+	ElectionID string
+	TotalVotes int
+	IsIntact   bool
+	BrokenLink BrokenBallotLink
+}
+
+// BrokenBallotLink is the first ballot whose hash does not verify. Position is
+// the 1-based position of the ballot in the chain.
+type BrokenBallotLink struct {
+	Position int
+	VoteID   string
+	Reason   string
+}
+
+type verifyElectionIntegrityHandler struct {
+	repository electionrepository.Repository
+}
+
+func NewVerifyElectionIntegrityHandler(repository electionrepository.Repository) *verifyElectionIntegrityHandler {
+	return &verifyElectionIntegrityHandler{
+		repository: repository,
+	}
+}
+
+func (h *verifyElectionIntegrityHandler) On(ctx context.Context, query VerifyElectionIntegrity) (VerifyElectionIntegrityResponse, error) {
+	election, err := h.repository.GetElection(ctx, query.ElectionID)
+	if err != nil {
+		return VerifyElectionIntegrityResponse{}, err
+	}
+
+	votes, err := h.repository.GetVotes(ctx, query.ElectionID)
+	if err != nil {
+		return VerifyElectionIntegrityResponse{}, err
+	}
+
+	brokenLink, isBroken := ballotchain.FindBrokenLink(votes)
+	if !isBroken && election.IsClosed && !election.IsCancelled {
+		brokenLink, isBroken = ballotchain.FindBrokenHead(votes, ballotchain.Head{
+			VoteHash:    election.BallotChainHead,
+			BallotCount: election.BallotCount,
+		})
+	}
+
+	return VerifyElectionIntegrityResponse{
+		ElectionID: query.ElectionID,
+		TotalVotes: len(votes),
+		IsIntact:   !isBroken,
+		BrokenLink: BrokenBallotLink{
+			Position: brokenLink.Position,
+			VoteID:   brokenLink.VoteID,
+			Reason:   brokenLink.Reason,
+		},
+	}, nil
+}
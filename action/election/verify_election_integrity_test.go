@@ -0,0 +1,225 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestVerifyElectionIntegrity(t *testing.T) {
+	const (
+		electionID = "5d8e2f4a-7b1c-4e3d-9f6a-2b4c6d8e0f13"
+		proposalID = "6e9f3a5b-8c2d-4f4e-a07b-3c5d7e9f1a24"
+		voteID1    = "7f0a4b6c-9d3e-4a5f-b18c-4d6e8f0a2b35"
+		voteID2    = "8a1b5c7d-0e4f-4b6a-c29d-5e7f9b1c3e46"
+		voteID3    = "9b2c6d8e-1f5a-4c7b-d3ae-6f8a0c2d4f57"
+	)
+
+	t.Run("reports intact chain", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2, voteID3)
+		query := election.VerifyElectionIntegrity{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.VerifyElectionIntegrityResponse{
+			ElectionID: electionID,
+			TotalVotes: 3,
+			IsIntact:   true,
+		}, response)
+	})
+
+	t.Run("reports first broken link", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t, votetest.WithElectionRepositoryDecorator(tamperVote(voteID2)))
+		ctx := app.GetAuthenticatedUserContext()
+		saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2, voteID3)
+		query := election.VerifyElectionIntegrity{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.VerifyElectionIntegrityResponse{
+			ElectionID: electionID,
+			TotalVotes: 3,
+			IsIntact:   false,
+			BrokenLink: election.BrokenBallotLink{
+				Position: 2,
+				VoteID:   voteID2,
+				Reason:   "vote hash does not match its contents",
+			},
+		}, response)
+	})
+
+	t.Run("reports vote added after the election closed", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2, voteID3)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		closeWithChainHead(t, ctx, app.ElectionRepository, electionID, ballotchain.GetHead(votes[:2]))
+		query := election.VerifyElectionIntegrity{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.VerifyElectionIntegrityResponse{
+			ElectionID: electionID,
+			TotalVotes: 3,
+			IsIntact:   false,
+			BrokenLink: election.BrokenBallotLink{
+				Position: 3,
+				VoteID:   voteID3,
+				Reason:   "vote was added after the chain head was recorded",
+			},
+		}, response)
+	})
+
+	t.Run("reports vote removed from the end of the chain after the election closed", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		saveChainedVotes(t, ctx, app.ElectionRepository, electionID, proposalID, voteID1, voteID2)
+		votes, err := app.ElectionRepository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		removedVote := ballotchain.Link(electionrepository.Vote{
+			VoteID:            voteID3,
+			ElectionID:        electionID,
+			UserID:            "user-" + voteID3,
+			RankedProposalIDs: []string{proposalID},
+		}, votes[1].VoteHash)
+		closeWithChainHead(t, ctx, app.ElectionRepository, electionID, ballotchain.GetHead(append(votes, removedVote)))
+		query := election.VerifyElectionIntegrity{
+			ElectionID: electionID,
+		}
+
+		// When
+		response, err := app.ExecuteQuery(ctx, query)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, election.VerifyElectionIntegrityResponse{
+			ElectionID: electionID,
+			TotalVotes: 2,
+			IsIntact:   false,
+			BrokenLink: election.BrokenBallotLink{
+				Position: 3,
+				Reason:   "vote was removed after the chain head was recorded",
+			},
+		}, response)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when election is not found", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			query := election.VerifyElectionIntegrity{
+				ElectionID: electionID,
+			}
+
+			// When
+			_, err := app.ExecuteQuery(ctx, query)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrElectionNotFound(electionID), err)
+		})
+	})
+}
+
+// saveChainedVotes saves an open election with a single proposal, and a vote for
+// it with each of voteIDs.
+func saveChainedVotes(t *testing.T, ctx context.Context, repository electionrepository.Repository, electionID, proposalID string, voteIDs ...string) {
+	t.Helper()
+
+	require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+		ElectionID:      electionID,
+		OrganizerUserID: "de06e622-9169-4351-b14e-9109dfd9dee3",
+		Name:            "Election Name",
+		Description:     "Election Description",
+	}))
+	require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+		ElectionID:  electionID,
+		ProposalID:  proposalID,
+		OwnerUserID: "d0adb8db-b56e-4f53-8e4a-4e6cac0cb95b",
+		Name:        "Proposal Name",
+		Description: "Proposal Description",
+	}))
+
+	for _, voteID := range voteIDs {
+		require.NoError(t, repository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            voteID,
+			ElectionID:        electionID,
+			UserID:            "user-" + voteID,
+			RankedProposalIDs: []string{proposalID},
+		}))
+	}
+}
+
+// closeWithChainHead saves the election as closed with head recorded as its ballot
+// chain head.
+func closeWithChainHead(t *testing.T, ctx context.Context, repository electionrepository.Repository, electionID string, head ballotchain.Head) {
+	t.Helper()
+
+	closedElection, err := repository.GetElection(ctx, electionID)
+	require.NoError(t, err)
+	closedElection.IsClosed = true
+	closedElection.BallotChainHead = head.VoteHash
+	closedElection.BallotCount = head.BallotCount
+	require.NoError(t, repository.SaveElection(ctx, closedElection))
+}
+
+// tamperVote simulates the voter of a vote being edited directly in the database,
+// after the vote was linked into the ballot chain.
+func tamperVote(voteID string) func(electionrepository.Repository) electionrepository.Repository {
+	return func(repository electionrepository.Repository) electionrepository.Repository {
+		return &tamperedVoteRepository{
+			Repository: repository,
+			voteID:     voteID,
+		}
+	}
+}
+
+type tamperedVoteRepository struct {
+	electionrepository.Repository
+	voteID string
+}
+
+func (r *tamperedVoteRepository) GetVotes(ctx context.Context, electionID string) ([]electionrepository.Vote, error) {
+	votes, err := r.Repository.GetVotes(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tamperedVotes := append([]electionrepository.Vote{}, votes...)
+	for i := range tamperedVotes {
+		if tamperedVotes[i].VoteID == r.voteID {
+			tamperedVotes[i].UserID = "tampered-user"
+		}
+	}
+
+	return tamperedVotes, nil
+}
@@ -0,0 +1,30 @@
+package election
+
+import (
+	"log"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// verifyElectionOrganizer rejects commands that manage an election, such as
+// closing it, unless the authenticated user is its organizer or an admin.
+func verifyElectionOrganizer(ctx authorization.Context, repository electionrepository.Repository, electionID string) error {
+	election, err := repository.GetElection(ctx.Context(), electionID)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsAdmin() {
+		return nil
+	}
+
+	if ctx.UserID() != election.OrganizerUserID {
+		log.Printf("user %s does not match election organizer user %s", ctx.UserID(), election.OrganizerUserID)
+		return cqrs.ErrAccessDenied
+	}
+
+	return nil
+}
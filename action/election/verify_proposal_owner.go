@@ -0,0 +1,22 @@
+package election
+
+import (
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// verifyProposalOwner rejects commands that change a proposal, such as
+// withdrawing it, unless the authenticated user owns the proposal, organizes its
+// election, or is an admin.
+func verifyProposalOwner(ctx authorization.Context, repository electionrepository.Repository, proposalID string) error {
+	proposal, err := repository.GetProposal(ctx.Context(), proposalID)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsAdmin() || ctx.UserID() == proposal.OwnerUserID {
+		return nil
+	}
+
+	return verifyElectionOrganizer(ctx, repository, proposal.ElectionID)
+}
@@ -0,0 +1,73 @@
+package election
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// WithdrawProposal removes a proposal from an election before voting starts.
+// Withdrawn proposals are no longer listed and cannot be ranked on a ballot. A
+// proposal that any ballot already ranks cannot be withdrawn, so tabulation never
+// has to discount it. Only the proposal owner, the election organizer, or an admin
+// may withdraw a proposal.
+type WithdrawProposal struct {
+	ElectionID string
+	ProposalID string
+}
+
+type withdrawProposalHandler struct {
+	repository electionrepository.Repository
+	eventStore eventstore.Store
+	clock      clock.Clock
+}
+
+func NewWithdrawProposalHandler(repository electionrepository.Repository, eventStore eventstore.Store, clock clock.Clock) *withdrawProposalHandler {
+	return &withdrawProposalHandler{
+		repository: repository,
+		eventStore: eventStore,
+		clock:      clock,
+	}
+}
+
+func (h *withdrawProposalHandler) Verify(ctx authorization.Context, cmd WithdrawProposal) error {
+	return verifyProposalOwner(ctx, h.repository, cmd.ProposalID)
+}
+
+func (h *withdrawProposalHandler) On(ctx context.Context, cmd WithdrawProposal, eventRaiser cqrs.EventRaiser) error {
+	ctx, span := tracer.Start(ctx, "vote.withdraw-proposal")
+	defer span.End()
+
+	unlock, err := lockElection(ctx, h.eventStore, h.repository, cmd.ElectionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	occurredAt := int(h.clock.Now().Unix())
+
+	proposal, election, err := getChangeableProposal(ctx, h.repository, cmd.ElectionID, cmd.ProposalID, occurredAt)
+	if err != nil {
+		return err
+	}
+
+	proposal.IsWithdrawn = true
+	proposal.WithdrawnAt = occurredAt
+
+	err = h.repository.ValidateProposalUpdate(ctx, proposal)
+	if err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, h.eventStore, h.repository, eventRaiser, election, event.ProposalWasWithdrawn{
+		ElectionID: cmd.ElectionID,
+		ProposalID: cmd.ProposalID,
+		OccurredAt: occurredAt,
+	})
+}
@@ -0,0 +1,154 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/votetest"
+)
+
+func TestWithdrawProposal(t *testing.T) {
+	const (
+		electionID      = "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d"
+		proposalID1     = "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e"
+		proposalID2     = "8c9d0e1f-2a3b-4c4d-8e5f-6a7b8c9d0e1f"
+		otherUserID     = "9d0e1f2a-3b4c-4d5e-9f6a-7b8c9d0e1f2a"
+		organizerUserID = "0e1f2a3b-4c5d-4e6f-8a7b-8c9d0e1f2a3b"
+	)
+
+	seedElection := func(t *testing.T, ctx context.Context, repository electionrepository.Repository, ownerUserID string) {
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID1,
+			OwnerUserID: ownerUserID,
+			ProposedAt:  1,
+		}))
+		require.NoError(t, repository.SaveProposal(ctx, electionrepository.Proposal{
+			ElectionID:  electionID,
+			ProposalID:  proposalID2,
+			OwnerUserID: otherUserID,
+			ProposedAt:  2,
+		}))
+	}
+
+	t.Run("withdraws proposal so it is no longer listed or ranked", func(t *testing.T) {
+		// Given
+		app := votetest.NewTestApp(t)
+		ctx := app.GetAuthenticatedUserContext()
+		seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+		command := election.WithdrawProposal{
+			ElectionID: electionID,
+			ProposalID: proposalID1,
+		}
+
+		// When
+		response, err := app.ExecuteCommand(ctx, command)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, cqrs.CommandResponse{
+			Status: "OK",
+		}, response)
+		assert.Equal(t, event.ProposalWasWithdrawn{
+			ElectionID: electionID,
+			ProposalID: proposalID1,
+			OccurredAt: 0,
+		}, app.EventDispatcher.GetEvent(0))
+
+		listResponse, err := app.ExecuteQuery(ctx, election.ListProposals{
+			ElectionID: electionID,
+		})
+		require.NoError(t, err)
+		proposals := listResponse.(election.ListProposalsResponse)
+		assert.Equal(t, 1, proposals.TotalResults)
+		require.Len(t, proposals.Proposals, 1)
+		assert.Equal(t, proposalID2, proposals.Proposals[0].ProposalID)
+
+		actualProposal, err := app.ElectionRepository.GetProposal(ctx, proposalID1)
+		require.NoError(t, err)
+		assert.True(t, actualProposal.IsWithdrawn)
+
+		err = app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+			VoteID:            "1f2a3b4c-5d6e-4f7a-9b8c-9d0e1f2a3b4d",
+			ElectionID:        electionID,
+			UserID:            otherUserID,
+			RankedProposalIDs: []string{proposalID2, proposalID1},
+		})
+		require.Equal(t, electionrepository.NewErrProposalWithdrawn(proposalID1), err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when user neither owns proposal nor organizes election", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, otherUserID)
+			command := election.WithdrawProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID1,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when a ballot ranks the proposal", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			require.NoError(t, app.ElectionRepository.SaveVote(ctx, electionrepository.Vote{
+				VoteID:            "2a3b4c5d-6e7f-4a8b-8c9d-0e1f2a3b4c5e",
+				ElectionID:        electionID,
+				UserID:            otherUserID,
+				RankedProposalIDs: []string{proposalID2, proposalID1},
+			}))
+			command := election.WithdrawProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID1,
+			}
+
+			// When
+			_, err := app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrProposalHasVotes(proposalID1), err)
+			assert.Empty(t, app.EventDispatcher.GetEvents())
+		})
+
+		t.Run("when proposal is already withdrawn", func(t *testing.T) {
+			// Given
+			app := votetest.NewTestApp(t)
+			ctx := app.GetAuthenticatedUserContext()
+			seedElection(t, ctx, app.ElectionRepository, app.RegularUserID)
+			command := election.WithdrawProposal{
+				ElectionID: electionID,
+				ProposalID: proposalID1,
+			}
+			_, err := app.ExecuteCommand(ctx, command)
+			require.NoError(t, err)
+
+			// When
+			_, err = app.ExecuteCommand(ctx, command)
+
+			// Then
+			require.Equal(t, electionrepository.NewErrProposalWithdrawn(proposalID1), err)
+			assert.Len(t, app.EventDispatcher.GetEvents(), 1)
+		})
+	})
+}
@@ -5,6 +5,9 @@ import (
 	_ "embed"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/inklabs/cqrs"
 	"github.com/inklabs/cqrs/asynccommandbus"
@@ -30,6 +33,9 @@ import (
 	"github.com/inklabs/vote/internal/electionrepository"
 	"github.com/inklabs/vote/internal/electionrepository/inmemoryrepo"
 	"github.com/inklabs/vote/internal/electionrepository/postgresrepo"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/eventstore/inmemorystore"
+	"github.com/inklabs/vote/internal/scheduler"
 	"github.com/inklabs/vote/listener"
 )
 
@@ -56,8 +62,11 @@ type app struct {
 	ctxShutdowns           []func(ctx context.Context) error
 	meterProvider          metric.MeterProvider
 	tracerProvider         trace.TracerProvider
+	schedulerInterval      time.Duration
+	scheduler              *scheduler.Scheduler
 
 	electionRepository electionrepository.Repository
+	eventStore         eventstore.Store
 }
 
 type Option func(a *app)
@@ -92,6 +101,12 @@ func WithElectionRepository(repository electionrepository.Repository) Option {
 	}
 }
 
+func WithEventStore(store eventstore.Store) Option {
+	return func(a *app) {
+		a.eventStore = store
+	}
+}
+
 func WithCtxShutdown(shutdowns ...func(ctx context.Context) error) Option {
 	return func(a *app) {
 		a.ctxShutdowns = append(a.ctxShutdowns, shutdowns...)
@@ -105,12 +120,19 @@ func WithTelemetry(meterProvider metric.MeterProvider, tracerProvider trace.Trac
 	}
 }
 
+func WithScheduler(interval time.Duration) Option {
+	return func(a *app) {
+		a.schedulerInterval = interval
+	}
+}
+
 func NewApp(opts ...Option) *app {
 	a := &app{
 		clock:              systemclock.New(),
 		authorization:      cqrstest.NewPassThruAuth(),
 		asyncCommandStore:  asynccommandstore.NewInMemory(),
 		electionRepository: inmemoryrepo.New(),
+		eventStore:         inmemorystore.New(),
 		meterProvider:      otel.GetMeterProvider(),
 		tracerProvider:     otel.GetTracerProvider(),
 	}
@@ -159,10 +181,25 @@ func NewApp(opts ...Option) *app {
 		a.tracerProvider,
 	)
 
+	if a.schedulerInterval > 0 {
+		a.scheduler = scheduler.New(
+			a.electionRepository,
+			a.asyncCommandBus,
+			a.asyncCommandStore,
+			a.clock,
+			a.schedulerInterval,
+			log.Default(),
+		)
+		a.scheduler.Start()
+	}
+
 	return a
 }
 
-func NewProdApp() *app {
+// NewProdApp returns the production app. Bearer tokens are verified, and actions
+// checked against the default policy, only when JWT authorization is configured
+// in the environment; otherwise every request is allowed, as with NewApp.
+func NewProdApp() (*app, error) {
 	//resource := NewResource()
 	//
 	//conn := NewOLTPConn()
@@ -174,6 +211,7 @@ func NewProdApp() *app {
 	eventDispatcher := newDistributedEventDispatcher(meterProvider, tracerProvider)
 
 	repository := inmemoryrepo.New()
+	eventStore := inmemorystore.New()
 	asyncCommandStore := asynccommandstore.NewInMemory()
 
 	//config := getPostgresConfig()
@@ -187,6 +225,16 @@ func NewProdApp() *app {
 	//	log.Fatalf("error initializing repository: %s", err)
 	//}
 
+	//db, err := postgresrepo.NewDB(config)
+	//if err != nil {
+	//	log.Fatalf("error loading event store: %s", err)
+	//}
+	//eventStore := postgresstore.NewFromDB(db, eventstore.NewElectionCodec())
+	//err = eventStore.InitDB(ctx)
+	//if err != nil {
+	//	log.Fatalf("error initializing event store: %s", err)
+	//}
+
 	//postgresConfig := asynccommandstore.PostgresConfig{
 	//	Host:       config.Host,
 	//	Port:       config.Port,
@@ -205,17 +253,24 @@ func NewProdApp() *app {
 	//	log.Fatalf("error initializing async command store: %s", err)
 	//}
 
+	auth, err := getAuthorization(repository)
+	if err != nil {
+		return nil, err
+	}
+
 	return NewApp(
-		WithAuthorization(authorization.NewDelayAuth()),
+		WithAuthorization(auth),
 		WithAsyncCommandStore(asyncCommandStore),
 		WithEventDispatcher(eventDispatcher),
 		WithElectionRepository(repository),
+		WithEventStore(eventStore),
 		WithTelemetry(meterProvider, tracerProvider),
+		WithScheduler(10*time.Second),
 		//WithCtxShutdown(
 		//	tracerProvider.Shutdown,
 		//	meterProvider.Shutdown,
 		//),
-	)
+	), nil
 }
 
 func (a *app) CommandBus() cqrs.CommandBus {
@@ -238,7 +293,15 @@ func (a *app) TracerProvider() trace.TracerProvider {
 	return a.tracerProvider
 }
 
+// HasPersistentEventStore reports whether the app's events outlive the process.
+func (a *app) HasPersistentEventStore() bool {
+	return !inmemorystore.IsInMemory(a.eventStore)
+}
+
 func (a *app) Stop() {
+	if a.scheduler != nil {
+		a.scheduler.Stop()
+	}
 	a.asyncCommandBus.Stop()
 	a.eventDispatcher.Stop()
 	_ = a.asyncCommandStore.Close()
@@ -250,25 +313,46 @@ func (a *app) Stop() {
 
 func (a *app) getCommandHandlers() []cqrs.CommandHandler {
 	return []cqrs.CommandHandler{
-		election.NewCommenceElectionHandler(a.electionRepository, a.clock),
-		election.NewMakeProposalHandler(a.electionRepository, a.clock),
-		election.NewCastVoteHandler(a.electionRepository, a.clock),
+		election.NewCommenceElectionHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewMakeProposalHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewUpdateProposalHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewWithdrawProposalHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewApproveProposalHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewRejectProposalHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewCastVoteHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewRegisterEligibleVoterHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewRemoveEligibleVoterHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewIssueBallotTokenHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewCastSecretBallotHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewCancelElectionHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewReopenElectionHandler(a.electionRepository, a.eventStore, a.clock),
 	}
 }
 
 func (a *app) getAsyncCommandHandlers() []cqrs.AsyncCommandHandler {
 	return []cqrs.AsyncCommandHandler{
-		election.NewCloseElectionByOwnerHandler(a.electionRepository, a.clock),
+		election.NewCloseElectionByOwnerHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewRebuildElectionProjectionsHandler(a.electionRepository, a.eventStore),
+		election.NewImportBallotsHandler(a.electionRepository, a.eventStore, a.clock),
+		election.NewImportEligibleVotersHandler(a.electionRepository, a.eventStore, a.clock),
 	}
 }
 
 func (a *app) getQueryHandlers() []cqrs.QueryHandler {
 	return []cqrs.QueryHandler{
 		election.NewListOpenElectionsHandler(a.electionRepository),
+		election.NewListElectionsHandler(a.electionRepository),
 		election.NewListProposalsHandler(a.electionRepository),
+		election.NewListPendingProposalsHandler(a.electionRepository),
 		election.NewGetElectionHandler(a.electionRepository),
 		election.NewGetProposalDetailsHandler(a.electionRepository),
 		election.NewGetElectionResultsHandler(a.electionRepository),
+		election.NewExportBallotsHandler(a.electionRepository),
+		election.NewGetTurnoutHandler(a.electionRepository),
+		election.NewGetBallotReceiptHandler(a.electionRepository),
+		election.NewGetBallotTokenHandler(a.electionRepository),
+		election.NewVerifyBallotReceiptHandler(a.electionRepository),
+		election.NewVerifyElectionIntegrityHandler(a.electionRepository),
 	}
 }
 
@@ -333,3 +417,66 @@ func getPostgresConfig() postgresrepo.Config {
 		SearchPath: "",
 	}
 }
+
+// getAuthorization verifies bearer tokens and applies the default policy when JWT
+// authorization is configured, and otherwise allows every request.
+func getAuthorization(repository electionrepository.Repository) (cqrs.Authorization, error) {
+	authenticator, err := getJWTAuthorization()
+	if err != nil {
+		return nil, err
+	}
+
+	if authenticator == nil {
+		return cqrstest.NewPassThruAuth(), nil
+	}
+
+	return authorization.NewPolicyAuthorization(
+		authenticator,
+		authorization.DefaultPolicy(),
+		authorization.NewElectionRoleProvider(repository),
+	), nil
+}
+
+// getJWTAuthorization configures bearer token verification from environment
+// variables: VOTE_JWT_SIGNING_KEY for HS256 tokens, VOTE_JWKS_URL for tokens
+// from an identity provider, and optionally VOTE_JWT_ISSUER and
+// VOTE_JWT_AUDIENCE. It returns nil when neither a signing key nor a JWKS URL
+// is set.
+func getJWTAuthorization() (authorization.Authenticator, error) {
+	signingKey := os.Getenv("VOTE_JWT_SIGNING_KEY")
+	jwksURL := os.Getenv("VOTE_JWKS_URL")
+	issuer := os.Getenv("VOTE_JWT_ISSUER")
+	audience := os.Getenv("VOTE_JWT_AUDIENCE")
+
+	if signingKey == "" && jwksURL == "" {
+		if issuer != "" || audience != "" {
+			return nil, fmt.Errorf("VOTE_JWT_ISSUER and VOTE_JWT_AUDIENCE require VOTE_JWT_SIGNING_KEY or VOTE_JWKS_URL")
+		}
+
+		return nil, nil
+	}
+
+	var opts []authorization.JWTOption
+
+	if jwksURL != "" {
+		httpClient := &http.Client{Timeout: 10 * time.Second}
+		opts = append(opts, authorization.WithJWKS(
+			authorization.NewJWKSURL(jwksURL, httpClient, authorization.DefaultJWKSRefreshInterval),
+		))
+	}
+
+	if issuer != "" {
+		opts = append(opts, authorization.WithIssuer(issuer))
+	}
+
+	if audience != "" {
+		opts = append(opts, authorization.WithAudience(audience))
+	}
+
+	var key []byte
+	if signingKey != "" {
+		key = []byte(signingKey)
+	}
+
+	return authorization.NewJWTAuthorization(key, opts...), nil
+}
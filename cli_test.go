@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/inklabs/cqrs/cqrstest"
+	"github.com/inklabs/cqrs/pkg/clock/provider/incrementingclock"
 
 	"github.com/inklabs/vote"
 )
@@ -28,7 +29,7 @@ func ExampleApp_cliRoot_help() {
 	// Available Commands:
 	//   async-command-status Async Command Status
 	//   completion           Generate the autocompletion script for the specified shell
-	//   election             9 actions: [CastVote, CloseElectionByOwner, CommenceElection, GetElection, GetElectionResults, GetProposalDetails, ListOpenElections, ListProposals, MakeProposal]
+	//   election             30 actions: [ApproveProposal, CancelElection, CastSecretBallot, CastVote, CloseElectionByOwner, CommenceElection, ExportBallots, GetBallotReceipt, GetBallotToken, GetElection, GetElectionResults, GetProposalDetails, GetTurnout, ImportBallots, ImportEligibleVoters, IssueBallotToken, ListElections, ListOpenElections, ListPendingProposals, ListProposals, MakeProposal, RebuildElectionProjections, RegisterEligibleVoter, RejectProposal, RemoveEligibleVoter, ReopenElection, UpdateProposal, VerifyBallotReceipt, VerifyElectionIntegrity, WithdrawProposal]
 	//   help                 Help about any command
 	//
 	// Flags:
@@ -52,15 +53,36 @@ func ExampleApp_cliElection_help() {
 	//   cli election [command]
 	//
 	// Available Commands:
+	//   ApproveProposal
+	//   CancelElection
+	//   CastSecretBallot
 	//   CastVote
 	//   CloseElectionByOwner
 	//   CommenceElection
+	//   ExportBallots
+	//   GetBallotReceipt
+	//   GetBallotToken
 	//   GetElection
 	//   GetElectionResults
 	//   GetProposalDetails
+	//   GetTurnout
+	//   ImportBallots
+	//   ImportEligibleVoters
+	//   IssueBallotToken
+	//   ListElections
 	//   ListOpenElections
+	//   ListPendingProposals
 	//   ListProposals
 	//   MakeProposal
+	//   RebuildElectionProjections
+	//   RegisterEligibleVoter
+	//   RejectProposal
+	//   RemoveEligibleVoter
+	//   ReopenElection
+	//   UpdateProposal
+	//   VerifyBallotReceipt
+	//   VerifyElectionIntegrity
+	//   WithdrawProposal
 	//
 	// Flags:
 	//   -h, --help   help for election
@@ -127,7 +149,7 @@ func ExampleApp_cliElectionCloseElectionByOwner() {
 	)
 	defer app.Stop()
 
-	recordingEventDispatcher.Add(4)
+	recordingEventDispatcher.Add(5)
 
 	cmd := vote.GetCobraRootCommand(app)
 	cmd.SetOut(NewTrimmingWriter(os.Stdout))
@@ -181,7 +203,8 @@ func ExampleApp_cliElectionCloseElectionByOwner() {
 	// AsyncCommandStatus: *cqrs.AsyncCommandStatus {
 	//   "Command": {
 	//     "ID": "AC1",
-	//     "ElectionID": "E1"
+	//     "ElectionID": "E1",
+	//     "AllowBrokenBallotChain": false
 	//   },
 	//   "CreatedAt": 1699900003,
 	//   "ModifiedAt": 1699900007,
@@ -203,6 +226,41 @@ func ExampleApp_cliElectionCloseElectionByOwner() {
 	// ]
 }
 
+func ExampleApp_cliReplay() {
+	app := vote.NewApp(
+		vote.WithClock(incrementingclock.New(time.Unix(1699900000, 0))),
+	)
+	defer app.Stop()
+
+	cmd := vote.GetCobraRootCommand(app)
+	cmd.AddCommand(vote.NewReplayCommand(app))
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"election", "CommenceElection",
+		"--ElectionID", "E1",
+		"--Name", "Election Name 1",
+		"--Description", "Election Description 1",
+		"--OrganizerUserID", "U1",
+	})
+	_ = cmd.Execute()
+	cmd.SetArgs([]string{"election", "CommenceElection",
+		"--ElectionID", "E2",
+		"--Name", "Election Name 2",
+		"--Description", "Election Description 2",
+		"--OrganizerUserID", "U1",
+	})
+	_ = cmd.Execute()
+
+	cmd.SetOut(NewTrimmingWriter(os.Stdout))
+	cmd.SetArgs([]string{"replay"})
+	_ = cmd.Execute()
+
+	// Output:
+	// Replaying events for 2 elections
+	// 1/2
+	// 2/2
+	// Replayed 2 elections
+}
+
 func ExampleApp_cliListOpenElections() {
 	recordingEventDispatcher := cqrstest.NewRecordingEventDispatcher()
 	app := newTestApp(
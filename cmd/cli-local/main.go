@@ -11,12 +11,21 @@ import (
 func main() {
 	fmt.Println("Vote - Local CLI")
 
-	app := vote.NewProdApp()
+	app, err := vote.NewProdApp()
+	if err != nil {
+		log.Fatalf("unable to create application: %v", err)
+	}
 	defer app.Stop()
 
 	command := vote.GetCobraRootCommand(app)
+	if app.HasPersistentEventStore() {
+		// An in-memory event store starts empty in every process, so replaying
+		// it would wipe the read models instead of rebuilding them.
+		command.AddCommand(vote.NewReplayCommand(app))
+	}
+	command.AddCommand(vote.NewVerifyReceiptCommand())
 	command.SetOut(os.Stdout)
-	err := command.Execute()
+	err = command.Execute()
 	if err != nil {
 		log.Fatalf("unable to execute application: %v", err)
 	}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/inklabs/cqrs/api/grpcserver"
 	"google.golang.org/grpc"
@@ -13,7 +14,10 @@ import (
 func main() {
 	fmt.Println("Vote - gRPC API")
 
-	app := vote.NewProdApp()
+	app, err := vote.NewProdApp()
+	if err != nil {
+		log.Fatalf("unable to create application: %v", err)
+	}
 
 	grpcserver.Start(app, func(grpcServer *grpc.Server) {
 		voteserver.RegisterServers(grpcServer, app)
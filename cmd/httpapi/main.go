@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"net/url"
 
 	"github.com/inklabs/cqrs/api/httpserver"
@@ -12,7 +13,10 @@ import (
 func main() {
 	fmt.Println("Vote - HTTP API")
 
-	app := vote.NewProdApp()
+	app, err := vote.NewProdApp()
+	if err != nil {
+		log.Fatalf("unable to create application: %v", err)
+	}
 	httpActionDecoder := vote.NewHTTPActionDecoder()
 
 	baseURI := url.URL{
@@ -21,7 +21,10 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	app := vote.NewProdApp()
+	app, err := vote.NewProdApp()
+	if err != nil {
+		log.Fatalf("unable to create application: %v", err)
+	}
 
 	eventRegistry := cqrs.NewEventRegistry()
 	event.BindEvents(eventRegistry)
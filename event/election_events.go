@@ -1,11 +1,26 @@
 package event
 
 type ElectionHasCommenced struct {
-	ElectionID      string
-	OrganizerUserID string
-	Name            string
-	Description     string
-	OccurredAt      int
+	ElectionID             string
+	OrganizerUserID        string
+	Name                   string
+	Description            string
+	SeatCount              int
+	VotingMethod           string
+	TieBreakPolicy         string
+	RevotePolicy           string
+	EligibilityPolicy      string
+	BallotSecrecy          string
+	MaxRanks               int
+	RequireCompleteRanking bool
+	AllowDuplicateRanks    bool
+	AllowSkippedRanks      bool
+	AllowWriteIns          bool
+	ModerateProposals      bool
+	ProposalDeadline       int
+	VotingStartsAt         int
+	VotingEndsAt           int
+	OccurredAt             int
 }
 
 type ProposalWasMade struct {
@@ -17,21 +32,143 @@ type ProposalWasMade struct {
 	ProposedAt  int
 }
 
+type ProposalWasUpdated struct {
+	ElectionID  string
+	ProposalID  string
+	Name        string
+	Description string
+	OccurredAt  int
+}
+
+type ProposalWasWithdrawn struct {
+	ElectionID string
+	ProposalID string
+	OccurredAt int
+}
+
+type ProposalWasApproved struct {
+	ElectionID string
+	ProposalID string
+	OccurredAt int
+}
+
+type ProposalWasRejected struct {
+	ElectionID string
+	ProposalID string
+	Reason     string
+	OccurredAt int
+}
+
+// VoteWasCast has IsImported set, and no UserID, when imported as a paper ballot.
+// Receipt is the voter's commitment to the ballot, as computed by ballotreceipt.Receipt.
 type VoteWasCast struct {
 	VoteID            string
 	ElectionID        string
 	UserID            string
 	RankedProposalIDs []string
+	Receipt           string
+	IsImported        bool
 	OccurredAt        int
 }
 
+// SecretBallotWasCast has no UserID or OccurredAt, so it cannot be matched to
+// the BallotTokenWasIssued event of the voter. BallotTokenHash identifies the
+// spent ballot token.
+type SecretBallotWasCast struct {
+	VoteID            string
+	ElectionID        string
+	RankedProposalIDs []string
+	BallotTokenHash   string
+	Receipt           string
+}
+
+type VoteWasReplaced struct {
+	ElectionID     string
+	UserID         string
+	VoteID         string
+	ReplacedVoteID string
+	OccurredAt     int
+}
+
+type EligibleVoterWasRegistered struct {
+	ElectionID string
+	UserID     string
+	OccurredAt int
+}
+
+type EligibleVoterWasRemoved struct {
+	ElectionID string
+	UserID     string
+	OccurredAt int
+}
+
+type BallotTokenWasIssued struct {
+	ElectionID     string
+	UserID         string
+	BlindSignature []byte
+	OccurredAt     int
+}
+
+// ElectionWasClosedByOwner records the VoteHash of the last ballot in the election's
+// ballot chain, and the number of ballots in it, so VerifyElectionIntegrity can detect
+// ballots added to or removed from the end of the chain after the election closed.
 type ElectionWasClosedByOwner struct {
+	ElectionID      string
+	BallotChainHead string
+	BallotCount     int
+	OccurredAt      int
+}
+
+type ElectionWasCancelled struct {
 	ElectionID string
+	Reason     string
 	OccurredAt int
 }
 
+// ElectionWasReopened clears the results of a closed or cancelled election, and
+// resumes voting until VotingEndsAt, or until it is closed when VotingEndsAt is 0.
+type ElectionWasReopened struct {
+	ElectionID   string
+	VotingEndsAt int
+	OccurredAt   int
+}
+
+// ElectionWinnerWasSelected records the TieBreakSeed used by the Random
+// tie-break policy, so the tabulation can be reproduced.
 type ElectionWinnerWasSelected struct {
-	ElectionID        string
-	WinningProposalID string
-	SelectedAt        int
+	ElectionID         string
+	WinningProposalID  string
+	WinningProposalIDs []string
+	TabulationRounds   []TabulationRound
+	ReceiptRoot        string
+	TieBreakSeed       int64
+	SelectedAt         int
+}
+
+// ElectionClosedWithoutWinner records why an election closed without a winner,
+// as one of the NoVotes, NoMajority, or Tie outcomes.
+type ElectionClosedWithoutWinner struct {
+	ElectionID       string
+	Outcome          string
+	TabulationRounds []TabulationRound
+	ReceiptRoot      string
+	TieBreakSeed     int64
+	OccurredAt       int
+}
+
+type TabulationRound struct {
+	Number               int
+	ProposalCounts       []ProposalCount
+	EliminatedProposalID string
+	ElectedProposalIDs   []string
+	TransferValue        float64
+	UsedBordaTieBreaker  bool
+	TiedProposalIDs      []string
+	ExhaustedBallots     int
+}
+
+type ProposalCount struct {
+	ProposalID    string
+	Count         int
+	WeightedCount float64
 }
@@ -28,7 +28,7 @@ func ExampleApp_httpCloseElectionByOwner() {
 	defer app.Stop()
 	api, _ := jsonapi.New(app, vote.NewHTTPActionDecoder(), baseUri, schemaBaseUri, version)
 
-	recordingEventDispatcher.Add(4)
+	recordingEventDispatcher.Add(5)
 
 	body, _ := json.Marshal(election.CommenceElection{
 		ElectionID:      "E1",
@@ -104,7 +104,22 @@ func ExampleApp_httpCloseElectionByOwner() {
 	//         "ElectionID": "E1",
 	//         "OrganizerUserID": "U1",
 	//         "Name": "Election Name",
-	//         "Description": "Election Description"
+	//         "Description": "Election Description",
+	//         "SeatCount": null,
+	//         "VotingMethod": null,
+	//         "TieBreakPolicy": null,
+	//         "RevotePolicy": null,
+	//         "EligibilityPolicy": null,
+	//         "BallotSecrecy": null,
+	//         "MaxRanks": null,
+	//         "RequireCompleteRanking": false,
+	//         "AllowDuplicateRanks": false,
+	//         "AllowSkippedRanks": false,
+	//         "AllowWriteIns": false,
+	//         "ModerateProposals": false,
+	//         "ProposalDeadline": null,
+	//         "VotingStartsAt": null,
+	//         "VotingEndsAt": null
 	//       },
 	//       "type": "election.CommenceElection"
 	//     }
@@ -177,7 +192,8 @@ func ExampleApp_httpCloseElectionByOwner() {
 	//     "request": {
 	//       "attributes": {
 	//         "ID": "AC1",
-	//         "ElectionID": "E1"
+	//         "ElectionID": "E1",
+	//         "AllowBrokenBallotChain": false
 	//       },
 	//       "type": "election.CloseElectionByOwner"
 	//     }
@@ -188,7 +204,8 @@ func ExampleApp_httpCloseElectionByOwner() {
 	//     "attributes": {
 	//       "Command": {
 	//         "ID": "AC1",
-	//         "ElectionID": "E1"
+	//         "ElectionID": "E1",
+	//         "AllowBrokenBallotChain": false
 	//       },
 	//       "CreatedAt": 1699900003,
 	//       "ModifiedAt": 1699900007,
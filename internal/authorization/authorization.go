@@ -13,6 +13,11 @@ type Context interface {
 	IsAdmin() bool
 }
 
+// Authenticator identifies the user making a request.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (Context, error)
+}
+
 type CommandVerifier interface {
 	VerifyAuthorization(ctx Context, command cqrs.Command) error
 }
@@ -0,0 +1,45 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// RoleProvider returns the roles a user holds because of the state of an
+// election, such as being its organizer.
+type RoleProvider interface {
+	ElectionRoles(ctx context.Context, userID, electionID string) ([]Role, error)
+}
+
+type electionRoleProvider struct {
+	repository electionrepository.Repository
+}
+
+func NewElectionRoleProvider(repository electionrepository.Repository) *electionRoleProvider {
+	return &electionRoleProvider{
+		repository: repository,
+	}
+}
+
+// ElectionRoles grants RoleOrganizer to the organizer of the election. An
+// election that does not exist grants no roles; the handler reports it as not
+// found.
+func (p *electionRoleProvider) ElectionRoles(ctx context.Context, userID, electionID string) ([]Role, error) {
+	election, err := p.repository.GetElection(ctx, electionID)
+	if err != nil {
+		var errElectionNotFound *electionrepository.ErrElectionNotFound
+		if errors.As(err, &errElectionNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if election.OrganizerUserID == userID {
+		return []Role{RoleOrganizer}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,245 @@
+package authorization
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultJWKSRefreshInterval = 15 * time.Minute
+
+	// minJWKSRefreshInterval limits how often an unknown kid can trigger a remote
+	// JWKS fetch.
+	minJWKSRefreshInterval = 30 * time.Second
+)
+
+// KeySource returns the public key used to verify a token signed with the given
+// key ID (kid).
+type KeySource interface {
+	PublicKey(ctx context.Context, keyID string) (crypto.PublicKey, error)
+}
+
+// jwksSource caches the keys from a JSON Web Key Set (RFC 7517). The keys are
+// reloaded once they are older than refreshInterval, or when a token is signed
+// with an unknown kid, such as after the identity provider rotates its keys.
+type jwksSource struct {
+	load               func(ctx context.Context) ([]byte, error)
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+
+	mux      sync.Mutex
+	keys     map[string]crypto.PublicKey
+	loadedAt time.Time
+}
+
+// NewJWKSFile returns a KeySource that reads a JSON Web Key Set from a local
+// file. The file is read again whenever a token references an unknown kid.
+func NewJWKSFile(path string) *jwksSource {
+	return newJWKSSource(func(_ context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, DefaultJWKSRefreshInterval, 0)
+}
+
+// NewJWKSURL returns a KeySource that fetches a JSON Web Key Set from url, such
+// as an identity provider's jwks_uri, and caches it for refreshInterval.
+func NewJWKSURL(url string, httpClient *http.Client, refreshInterval time.Duration) *jwksSource {
+	return newJWKSSource(func(ctx context.Context) ([]byte, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := httpClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = response.Body.Close() }()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", response.StatusCode)
+		}
+
+		return io.ReadAll(response.Body)
+	}, refreshInterval, minJWKSRefreshInterval)
+}
+
+func newJWKSSource(load func(ctx context.Context) ([]byte, error), refreshInterval, minRefreshInterval time.Duration) *jwksSource {
+	return &jwksSource{
+		load:               load,
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: minRefreshInterval,
+	}
+}
+
+func (s *jwksSource) PublicKey(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	sinceLoaded := time.Since(s.loadedAt)
+	_, isKnownKey := s.keys[keyID]
+
+	if s.keys == nil ||
+		sinceLoaded >= s.refreshInterval ||
+		(!isKnownKey && sinceLoaded >= s.minRefreshInterval) {
+		err := s.refresh(ctx)
+		if err != nil && s.keys == nil {
+			return nil, err
+		}
+	}
+
+	key, ok := s.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, keyID)
+	}
+
+	return key, nil
+}
+
+// refresh reloads the key set. The previous keys are kept if the key set cannot
+// be loaded, so a temporary outage of the identity provider does not reject
+// every token.
+func (s *jwksSource) refresh(ctx context.Context) error {
+	s.loadedAt = time.Now()
+
+	data, err := s.load(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to load JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+
+	return nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// parseJWKS returns the RSA and EC signing keys in the key set, keyed by kid.
+// Keys for other uses, or of other types, are ignored.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var keySet jsonWebKeySet
+	err := json.Unmarshal(data, &keySet)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		switch jwk.KeyType {
+		case "RSA":
+			key, err = jwk.rsaPublicKey()
+		case "EC":
+			key, err = jwk.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse JWK %s: %w", jwk.KeyID, err)
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: n,
+		E: int(e.Int64()),
+	}, nil
+}
+
+func (k jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Curve {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", k.Curve)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on curve")
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+var ErrUnknownKeyID = errors.New("unknown key id")
@@ -2,6 +2,9 @@ package authorization
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +16,8 @@ import (
 
 const Expiration = 24 * time.Hour
 
+// JWTClaims identifies the user by UserID. Tokens from an identity provider
+// without a UserID claim identify the user by the standard sub claim instead.
 type JWTClaims struct {
 	jwt.RegisteredClaims
 	Email   string
@@ -43,12 +48,56 @@ func (a jwtClaimsContext) IsAdmin() bool {
 
 type jwtAuthorization struct {
 	signingKey []byte
+	keySource  KeySource
+	issuer     string
+	audience   string
+	clockSkew  time.Duration
 }
 
-func NewJWTAuthorization(signingKey []byte) *jwtAuthorization {
-	return &jwtAuthorization{
+type JWTOption func(*jwtAuthorization)
+
+// WithJWKS verifies RS256 and ES256 tokens against the public key matching the
+// token's kid header.
+func WithJWKS(keySource KeySource) JWTOption {
+	return func(a *jwtAuthorization) {
+		a.keySource = keySource
+	}
+}
+
+// WithIssuer rejects tokens whose iss claim does not match issuer.
+func WithIssuer(issuer string) JWTOption {
+	return func(a *jwtAuthorization) {
+		a.issuer = issuer
+	}
+}
+
+// WithAudience rejects tokens whose aud claim does not contain audience.
+func WithAudience(audience string) JWTOption {
+	return func(a *jwtAuthorization) {
+		a.audience = audience
+	}
+}
+
+// WithClockSkew tolerates clock differences with the token issuer when
+// validating the exp, nbf, and iat claims.
+func WithClockSkew(clockSkew time.Duration) JWTOption {
+	return func(a *jwtAuthorization) {
+		a.clockSkew = clockSkew
+	}
+}
+
+// NewJWTAuthorization verifies HS256 tokens with signingKey. Pass a nil
+// signingKey along with WithJWKS to only accept asymmetric tokens.
+func NewJWTAuthorization(signingKey []byte, opts ...JWTOption) *jwtAuthorization {
+	a := &jwtAuthorization{
 		signingKey: signingKey,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 func (a *jwtAuthorization) VerifyCommand(ctx context.Context, handler cqrs.CommandHandler, command cqrs.Command) error {
@@ -126,11 +175,31 @@ func (a *jwtAuthorization) VerifyRequest(ctx context.Context) error {
 	return nil
 }
 
+// Authenticate returns the claims of the bearer token in ctx.
+func (a *jwtAuthorization) Authenticate(ctx context.Context) (Context, error) {
+	claimsContext, err := a.getContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return claimsContext, nil
+}
+
 func (a *jwtAuthorization) getContext(ctx context.Context) (*jwtClaimsContext, error) {
+	if IsSystemContext(ctx) {
+		return &jwtClaimsContext{
+			claims: &JWTClaims{
+				UserID:  SystemUserID,
+				IsAdmin: true,
+			},
+			ctx: ctx,
+		}, nil
+	}
+
 	if authorizationToken, ok := ctx.Value("authorization").(string); ok {
 		splitToken := strings.Split(authorizationToken, "Bearer ")
 		if len(splitToken) > 1 {
-			claims, err := a.getClaims(splitToken[1])
+			claims, err := a.getClaims(ctx, splitToken[1])
 			if err != nil {
 				return nil, err
 			}
@@ -145,23 +214,98 @@ func (a *jwtAuthorization) getContext(ctx context.Context) (*jwtClaimsContext, e
 	return nil, cqrs.ErrAccessDenied
 }
 
-func (a *jwtAuthorization) getClaims(tokenString string) (*JWTClaims, error) {
+func (a *jwtAuthorization) getClaims(ctx context.Context, tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		return a.getVerificationKey(ctx, token)
+	}, a.parserOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("unable to get claims")
+	}
+
+	if claims.UserID == "" {
+		claims.UserID = claims.Subject
+	}
+
+	if claims.UserID == "" {
+		return nil, ErrMissingUserID
+	}
+
+	return claims, nil
+}
+
+var ErrMissingUserID = errors.New("token has no UserID or sub claim")
+
+func (a *jwtAuthorization) getVerificationKey(ctx context.Context, token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(a.signingKey) == 0 {
+			break
 		}
 
 		return a.signingKey, nil
-	})
-	if err != nil {
-		return nil, err
+
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if a.keySource == nil {
+			break
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("missing kid header")
+		}
+
+		publicKey, err := a.keySource.PublicKey(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Guard against a token claiming a different algorithm than the key
+		// was published for.
+		switch publicKey.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+				return publicKey, nil
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+				return publicKey, nil
+			}
+		}
+
+		return nil, fmt.Errorf("signing method %v does not match key %s", token.Header["alg"], keyID)
+	}
+
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+func (a *jwtAuthorization) parserOptions() []jwt.ParserOption {
+	var validMethods []string
+	if len(a.signingKey) > 0 {
+		validMethods = append(validMethods, "HS256", "HS384", "HS512")
+	}
+	if a.keySource != nil {
+		validMethods = append(validMethods, "RS256", "RS384", "RS512", "ES256", "ES384", "ES512")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithLeeway(a.clockSkew),
+	}
+
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	if a.audience != "" {
+		opts = append(opts, jwt.WithAudience(a.audience))
 	}
 
-	return nil, fmt.Errorf("unable to get claims")
+	return opts
 }
 
 func NewSignedToken(claims JWTClaims, signingKey []byte) (string, error) {
@@ -0,0 +1,405 @@
+package authorization_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/authorization"
+)
+
+const (
+	testIssuer   = "https://id.example.com"
+	testAudience = "vote"
+)
+
+func TestJWTAuthorization_VerifyRequest(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwksPath := writeJWKS(t, map[string]any{
+		"rsa-1": &rsaKey.PublicKey,
+		"ec-1":  &ecKey.PublicKey,
+	})
+	hmacKey := []byte("9742fed04ba648bcb476a13b9e3d87e3")
+
+	newAuthorization := func() cqrs.Authorization {
+		return authorization.NewJWTAuthorization(hmacKey,
+			authorization.WithJWKS(authorization.NewJWKSFile(jwksPath)),
+			authorization.WithIssuer(testIssuer),
+			authorization.WithAudience(testAudience),
+			authorization.WithClockSkew(time.Minute),
+		)
+	}
+
+	t.Run("accepts RS256 token signed by a key in the JWKS", func(t *testing.T) {
+		// Given
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims())
+
+		// When
+		err := newAuthorization().VerifyRequest(bearerContext(token))
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("accepts ES256 token signed by a key in the JWKS", func(t *testing.T) {
+		// Given
+		token := signToken(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims())
+
+		// When
+		err := newAuthorization().VerifyRequest(bearerContext(token))
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("accepts HS256 token signed with the shared key", func(t *testing.T) {
+		// Given
+		claims := validClaims()
+		token := signToken(t, jwt.SigningMethodHS256, "", hmacKey, claims)
+
+		// When
+		err := newAuthorization().VerifyRequest(bearerContext(token))
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("accepts token expired within the clock skew", func(t *testing.T) {
+		// Given
+		claims := validClaims()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		// When
+		err := newAuthorization().VerifyRequest(bearerContext(token))
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("identifies user by sub when token has no UserID", func(t *testing.T) {
+		// Given
+		claims := validClaims()
+		claims.UserID = ""
+		claims.Subject = "auth0|5f7c8ec7c33c6c004bbafe82"
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+		auth := authorization.NewJWTAuthorization(hmacKey,
+			authorization.WithJWKS(authorization.NewJWKSFile(jwksPath)),
+		)
+
+		// When
+		authContext, err := auth.Authenticate(bearerContext(token))
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "auth0|5f7c8ec7c33c6c004bbafe82", authContext.UserID())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("token expired beyond the clock skew", func(t *testing.T) {
+			// Given
+			claims := validClaims()
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Minute))
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenExpired)
+		})
+
+		t.Run("wrong issuer", func(t *testing.T) {
+			// Given
+			claims := validClaims()
+			claims.Issuer = "https://evil.example.com"
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+		})
+
+		t.Run("wrong audience", func(t *testing.T) {
+			// Given
+			claims := validClaims()
+			claims.Audience = jwt.ClaimStrings{"other-app"}
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+		})
+
+		t.Run("unknown kid", func(t *testing.T) {
+			// Given
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, validClaims())
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, authorization.ErrUnknownKeyID)
+		})
+
+		t.Run("signed by a key not in the JWKS", func(t *testing.T) {
+			// Given
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims())
+
+			// When
+			err = newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+		})
+
+		t.Run("algorithm does not match key type", func(t *testing.T) {
+			// Given
+			token := signToken(t, jwt.SigningMethodES256, "rsa-1", ecKey, validClaims())
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenUnverifiable)
+		})
+
+		t.Run("token has no UserID or sub", func(t *testing.T) {
+			// Given
+			claims := validClaims()
+			claims.UserID = ""
+			token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+			// When
+			err := newAuthorization().VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, authorization.ErrMissingUserID)
+		})
+
+		t.Run("HS256 token when only JWKS is configured", func(t *testing.T) {
+			// Given
+			auth := authorization.NewJWTAuthorization(nil,
+				authorization.WithJWKS(authorization.NewJWKSFile(jwksPath)),
+			)
+			token := signToken(t, jwt.SigningMethodHS256, "", hmacKey, validClaims())
+
+			// When
+			err := auth.VerifyRequest(bearerContext(token))
+
+			// Then
+			require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+		})
+	})
+}
+
+func TestJWKSFile(t *testing.T) {
+	t.Run("reloads the file for a rotated key", func(t *testing.T) {
+		// Given
+		firstKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		jwksPath := writeJWKS(t, map[string]any{"rsa-1": &firstKey.PublicKey})
+		keySource := authorization.NewJWKSFile(jwksPath)
+		ctx := context.Background()
+		_, err = keySource.PublicKey(ctx, "rsa-1")
+		require.NoError(t, err)
+
+		secondKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		writeJWKSFile(t, jwksPath, map[string]any{"rsa-2": &secondKey.PublicKey})
+
+		// When
+		publicKey, err := keySource.PublicKey(ctx, "rsa-2")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &secondKey.PublicKey, publicKey)
+	})
+}
+
+func TestJWKSURL(t *testing.T) {
+	t.Run("caches the key set between requests", func(t *testing.T) {
+		// Given
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		var totalRequests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			totalRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(newJWKS(t, map[string]any{"rsa-1": &rsaKey.PublicKey}))
+		}))
+		defer server.Close()
+		keySource := authorization.NewJWKSURL(server.URL, server.Client(), time.Hour)
+		ctx := context.Background()
+
+		// When
+		first, err := keySource.PublicKey(ctx, "rsa-1")
+		require.NoError(t, err)
+		second, err := keySource.PublicKey(ctx, "rsa-1")
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, &rsaKey.PublicKey, first)
+		assert.Equal(t, first, second)
+		assert.Equal(t, int32(1), totalRequests.Load())
+	})
+
+	t.Run("refreshes the key set after the refresh interval", func(t *testing.T) {
+		// Given
+		firstKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		secondKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		var totalRequests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			keys := map[string]any{"rsa-1": &firstKey.PublicKey}
+			if totalRequests.Add(1) > 1 {
+				keys = map[string]any{"rsa-2": &secondKey.PublicKey}
+			}
+			_ = json.NewEncoder(w).Encode(newJWKS(t, keys))
+		}))
+		defer server.Close()
+		keySource := authorization.NewJWKSURL(server.URL, server.Client(), 0)
+		ctx := context.Background()
+		_, err = keySource.PublicKey(ctx, "rsa-1")
+		require.NoError(t, err)
+
+		// When
+		publicKey, err := keySource.PublicKey(ctx, "rsa-2")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &secondKey.PublicKey, publicKey)
+		assert.Equal(t, int32(2), totalRequests.Load())
+	})
+
+	t.Run("keeps the cached keys when a refresh fails", func(t *testing.T) {
+		// Given
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		var totalRequests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if totalRequests.Add(1) > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(newJWKS(t, map[string]any{"rsa-1": &rsaKey.PublicKey}))
+		}))
+		defer server.Close()
+		keySource := authorization.NewJWKSURL(server.URL, server.Client(), 0)
+		ctx := context.Background()
+		_, err = keySource.PublicKey(ctx, "rsa-1")
+		require.NoError(t, err)
+
+		// When
+		publicKey, err := keySource.PublicKey(ctx, "rsa-1")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &rsaKey.PublicKey, publicKey)
+		assert.Equal(t, int32(2), totalRequests.Load())
+	})
+}
+
+func validClaims() authorization.JWTClaims {
+	now := time.Now()
+	return authorization.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+		Email:  "john.user@example.com",
+		UserID: "de06e622-9169-4351-b14e-9109dfd9dee3",
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, keyID string, key any, claims authorization.JWTClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+
+	signedToken, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signedToken
+}
+
+func bearerContext(token string) context.Context {
+	return context.WithValue(context.Background(), "authorization", "Bearer "+token)
+}
+
+func writeJWKS(t *testing.T, keys map[string]any) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKSFile(t, path, keys)
+	return path
+}
+
+func writeJWKSFile(t *testing.T, path string, keys map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(newJWKS(t, keys))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func newJWKS(t *testing.T, keys map[string]any) map[string]any {
+	t.Helper()
+	encode := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.Bytes())
+	}
+
+	var jwks []map[string]string
+	for keyID, key := range keys {
+		switch publicKey := key.(type) {
+		case *rsa.PublicKey:
+			jwks = append(jwks, map[string]string{
+				"kty": "RSA",
+				"kid": keyID,
+				"use": "sig",
+				"n":   encode(publicKey.N),
+				"e":   encode(big.NewInt(int64(publicKey.E))),
+			})
+		case *ecdsa.PublicKey:
+			jwks = append(jwks, map[string]string{
+				"kty": "EC",
+				"kid": keyID,
+				"crv": publicKey.Curve.Params().Name,
+				"x":   encode(publicKey.X),
+				"y":   encode(publicKey.Y),
+			})
+		default:
+			t.Fatalf("unsupported key type %T", key)
+		}
+	}
+
+	return map[string]any{"keys": jwks}
+}
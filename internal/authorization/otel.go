@@ -7,7 +7,12 @@ import (
 const (
 	instrumentationName = "github.com/inklabs/vote/internal/authorization/delay-auth"
 
-	UserIDKey = "user.id"
+	UserIDKey     = "user.id"
+	ElectionIDKey = "election.id"
+	ActionKey     = "authorization.action"
+	RolesKey      = "authorization.roles"
+	DecisionKey   = "authorization.decision"
+	RuleKey       = "authorization.rule"
 )
 
 var (
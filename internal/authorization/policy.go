@@ -0,0 +1,345 @@
+package authorization
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Role string
+
+const (
+	// RoleAdmin is granted to users with the IsAdmin claim, in every election.
+	RoleAdmin Role = "admin"
+
+	// RoleOrganizer is granted to the user who commenced an election.
+	RoleOrganizer Role = "organizer"
+
+	// RoleVoter may propose and cast votes in an election.
+	RoleVoter Role = "voter"
+
+	// RoleObserver may follow an election without taking part in it.
+	RoleObserver Role = "observer"
+
+	// RoleAnonymous is the only role of a request for an action that a rule
+	// allows for anonymous. The user making the request is not authenticated.
+	RoleAnonymous Role = "anonymous"
+)
+
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// DefaultDenyRule names the decision when no rule applies to a request.
+const DefaultDenyRule = "default-deny"
+
+const anyAction = "*"
+
+const (
+	userIDAttribute = "$user.id"
+	emailAttribute  = "$user.email"
+)
+
+//go:embed default_policy.yaml
+var defaultPolicy []byte
+
+// Policy decides which roles may execute each command and query. Rules are
+// evaluated in order, and the first rule that applies to a request decides it.
+// A request that no rule applies to is denied.
+type Policy struct {
+	// DefaultRoles are granted to every authenticated user in every election.
+	DefaultRoles []Role `yaml:"defaultRoles"`
+
+	// Bindings grant roles to specific users.
+	Bindings []RoleBinding `yaml:"bindings"`
+
+	Rules []Rule `yaml:"rules"`
+}
+
+// RoleBinding grants Role to UserID within ElectionID, or within every election
+// when ElectionID is empty or "*".
+type RoleBinding struct {
+	UserID     string `yaml:"userId"`
+	Role       Role   `yaml:"role"`
+	ElectionID string `yaml:"electionId"`
+}
+
+// Rule applies to a request for one of Actions, by a user holding one of Roles,
+// when every Match condition holds. Match maps a field of the command or query
+// to the expected value, either a literal or an attribute of the authenticated
+// user: $user.id or $user.email.
+type Rule struct {
+	Name    string            `yaml:"name"`
+	Effect  Effect            `yaml:"effect"`
+	Actions []string          `yaml:"actions"`
+	Roles   []Role            `yaml:"roles"`
+	Match   map[string]string `yaml:"match"`
+}
+
+// PolicyRequest describes a command or query to evaluate against a Policy.
+type PolicyRequest struct {
+	Action     string
+	ElectionID string
+	UserID     string
+	Email      string
+	Roles      []Role
+
+	// Resource is the command or query. Its fields are used by Rule.Match.
+	Resource any
+}
+
+// Decision is the outcome of evaluating a PolicyRequest. Rule names the rule
+// that allowed the request, or the rule that caused it to be denied.
+type Decision struct {
+	Allowed bool
+	Rule    string
+}
+
+// DefaultPolicy returns the policy shipped with the application.
+func DefaultPolicy() *Policy {
+	policy, err := ParsePolicy(defaultPolicy)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default policy: %v", err))
+	}
+
+	return policy
+}
+
+// LoadPolicyFile reads a policy from a YAML or JSON file.
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses a YAML or JSON policy. Unknown fields are rejected so a
+// misspelled key cannot silently widen or narrow access.
+func ParsePolicy(data []byte) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var policy Policy
+	err := decoder.Decode(&policy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	err = policy.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	return &policy, nil
+}
+
+func (p *Policy) validate() error {
+	ruleNames := make(map[string]struct{}, len(p.Rules))
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d is missing a name", i+1)
+		}
+
+		if _, ok := ruleNames[rule.Name]; ok {
+			return fmt.Errorf("duplicate rule %s", rule.Name)
+		}
+		ruleNames[rule.Name] = struct{}{}
+
+		switch rule.Effect {
+		case "":
+			rule.Effect = EffectAllow
+		case EffectAllow, EffectDeny:
+		default:
+			return fmt.Errorf("rule %s has unknown effect %s", rule.Name, rule.Effect)
+		}
+
+		if len(rule.Actions) == 0 {
+			return fmt.Errorf("rule %s is missing actions", rule.Name)
+		}
+
+		if len(rule.Roles) == 0 {
+			return fmt.Errorf("rule %s is missing roles", rule.Name)
+		}
+
+		for _, role := range rule.Roles {
+			if !isKnownRole(role) {
+				return fmt.Errorf("rule %s has unknown role %s", rule.Name, role)
+			}
+		}
+	}
+
+	for _, role := range p.DefaultRoles {
+		if !isKnownRole(role) || role == RoleAnonymous {
+			return fmt.Errorf("unknown default role %s", role)
+		}
+	}
+
+	for _, binding := range p.Bindings {
+		if binding.UserID == "" {
+			return fmt.Errorf("binding for role %s is missing a userId", binding.Role)
+		}
+
+		if !isKnownRole(binding.Role) || binding.Role == RoleAnonymous {
+			return fmt.Errorf("binding for user %s has unknown role %s", binding.UserID, binding.Role)
+		}
+	}
+
+	return nil
+}
+
+// Roles returns the roles granted to userID within electionID by the policy
+// itself, excluding roles derived from the election or the user's claims.
+func (p *Policy) Roles(userID, electionID string) []Role {
+	roles := slices.Clone(p.DefaultRoles)
+
+	for _, binding := range p.Bindings {
+		if binding.UserID != userID {
+			continue
+		}
+
+		if binding.ElectionID == "" || binding.ElectionID == "*" || binding.ElectionID == electionID {
+			roles = append(roles, binding.Role)
+		}
+	}
+
+	return roles
+}
+
+// IsAnonymous reports whether a rule allows action for the anonymous role. Such
+// actions are listed by name, as "*" does not match them.
+func (p *Policy) IsAnonymous(action string) bool {
+	for _, rule := range p.Rules {
+		if rule.Effect == EffectAllow && slices.Contains(rule.Actions, action) && slices.Contains(rule.Roles, RoleAnonymous) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evaluate decides request. When a request is denied because no rule applied,
+// the decision names the first rule that applied to the action and roles but
+// whose Match conditions failed, or DefaultDenyRule.
+func (p *Policy) Evaluate(request PolicyRequest) Decision {
+	failedRule := DefaultDenyRule
+
+	for _, rule := range p.Rules {
+		if !rule.appliesTo(request) {
+			continue
+		}
+
+		if !rule.matches(request) {
+			if failedRule == DefaultDenyRule {
+				failedRule = rule.Name
+			}
+			continue
+		}
+
+		return Decision{
+			Allowed: rule.Effect == EffectAllow,
+			Rule:    rule.Name,
+		}
+	}
+
+	return Decision{
+		Allowed: false,
+		Rule:    failedRule,
+	}
+}
+
+func (r Rule) appliesTo(request PolicyRequest) bool {
+	if !slices.Contains(r.Actions, anyAction) && !slices.Contains(r.Actions, request.Action) {
+		return false
+	}
+
+	for _, role := range request.Roles {
+		if slices.Contains(r.Roles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) matches(request PolicyRequest) bool {
+	for fieldName, expected := range r.Match {
+		value, ok := stringField(request.Resource, fieldName)
+		if !ok {
+			return false
+		}
+
+		switch expected {
+		case userIDAttribute:
+			expected = request.UserID
+		case emailAttribute:
+			expected = request.Email
+		}
+
+		if value != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isKnownRole(role Role) bool {
+	switch role {
+	case RoleAdmin, RoleOrganizer, RoleVoter, RoleObserver, RoleAnonymous:
+		return true
+	}
+
+	return false
+}
+
+// actionName returns the type name of a command or query, such as CastVote.
+func actionName(action any) string {
+	actionType := reflect.TypeOf(action)
+	if actionType == nil {
+		return ""
+	}
+
+	if actionType.Kind() == reflect.Pointer {
+		actionType = actionType.Elem()
+	}
+
+	return actionType.Name()
+}
+
+// stringField returns the value of the named string field of a command or query.
+func stringField(resource any, fieldName string) (string, bool) {
+	value := reflect.ValueOf(resource)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	field := value.FieldByName(fieldName)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return "", false
+	}
+
+	return field.String(), true
+}
+
+var ErrInvalidPolicy = errors.New("invalid authorization policy")
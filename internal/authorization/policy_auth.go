@@ -0,0 +1,193 @@
+package authorization
+
+import (
+	"context"
+	"log"
+
+	"github.com/inklabs/cqrs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// policyAuthorization evaluates a Policy for every command and query, after the
+// handler's own VerifyAuthorization checks. Both must allow the request.
+//
+// Requests for an action the policy allows for anonymous are not authenticated,
+// even if they carry a bearer token, so they are never linked to a user.
+type policyAuthorization struct {
+	authenticator Authenticator
+	policy        *Policy
+	roleProvider  RoleProvider
+}
+
+func NewPolicyAuthorization(authenticator Authenticator, policy *Policy, roleProvider RoleProvider) *policyAuthorization {
+	return &policyAuthorization{
+		authenticator: authenticator,
+		policy:        policy,
+		roleProvider:  roleProvider,
+	}
+}
+
+func (a *policyAuthorization) VerifyCommand(ctx context.Context, handler cqrs.CommandHandler, command cqrs.Command) error {
+	ctx, span := tracer.Start(ctx, "policy-auth.verify-command")
+	defer span.End()
+
+	authContext, err := a.authenticate(ctx, span, command)
+	if err != nil {
+		return err
+	}
+
+	if verifier, ok := handler.(CommandVerifier); ok {
+		err = verifier.VerifyAuthorization(authContext, command)
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.evaluate(ctx, span, authContext, command)
+}
+
+func (a *policyAuthorization) VerifyAsyncCommand(ctx context.Context, handler cqrs.AsyncCommandHandler, command cqrs.AsyncCommand) error {
+	ctx, span := tracer.Start(ctx, "policy-auth.verify-async-command")
+	defer span.End()
+
+	authContext, err := a.authenticate(ctx, span, command)
+	if err != nil {
+		return err
+	}
+
+	if verifier, ok := handler.(AsyncCommandVerifier); ok {
+		err = verifier.VerifyAuthorization(authContext, command)
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.evaluate(ctx, span, authContext, command)
+}
+
+func (a *policyAuthorization) VerifyQuery(ctx context.Context, handler cqrs.QueryHandler, query cqrs.Query) error {
+	ctx, span := tracer.Start(ctx, "policy-auth.verify-query")
+	defer span.End()
+
+	authContext, err := a.authenticate(ctx, span, query)
+	if err != nil {
+		return err
+	}
+
+	if verifier, ok := handler.(QueryVerifier); ok {
+		err = verifier.VerifyAuthorization(authContext, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.evaluate(ctx, span, authContext, query)
+}
+
+func (a *policyAuthorization) VerifyRequest(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "policy-auth.verify-request")
+	defer span.End()
+
+	_, err := a.authenticate(ctx, span, nil)
+	return err
+}
+
+func (a *policyAuthorization) authenticate(ctx context.Context, span trace.Span, resource any) (Context, error) {
+	if a.policy.IsAnonymous(actionName(resource)) {
+		return anonymousContext{ctx: ctx}, nil
+	}
+
+	authContext, err := a.authenticator.Authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String(UserIDKey, authContext.UserID()))
+
+	return authContext, nil
+}
+
+func (a *policyAuthorization) evaluate(ctx context.Context, span trace.Span, authContext Context, resource any) error {
+	request := PolicyRequest{
+		Action:   actionName(resource),
+		UserID:   authContext.UserID(),
+		Email:    authContext.Email(),
+		Resource: resource,
+	}
+	request.ElectionID, _ = stringField(resource, "ElectionID")
+
+	roles, err := a.getRoles(ctx, authContext, request.ElectionID)
+	if err != nil {
+		return err
+	}
+	request.Roles = roles
+
+	decision := a.policy.Evaluate(request)
+
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = string(role)
+	}
+
+	span.SetAttributes(
+		attribute.String(ActionKey, request.Action),
+		attribute.String(ElectionIDKey, request.ElectionID),
+		attribute.StringSlice(RolesKey, roleNames),
+		attribute.String(RuleKey, decision.Rule),
+	)
+
+	if !decision.Allowed {
+		span.SetAttributes(attribute.String(DecisionKey, string(EffectDeny)))
+		log.Printf("rule %s denied %s for user %s", decision.Rule, request.Action, request.UserID)
+		return cqrs.ErrAccessDenied
+	}
+
+	span.SetAttributes(attribute.String(DecisionKey, string(EffectAllow)))
+
+	return nil
+}
+
+func (a *policyAuthorization) getRoles(ctx context.Context, authContext Context, electionID string) ([]Role, error) {
+	if _, ok := authContext.(anonymousContext); ok {
+		return []Role{RoleAnonymous}, nil
+	}
+
+	roles := a.policy.Roles(authContext.UserID(), electionID)
+
+	if authContext.IsAdmin() {
+		roles = append(roles, RoleAdmin)
+	}
+
+	if electionID != "" && a.roleProvider != nil {
+		electionRoles, err := a.roleProvider.ElectionRoles(ctx, authContext.UserID(), electionID)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, electionRoles...)
+	}
+
+	return roles, nil
+}
+
+// anonymousContext is the Context of a request for an anonymous action.
+type anonymousContext struct {
+	ctx context.Context
+}
+
+func (c anonymousContext) Context() context.Context {
+	return c.ctx
+}
+
+func (c anonymousContext) Email() string {
+	return ""
+}
+
+func (c anonymousContext) UserID() string {
+	return ""
+}
+
+func (c anonymousContext) IsAdmin() bool {
+	return false
+}
@@ -0,0 +1,172 @@
+package authorization_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/electionrepository/inmemoryrepo"
+)
+
+func TestPolicyAuthorization(t *testing.T) {
+	const (
+		organizerUserID = "de06e622-9169-4351-b14e-9109dfd9dee3"
+		voterUserID     = "0b1b3c68-7d0c-4d9f-8e3f-4ac1a5cbd0a1"
+		electionID      = "7c4f1b5e-7bd6-4a28-8f8d-6f1c7a0a4c11"
+	)
+	signingKey := []byte("9742fed04ba648bcb476a13b9e3d87e3")
+
+	spanRecorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+
+	newAuthorization := func(t *testing.T) cqrs.Authorization {
+		repository := inmemoryrepo.New()
+		require.NoError(t, repository.SaveElection(context.Background(), electionrepository.Election{
+			ElectionID:      electionID,
+			OrganizerUserID: organizerUserID,
+		}))
+
+		return authorization.NewPolicyAuthorization(
+			authorization.NewJWTAuthorization(signingKey),
+			authorization.DefaultPolicy(),
+			authorization.NewElectionRoleProvider(repository),
+		)
+	}
+
+	userContext := func(t *testing.T, userID string) context.Context {
+		token, err := authorization.NewSignedToken(authorization.JWTClaims{UserID: userID}, signingKey)
+		require.NoError(t, err)
+		return context.WithValue(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	lastSpanAttributes := func() map[attribute.Key]attribute.Value {
+		spans := spanRecorder.Ended()
+		require.NotEmpty(t, spans)
+
+		attributes := make(map[attribute.Key]attribute.Value)
+		for _, keyValue := range spans[len(spans)-1].Attributes() {
+			attributes[keyValue.Key] = keyValue.Value
+		}
+
+		return attributes
+	}
+
+	t.Run("allows the organizer to close their election", func(t *testing.T) {
+		// Given
+		auth := newAuthorization(t)
+		command := CloseElectionByOwner{ElectionID: electionID}
+
+		// When
+		err := auth.VerifyAsyncCommand(userContext(t, organizerUserID), nil, command)
+
+		// Then
+		require.NoError(t, err)
+		attributes := lastSpanAttributes()
+		assert.Equal(t, "allow", attributes[authorization.DecisionKey].AsString())
+		assert.Equal(t, "organizer-manages-election", attributes[authorization.RuleKey].AsString())
+		assert.Equal(t, []string{"voter", "organizer"}, attributes[authorization.RolesKey].AsStringSlice())
+	})
+
+	t.Run("allows a voter to read elections", func(t *testing.T) {
+		// Given
+		auth := newAuthorization(t)
+		query := GetElection{ElectionID: electionID}
+
+		// When
+		err := auth.VerifyQuery(userContext(t, voterUserID), nil, query)
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("allows a secret ballot without authenticating the user", func(t *testing.T) {
+		// Given
+		auth := newAuthorization(t)
+		command := CastSecretBallot{ElectionID: electionID}
+
+		// When
+		err := auth.VerifyCommand(userContext(t, voterUserID), nil, command)
+
+		// Then
+		require.NoError(t, err)
+		attributes := lastSpanAttributes()
+		assert.Equal(t, "allow", attributes[authorization.DecisionKey].AsString())
+		assert.Equal(t, "cast-secret-ballot", attributes[authorization.RuleKey].AsString())
+		assert.Equal(t, []string{"anonymous"}, attributes[authorization.RolesKey].AsStringSlice())
+		assert.NotContains(t, attributes, attribute.Key(authorization.UserIDKey))
+	})
+
+	t.Run("allows a secret ballot without a bearer token", func(t *testing.T) {
+		// Given
+		auth := newAuthorization(t)
+		command := CastSecretBallot{ElectionID: electionID}
+
+		// When
+		err := auth.VerifyCommand(context.Background(), nil, command)
+
+		// Then
+		require.NoError(t, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when a voter closes an election they did not organize", func(t *testing.T) {
+			// Given
+			auth := newAuthorization(t)
+			command := CloseElectionByOwner{ElectionID: electionID}
+
+			// When
+			err := auth.VerifyAsyncCommand(userContext(t, voterUserID), nil, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			attributes := lastSpanAttributes()
+			assert.Equal(t, "deny", attributes[authorization.DecisionKey].AsString())
+			assert.Equal(t, authorization.DefaultDenyRule, attributes[authorization.RuleKey].AsString())
+			assert.Equal(t, "CloseElectionByOwner", attributes[authorization.ActionKey].AsString())
+			assert.Equal(t, electionID, attributes[authorization.ElectionIDKey].AsString())
+		})
+
+		t.Run("when voting as another user", func(t *testing.T) {
+			// Given
+			auth := newAuthorization(t)
+			command := CastVote{ElectionID: electionID, UserID: organizerUserID}
+
+			// When
+			err := auth.VerifyCommand(userContext(t, voterUserID), nil, command)
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+			attributes := lastSpanAttributes()
+			assert.Equal(t, "deny", attributes[authorization.DecisionKey].AsString())
+			assert.Equal(t, "vote-as-self", attributes[authorization.RuleKey].AsString())
+		})
+
+		t.Run("when not authenticated", func(t *testing.T) {
+			// Given
+			auth := newAuthorization(t)
+
+			// When
+			err := auth.VerifyQuery(context.Background(), nil, GetElection{ElectionID: electionID})
+
+			// Then
+			require.Equal(t, cqrs.ErrAccessDenied, err)
+		})
+	})
+}
+
+type GetElection struct {
+	ElectionID string
+}
+
+type CastSecretBallot struct {
+	ElectionID string
+}
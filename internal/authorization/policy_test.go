@@ -0,0 +1,233 @@
+package authorization_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/authorization"
+)
+
+type CastVote struct {
+	ElectionID string
+	UserID     string
+}
+
+type CloseElectionByOwner struct {
+	ElectionID string
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("parses yaml", func(t *testing.T) {
+		// Given
+		data := []byte(`
+defaultRoles: [voter]
+bindings:
+  - userId: u1
+    role: observer
+    electionId: e1
+rules:
+  - name: vote-as-self
+    actions: [CastVote]
+    roles: [voter]
+    match:
+      UserID: $user.id
+`)
+
+		// When
+		policy, err := authorization.ParsePolicy(data)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &authorization.Policy{
+			DefaultRoles: []authorization.Role{authorization.RoleVoter},
+			Bindings: []authorization.RoleBinding{
+				{UserID: "u1", Role: authorization.RoleObserver, ElectionID: "e1"},
+			},
+			Rules: []authorization.Rule{
+				{
+					Name:    "vote-as-self",
+					Effect:  authorization.EffectAllow,
+					Actions: []string{"CastVote"},
+					Roles:   []authorization.Role{authorization.RoleVoter},
+					Match:   map[string]string{"UserID": "$user.id"},
+				},
+			},
+		}, policy)
+	})
+
+	t.Run("loads json file", func(t *testing.T) {
+		// Given
+		path := filepath.Join(t.TempDir(), "policy.json")
+		data := []byte(`{"rules": [{"name": "deny-all", "effect": "deny", "actions": ["*"], "roles": ["voter"]}]}`)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		// When
+		policy, err := authorization.LoadPolicyFile(path)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, policy.Rules, 1)
+		assert.Equal(t, authorization.EffectDeny, policy.Rules[0].Effect)
+	})
+
+	t.Run("default policy is valid", func(t *testing.T) {
+		// When
+		policy := authorization.DefaultPolicy()
+
+		// Then
+		assert.NotEmpty(t, policy.Rules)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string]string{
+			"unknown field":     `{"rules": [{"name": "r", "action": ["*"], "roles": ["voter"]}]}`,
+			"missing name":      `{"rules": [{"actions": ["*"], "roles": ["voter"]}]}`,
+			"duplicate name":    `{"rules": [{"name": "r", "actions": ["*"], "roles": ["voter"]}, {"name": "r", "actions": ["*"], "roles": ["voter"]}]}`,
+			"unknown effect":    `{"rules": [{"name": "r", "effect": "maybe", "actions": ["*"], "roles": ["voter"]}]}`,
+			"unknown role":      `{"rules": [{"name": "r", "actions": ["*"], "roles": ["auditor"]}]}`,
+			"no actions":        `{"rules": [{"name": "r", "roles": ["voter"]}]}`,
+			"binding user":      `{"bindings": [{"role": "observer"}]}`,
+			"default anonymous": `{"defaultRoles": ["anonymous"]}`,
+		}
+
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				// When
+				_, err := authorization.ParsePolicy([]byte(data))
+
+				// Then
+				require.ErrorIs(t, err, authorization.ErrInvalidPolicy)
+			})
+		}
+	})
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	const (
+		userID     = "de06e622-9169-4351-b14e-9109dfd9dee3"
+		electionID = "7c4f1b5e-7bd6-4a28-8f8d-6f1c7a0a4c11"
+	)
+	policy := authorization.DefaultPolicy()
+
+	t.Run("allows a voter to vote as themself", func(t *testing.T) {
+		// When
+		decision := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CastVote",
+			UserID:   userID,
+			Roles:    []authorization.Role{authorization.RoleVoter},
+			Resource: CastVote{ElectionID: electionID, UserID: userID},
+		})
+
+		// Then
+		assert.Equal(t, authorization.Decision{Allowed: true, Rule: "vote-as-self"}, decision)
+	})
+
+	t.Run("names the failing rule when a voter votes as another user", func(t *testing.T) {
+		// When
+		decision := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CastVote",
+			UserID:   userID,
+			Roles:    []authorization.Role{authorization.RoleVoter},
+			Resource: CastVote{ElectionID: electionID, UserID: "another-user"},
+		})
+
+		// Then
+		assert.Equal(t, authorization.Decision{Allowed: false, Rule: "vote-as-self"}, decision)
+	})
+
+	t.Run("denies an observer from voting", func(t *testing.T) {
+		// When
+		decision := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CastVote",
+			UserID:   userID,
+			Roles:    []authorization.Role{authorization.RoleObserver},
+			Resource: CastVote{ElectionID: electionID, UserID: userID},
+		})
+
+		// Then
+		assert.Equal(t, authorization.Decision{Allowed: false, Rule: authorization.DefaultDenyRule}, decision)
+	})
+
+	t.Run("allows an organizer to close their election", func(t *testing.T) {
+		// When
+		decision := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CloseElectionByOwner",
+			Roles:    []authorization.Role{authorization.RoleVoter, authorization.RoleOrganizer},
+			Resource: CloseElectionByOwner{ElectionID: electionID},
+		})
+
+		// Then
+		assert.Equal(t, authorization.Decision{Allowed: true, Rule: "organizer-manages-election"}, decision)
+	})
+
+	t.Run("first applicable deny rule wins", func(t *testing.T) {
+		// Given
+		policy, err := authorization.ParsePolicy([]byte(`
+rules:
+  - name: no-closing-e1
+    effect: deny
+    actions: [CloseElectionByOwner]
+    roles: [organizer]
+    match:
+      ElectionID: e1
+  - name: organizer-manages-election
+    actions: [CloseElectionByOwner]
+    roles: [organizer]
+`))
+		require.NoError(t, err)
+
+		// When
+		denied := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CloseElectionByOwner",
+			Roles:    []authorization.Role{authorization.RoleOrganizer},
+			Resource: CloseElectionByOwner{ElectionID: "e1"},
+		})
+		allowed := policy.Evaluate(authorization.PolicyRequest{
+			Action:   "CloseElectionByOwner",
+			Roles:    []authorization.Role{authorization.RoleOrganizer},
+			Resource: CloseElectionByOwner{ElectionID: "e2"},
+		})
+
+		// Then
+		assert.Equal(t, authorization.Decision{Allowed: false, Rule: "no-closing-e1"}, denied)
+		assert.Equal(t, authorization.Decision{Allowed: true, Rule: "organizer-manages-election"}, allowed)
+	})
+}
+
+func TestPolicy_Roles(t *testing.T) {
+	t.Run("grants default roles and bindings scoped to the election", func(t *testing.T) {
+		// Given
+		policy, err := authorization.ParsePolicy([]byte(`
+defaultRoles: [voter]
+bindings:
+  - userId: u1
+    role: observer
+    electionId: e1
+  - userId: u1
+    role: admin
+    electionId: "*"
+  - userId: u2
+    role: observer
+`))
+		require.NoError(t, err)
+
+		// When
+		rolesInE1 := policy.Roles("u1", "e1")
+		rolesInE2 := policy.Roles("u1", "e2")
+
+		// Then
+		assert.Equal(t, []authorization.Role{
+			authorization.RoleVoter,
+			authorization.RoleObserver,
+			authorization.RoleAdmin,
+		}, rolesInE1)
+		assert.Equal(t, []authorization.Role{
+			authorization.RoleVoter,
+			authorization.RoleAdmin,
+		}, rolesInE2)
+	})
+}
@@ -0,0 +1,23 @@
+package authorization
+
+import (
+	"context"
+)
+
+// SystemUserID identifies actions performed by the application itself rather than a user.
+const SystemUserID = "system"
+
+type systemContextKey struct{}
+
+// NewSystemContext returns a context for trusted internal subsystems, such as the
+// election scheduler, that act with admin privileges. The key is unexported, so
+// it cannot be set by an incoming request.
+func NewSystemContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemContextKey{}, true)
+}
+
+// IsSystemContext reports whether ctx was created by NewSystemContext.
+func IsSystemContext(ctx context.Context) bool {
+	isSystem, _ := ctx.Value(systemContextKey{}).(bool)
+	return isSystem
+}
@@ -0,0 +1,146 @@
+// Package ballotchain links each stored ballot to the ballot saved before it in
+// the same election, by including the previous ballot's hash in its own. Editing,
+// inserting, or removing a ballot outside the repository breaks the chain at that
+// ballot. The chain is unkeyed, so ballots added to or removed from its end are
+// only detected against a Head recorded elsewhere, such as when the election
+// closes. Secret ballots are stored apart from the votes and are not chained.
+package ballotchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+const voteDomain = "vote-chain-v1"
+
+// BrokenLink is the first ballot in a chain whose hash does not verify. Position
+// is the 1-based position of the ballot in the chain.
+type BrokenLink struct {
+	Position int
+	VoteID   string
+	Reason   string
+}
+
+// Hash returns the hex encoded SHA-256 hash over a vote and the hash of the vote
+// before it.
+func Hash(vote electionrepository.Vote) string {
+	hash := sha256.New()
+	writeField(hash, voteDomain)
+	writeField(hash, vote.PreviousVoteHash)
+	writeField(hash, vote.VoteID)
+	writeField(hash, vote.ElectionID)
+	writeField(hash, vote.UserID)
+	writeInt(hash, vote.SubmittedAt)
+	writeField(hash, vote.SupersedesVoteID)
+	writeInt(hash, len(vote.RankedProposalIDs))
+
+	for _, proposalID := range vote.RankedProposalIDs {
+		writeField(hash, proposalID)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Link appends vote to a chain whose last vote hashed to previousVoteHash. The
+// first vote in an election has an empty previousVoteHash.
+func Link(vote electionrepository.Vote, previousVoteHash string) electionrepository.Vote {
+	vote.PreviousVoteHash = previousVoteHash
+	vote.VoteHash = Hash(vote)
+	return vote
+}
+
+// FindBrokenLink recomputes the chain over votes, in the order they were saved,
+// and returns the first vote that does not verify.
+func FindBrokenLink(votes []electionrepository.Vote) (BrokenLink, bool) {
+	for i, vote := range votes {
+		if vote.PreviousVoteHash != previousVoteHash(votes, i) {
+			reason := "previous vote hash does not match the vote before it"
+			if i == 0 {
+				reason = "first vote has a previous vote hash"
+			}
+
+			return BrokenLink{
+				Position: i + 1,
+				VoteID:   vote.VoteID,
+				Reason:   reason,
+			}, true
+		}
+
+		if vote.VoteHash != Hash(vote) {
+			return BrokenLink{
+				Position: i + 1,
+				VoteID:   vote.VoteID,
+				Reason:   "vote hash does not match its contents",
+			}, true
+		}
+	}
+
+	return BrokenLink{}, false
+}
+
+// Head is the last link of a ballot chain: the VoteHash of its last vote, and
+// the number of votes in the chain.
+type Head struct {
+	VoteHash    string
+	BallotCount int
+}
+
+// GetHead returns the head of the chain over votes.
+func GetHead(votes []electionrepository.Vote) Head {
+	return Head{
+		VoteHash:    previousVoteHash(votes, len(votes)),
+		BallotCount: len(votes),
+	}
+}
+
+// FindBrokenHead compares the chain over votes with a head recorded earlier,
+// and returns the first position where they differ.
+func FindBrokenHead(votes []electionrepository.Vote, head Head) (BrokenLink, bool) {
+	if len(votes) > head.BallotCount {
+		return BrokenLink{
+			Position: head.BallotCount + 1,
+			VoteID:   votes[head.BallotCount].VoteID,
+			Reason:   "vote was added after the chain head was recorded",
+		}, true
+	}
+
+	if len(votes) < head.BallotCount {
+		return BrokenLink{
+			Position: len(votes) + 1,
+			Reason:   "vote was removed after the chain head was recorded",
+		}, true
+	}
+
+	if len(votes) > 0 && GetHead(votes) != head {
+		return BrokenLink{
+			Position: len(votes),
+			VoteID:   votes[len(votes)-1].VoteID,
+			Reason:   "last vote hash does not match the recorded chain head",
+		}, true
+	}
+
+	return BrokenLink{}, false
+}
+
+func previousVoteHash(votes []electionrepository.Vote, i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	return votes[i-1].VoteHash
+}
+
+func writeField(w io.Writer, value string) {
+	writeInt(w, len(value))
+	_, _ = io.WriteString(w, value)
+}
+
+func writeInt(w io.Writer, value int) {
+	var encoded [8]byte
+	binary.BigEndian.PutUint64(encoded[:], uint64(value))
+	_, _ = w.Write(encoded[:])
+}
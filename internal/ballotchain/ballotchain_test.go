@@ -0,0 +1,196 @@
+package ballotchain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+func TestFindBrokenLink(t *testing.T) {
+	t.Run("intact chain", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+
+		// When
+		_, isBroken := ballotchain.FindBrokenLink(votes)
+
+		// Then
+		assert.False(t, isBroken)
+	})
+
+	t.Run("empty chain", func(t *testing.T) {
+		// When
+		_, isBroken := ballotchain.FindBrokenLink(nil)
+
+		// Then
+		assert.False(t, isBroken)
+	})
+
+	t.Run("edited vote", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		votes[1].RankedProposalIDs = []string{"P2", "P1"}
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenLink(votes)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 2,
+			VoteID:   "V2",
+			Reason:   "vote hash does not match its contents",
+		}, brokenLink)
+	})
+
+	t.Run("edited vote with recomputed hash", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		votes[1].UserID = "U9"
+		votes[1].VoteHash = ballotchain.Hash(votes[1])
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenLink(votes)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 3,
+			VoteID:   "V3",
+			Reason:   "previous vote hash does not match the vote before it",
+		}, brokenLink)
+	})
+
+	t.Run("removed vote", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		votes = append(votes[:1], votes[2:]...)
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenLink(votes)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, 2, brokenLink.Position)
+		assert.Equal(t, "V3", brokenLink.VoteID)
+	})
+
+	t.Run("removed first vote", func(t *testing.T) {
+		// Given
+		votes := newChain(3)[1:]
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenLink(votes)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 1,
+			VoteID:   "V2",
+			Reason:   "first vote has a previous vote hash",
+		}, brokenLink)
+	})
+}
+
+func TestFindBrokenHead(t *testing.T) {
+	t.Run("chain matches head", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		head := ballotchain.GetHead(votes)
+
+		// When
+		_, isBroken := ballotchain.FindBrokenHead(votes, head)
+
+		// Then
+		assert.False(t, isBroken)
+		assert.Equal(t, ballotchain.Head{VoteHash: votes[2].VoteHash, BallotCount: 3}, head)
+	})
+
+	t.Run("empty chain", func(t *testing.T) {
+		// When
+		_, isBroken := ballotchain.FindBrokenHead(nil, ballotchain.GetHead(nil))
+
+		// Then
+		assert.False(t, isBroken)
+	})
+
+	t.Run("vote added after head", func(t *testing.T) {
+		// Given
+		votes := newChain(4)
+		head := ballotchain.GetHead(votes[:3])
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenHead(votes, head)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 4,
+			VoteID:   "V4",
+			Reason:   "vote was added after the chain head was recorded",
+		}, brokenLink)
+	})
+
+	t.Run("last vote removed after head", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		head := ballotchain.GetHead(votes)
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenHead(votes[:2], head)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 3,
+			Reason:   "vote was removed after the chain head was recorded",
+		}, brokenLink)
+	})
+
+	t.Run("last vote replaced after head", func(t *testing.T) {
+		// Given
+		votes := newChain(3)
+		head := ballotchain.GetHead(votes)
+		votes[2] = ballotchain.Link(electionrepository.Vote{
+			VoteID:            "V3",
+			ElectionID:        "E1",
+			UserID:            "U3",
+			RankedProposalIDs: []string{"P2", "P1"},
+		}, votes[1].VoteHash)
+
+		// When
+		brokenLink, isBroken := ballotchain.FindBrokenHead(votes, head)
+
+		// Then
+		assert.True(t, isBroken)
+		assert.Equal(t, ballotchain.BrokenLink{
+			Position: 3,
+			VoteID:   "V3",
+			Reason:   "last vote hash does not match the recorded chain head",
+		}, brokenLink)
+	})
+}
+
+func newChain(totalVotes int) []electionrepository.Vote {
+	voteIDs := []string{"V1", "V2", "V3", "V4"}
+
+	var votes []electionrepository.Vote
+	previousVoteHash := ""
+
+	for _, voteID := range voteIDs[:totalVotes] {
+		vote := ballotchain.Link(electionrepository.Vote{
+			VoteID:            voteID,
+			ElectionID:        "E1",
+			UserID:            "U" + voteID[1:],
+			RankedProposalIDs: []string{"P1", "P2"},
+		}, previousVoteHash)
+
+		votes = append(votes, vote)
+		previousVoteHash = vote.VoteHash
+	}
+
+	return votes
+}
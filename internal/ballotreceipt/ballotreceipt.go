@@ -0,0 +1,212 @@
+// Package ballotreceipt commits to ballots with hash receipts, and proves that a
+// receipt is included in the Merkle root published when an election closes. A
+// voter can check the proof offline, without trusting the election.
+package ballotreceipt
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sort"
+)
+
+const receiptDomain = "vote-receipt-v1"
+
+// Hash prefixes keep a leaf from being passed off as an interior node.
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// ProofStep is a sibling hash on the path from a receipt to the Merkle root.
+// IsLeft reports whether the sibling is hashed to the left of the path.
+type ProofStep struct {
+	Hash   string
+	IsLeft bool
+}
+
+// Receipt returns the hex encoded SHA-256 commitment over a ballot's VoteID,
+// ElectionID, and ranked proposals. Each field is length prefixed, so that two
+// different ballots cannot produce the same hash input.
+func Receipt(voteID, electionID string, rankedProposalIDs []string) string {
+	hash := sha256.New()
+	writeField(hash, receiptDomain)
+	writeField(hash, voteID)
+	writeField(hash, electionID)
+
+	var count [4]byte
+	binary.BigEndian.PutUint32(count[:], uint32(len(rankedProposalIDs)))
+	hash.Write(count[:])
+
+	for _, proposalID := range rankedProposalIDs {
+		writeField(hash, proposalID)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Root returns the hex encoded Merkle root over receipts. Receipts are sorted
+// first, so the root does not depend on the order the ballots were stored in.
+// The root of no receipts is empty.
+func Root(receipts []string) (string, error) {
+	levels, err := buildLevels(receipts)
+	if err != nil {
+		return "", err
+	}
+
+	if len(levels) == 0 {
+		return "", nil
+	}
+
+	return hex.EncodeToString(levels[len(levels)-1][0]), nil
+}
+
+// Proof returns the sibling hashes leading from receipt to the Merkle root over
+// receipts.
+func Proof(receipts []string, receipt string) ([]ProofStep, error) {
+	levels, err := buildLevels(receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, sortedReceipt := range sortedCopy(receipts) {
+		if sortedReceipt == receipt {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		return nil, ErrReceiptNotFound
+	}
+
+	proof := []ProofStep{}
+
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, ProofStep{
+				Hash:   hex.EncodeToString(level[sibling]),
+				IsLeft: sibling < index,
+			})
+		}
+
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// Verify checks that proof leads from receipt to root.
+func Verify(receipt string, proof []ProofStep, root string) error {
+	leaf, err := decodeHash(receipt)
+	if err != nil {
+		return err
+	}
+
+	hash := hashLeaf(leaf)
+
+	for _, step := range proof {
+		sibling, err := decodeHash(step.Hash)
+		if err != nil {
+			return ErrInvalidProof
+		}
+
+		if step.IsLeft {
+			hash = hashNode(sibling, hash)
+		} else {
+			hash = hashNode(hash, sibling)
+		}
+	}
+
+	if hex.EncodeToString(hash) != root {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// buildLevels hashes the sorted receipts into the levels of a Merkle tree, from
+// the leaves up to the root. A node without a sibling is promoted to the next
+// level unchanged.
+func buildLevels(receipts []string) ([][][]byte, error) {
+	if len(receipts) == 0 {
+		return nil, nil
+	}
+
+	level := make([][]byte, len(receipts))
+	for i, receipt := range sortedCopy(receipts) {
+		leaf, err := decodeHash(receipt)
+		if err != nil {
+			return nil, err
+		}
+
+		level[i] = hashLeaf(leaf)
+	}
+
+	levels := [][][]byte{level}
+
+	for len(level) > 1 {
+		var next [][]byte
+
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+
+			next = append(next, hashNode(level[i], level[i+1]))
+		}
+
+		levels = append(levels, next)
+		level = next
+	}
+
+	return levels, nil
+}
+
+func hashLeaf(leaf []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte{leafPrefix})
+	hash.Write(leaf)
+	return hash.Sum(nil)
+}
+
+func hashNode(left, right []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte{nodePrefix})
+	hash.Write(left)
+	hash.Write(right)
+	return hash.Sum(nil)
+}
+
+func writeField(w io.Writer, value string) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(value)))
+	_, _ = w.Write(length[:])
+	_, _ = io.WriteString(w, value)
+}
+
+func decodeHash(value string) ([]byte, error) {
+	decoded, err := hex.DecodeString(value)
+	if err != nil || len(decoded) != sha256.Size {
+		return nil, ErrInvalidReceipt
+	}
+
+	return decoded, nil
+}
+
+func sortedCopy(receipts []string) []string {
+	sorted := append([]string{}, receipts...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+var (
+	ErrInvalidReceipt  = errors.New("receipt must be a hex encoded SHA-256 hash")
+	ErrReceiptNotFound = errors.New("receipt not found")
+	ErrInvalidProof    = errors.New("proof does not lead to the root")
+)
@@ -0,0 +1,145 @@
+package ballotreceipt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/ballotreceipt"
+)
+
+func TestReceipt(t *testing.T) {
+	t.Run("is deterministic", func(t *testing.T) {
+		// When
+		receipt1 := ballotreceipt.Receipt("V1", "E1", []string{"P1", "P2"})
+		receipt2 := ballotreceipt.Receipt("V1", "E1", []string{"P1", "P2"})
+
+		// Then
+		assert.Equal(t, receipt1, receipt2)
+		assert.Len(t, receipt1, 64)
+	})
+
+	t.Run("commits to the ranking", func(t *testing.T) {
+		// When
+		receipt1 := ballotreceipt.Receipt("V1", "E1", []string{"P1", "P2"})
+		receipt2 := ballotreceipt.Receipt("V1", "E1", []string{"P2", "P1"})
+
+		// Then
+		assert.NotEqual(t, receipt1, receipt2)
+	})
+
+	t.Run("does not collide when fields are split differently", func(t *testing.T) {
+		// When
+		receipt1 := ballotreceipt.Receipt("V1", "E1", []string{"P1P2"})
+		receipt2 := ballotreceipt.Receipt("V1", "E1", []string{"P1", "P2"})
+
+		// Then
+		assert.NotEqual(t, receipt1, receipt2)
+	})
+}
+
+func TestMerkleProof(t *testing.T) {
+	t.Run("every receipt verifies against the root", func(t *testing.T) {
+		for totalReceipts := 1; totalReceipts <= 9; totalReceipts++ {
+			// Given
+			receipts := newReceipts(totalReceipts)
+			root, err := ballotreceipt.Root(receipts)
+			require.NoError(t, err)
+
+			for _, receipt := range receipts {
+				// When
+				proof, err := ballotreceipt.Proof(receipts, receipt)
+
+				// Then
+				require.NoError(t, err)
+				assert.NoError(t, ballotreceipt.Verify(receipt, proof, root), "%d receipts", totalReceipts)
+			}
+		}
+	})
+
+	t.Run("root does not depend on receipt order", func(t *testing.T) {
+		// Given
+		receipts := newReceipts(5)
+		reversed := []string{receipts[4], receipts[3], receipts[2], receipts[1], receipts[0]}
+
+		// When
+		root1, err := ballotreceipt.Root(receipts)
+		require.NoError(t, err)
+		root2, err := ballotreceipt.Root(reversed)
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, root1, root2)
+	})
+
+	t.Run("root of no receipts is empty", func(t *testing.T) {
+		// When
+		root, err := ballotreceipt.Root(nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Empty(t, root)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("when receipt is not in the tree", func(t *testing.T) {
+			// Given
+			receipts := newReceipts(4)
+
+			// When
+			_, err := ballotreceipt.Proof(receipts, ballotreceipt.Receipt("V9", "E1", nil))
+
+			// Then
+			require.Equal(t, ballotreceipt.ErrReceiptNotFound, err)
+		})
+
+		t.Run("when proof has been tampered with", func(t *testing.T) {
+			// Given
+			receipts := newReceipts(4)
+			root, err := ballotreceipt.Root(receipts)
+			require.NoError(t, err)
+			proof, err := ballotreceipt.Proof(receipts, receipts[0])
+			require.NoError(t, err)
+			proof[0].IsLeft = !proof[0].IsLeft
+
+			// When
+			err = ballotreceipt.Verify(receipts[0], proof, root)
+
+			// Then
+			require.Equal(t, ballotreceipt.ErrInvalidProof, err)
+		})
+
+		t.Run("when receipt is not in the root", func(t *testing.T) {
+			// Given
+			receipts := newReceipts(4)
+			root, err := ballotreceipt.Root(receipts[1:])
+			require.NoError(t, err)
+			proof, err := ballotreceipt.Proof(receipts, receipts[0])
+			require.NoError(t, err)
+
+			// When
+			err = ballotreceipt.Verify(receipts[0], proof, root)
+
+			// Then
+			require.Equal(t, ballotreceipt.ErrInvalidProof, err)
+		})
+
+		t.Run("when receipt is not a hash", func(t *testing.T) {
+			// When
+			_, err := ballotreceipt.Root([]string{"not a hash"})
+
+			// Then
+			require.Equal(t, ballotreceipt.ErrInvalidReceipt, err)
+		})
+	})
+}
+
+func newReceipts(totalReceipts int) []string {
+	receipts := make([]string, totalReceipts)
+	for i := range receipts {
+		receipts[i] = ballotreceipt.Receipt(fmt.Sprintf("V%d", i), "E1", []string{"P1"})
+	}
+	return receipts
+}
@@ -0,0 +1,214 @@
+// Package blindsig implements RSA blind signatures over a full-domain hash
+// (RSA-FDH). A client blinds a message, the signer signs the blinded message
+// without learning it, and the client unblinds the result into an ordinary
+// signature over the original message. The signer cannot link the signature
+// it later verifies to the blinded message it signed.
+package blindsig
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// Blind hashes msg and blinds it with a random factor. The blinded message is
+// sent to the signer, and the blinding factor is kept by the client to Unblind
+// the signature.
+func Blind(random io.Reader, publicKey *rsa.PublicKey, msg []byte) (blindedMsg, blindingFactor []byte, err error) {
+	if random == nil {
+		random = rand.Reader
+	}
+
+	r, err := newBlindingFactor(random, publicKey.N)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := fullDomainHash(publicKey, msg)
+
+	blinded := new(big.Int).Exp(r, big.NewInt(int64(publicKey.E)), publicKey.N)
+	blinded.Mul(blinded, m)
+	blinded.Mod(blinded, publicKey.N)
+
+	return encode(publicKey, blinded), encode(publicKey, r), nil
+}
+
+// Sign signs a blinded message. The blinded message is chosen by the client,
+// so the private key operation is itself blinded with a fresh random factor
+// and computed with the CRT, as crypto/rsa does for its own decryption.
+func Sign(privateKey *rsa.PrivateKey, blindedMsg []byte) ([]byte, error) {
+	publicKey := &privateKey.PublicKey
+
+	m, err := decode(publicKey, blindedMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := signBlinded(rand.Reader, privateKey, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the signature before releasing it, so that a faulty computation
+	// cannot leak the private key.
+	check := new(big.Int).Exp(s, big.NewInt(int64(publicKey.E)), publicKey.N)
+	if check.Cmp(m) != 0 {
+		return nil, ErrSigningFailed
+	}
+
+	return encode(publicKey, s), nil
+}
+
+// signBlinded computes m^d mod N over m·r^e for a random r, then removes r from
+// the result, so the timing of the private key operation does not depend on
+// the attacker supplied m.
+func signBlinded(random io.Reader, privateKey *rsa.PrivateKey, m *big.Int) (*big.Int, error) {
+	publicKey := &privateKey.PublicKey
+	n := publicKey.N
+
+	r, err := newBlindingFactor(random, n)
+	if err != nil {
+		return nil, err
+	}
+
+	rInverse := new(big.Int).ModInverse(r, n)
+	if rInverse == nil {
+		return nil, ErrInvalidBlindingFactor
+	}
+
+	c := new(big.Int).Exp(r, big.NewInt(int64(publicKey.E)), n)
+	c.Mul(c, m)
+	c.Mod(c, n)
+
+	s := signCRT(privateKey, c)
+
+	s.Mul(s, rInverse)
+	s.Mod(s, n)
+
+	return s, nil
+}
+
+// signCRT computes c^d mod N with the Chinese remainder theorem over the two
+// prime factors of N.
+func signCRT(privateKey *rsa.PrivateKey, c *big.Int) *big.Int {
+	if len(privateKey.Primes) != 2 {
+		return new(big.Int).Exp(c, privateKey.D, privateKey.N)
+	}
+
+	if privateKey.Precomputed.Dp == nil {
+		privateKey.Precompute()
+	}
+
+	p, q := privateKey.Primes[0], privateKey.Primes[1]
+	precomputed := privateKey.Precomputed
+
+	m1 := new(big.Int).Exp(c, precomputed.Dp, p)
+	m2 := new(big.Int).Exp(c, precomputed.Dq, q)
+
+	h := new(big.Int).Sub(m1, m2)
+	h.Mul(h, precomputed.Qinv)
+	h.Mod(h, p)
+
+	h.Mul(h, q)
+	return h.Add(h, m2)
+}
+
+// Unblind removes the blinding factor from a blind signature, returning a
+// signature over the original message.
+func Unblind(publicKey *rsa.PublicKey, blindSignature, blindingFactor []byte) ([]byte, error) {
+	s, err := decode(publicKey, blindSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decode(publicKey, blindingFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	rInverse := new(big.Int).ModInverse(r, publicKey.N)
+	if rInverse == nil {
+		return nil, ErrInvalidBlindingFactor
+	}
+
+	s.Mul(s, rInverse)
+	s.Mod(s, publicKey.N)
+
+	return encode(publicKey, s), nil
+}
+
+// Verify reports whether signature is a valid signature over msg.
+func Verify(publicKey *rsa.PublicKey, msg, signature []byte) error {
+	s, err := decode(publicKey, signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	m := new(big.Int).Exp(s, big.NewInt(int64(publicKey.E)), publicKey.N)
+	if m.Cmp(fullDomainHash(publicKey, msg)) != 0 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// newBlindingFactor returns a random factor that is invertible modulo n.
+func newBlindingFactor(random io.Reader, n *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(random, n)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.Sign() > 0 && new(big.Int).GCD(nil, nil, r, n).Cmp(big.NewInt(1)) == 0 {
+			return r, nil
+		}
+	}
+}
+
+// fullDomainHash maps msg to an integer modulo N by expanding its SHA-256 hash
+// to the size of the modulus with MGF1.
+func fullDomainHash(publicKey *rsa.PublicKey, msg []byte) *big.Int {
+	size := publicKey.Size()
+	digest := make([]byte, 0, size+sha256.Size)
+
+	var counter [4]byte
+	for i := uint32(0); len(digest) < size; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		hash := sha256.New()
+		hash.Write(msg)
+		hash.Write(counter[:])
+		digest = hash.Sum(digest)
+	}
+
+	m := new(big.Int).SetBytes(digest[:size])
+	return m.Mod(m, publicKey.N)
+}
+
+func encode(publicKey *rsa.PublicKey, value *big.Int) []byte {
+	return value.FillBytes(make([]byte, publicKey.Size()))
+}
+
+func decode(publicKey *rsa.PublicKey, data []byte) (*big.Int, error) {
+	if len(data) != publicKey.Size() {
+		return nil, ErrInvalidLength
+	}
+
+	value := new(big.Int).SetBytes(data)
+	if value.Cmp(publicKey.N) >= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	return value, nil
+}
+
+var (
+	ErrInvalidLength         = errors.New("value does not match the key size")
+	ErrInvalidBlindingFactor = errors.New("invalid blinding factor")
+	ErrInvalidSignature      = errors.New("invalid signature")
+	ErrSigningFailed         = errors.New("signing failed")
+)
@@ -0,0 +1,109 @@
+package blindsig_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/blindsig"
+)
+
+func TestBlindSignature(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey := &privateKey.PublicKey
+
+	t.Run("unblinded signature verifies against the original message", func(t *testing.T) {
+		// Given
+		msg := []byte("ballot token")
+		blindedMsg, blindingFactor, err := blindsig.Blind(rand.Reader, publicKey, msg)
+		require.NoError(t, err)
+
+		// When
+		blindSignature, err := blindsig.Sign(privateKey, blindedMsg)
+		require.NoError(t, err)
+		signature, err := blindsig.Unblind(publicKey, blindSignature, blindingFactor)
+		require.NoError(t, err)
+
+		// Then
+		require.NoError(t, blindsig.Verify(publicKey, msg, signature))
+		assert.NotEqual(t, blindSignature, signature)
+	})
+
+	t.Run("blinding the same message twice is unlinkable", func(t *testing.T) {
+		// Given
+		msg := []byte("ballot token")
+
+		// When
+		blindedMsg1, _, err := blindsig.Blind(rand.Reader, publicKey, msg)
+		require.NoError(t, err)
+		blindedMsg2, _, err := blindsig.Blind(rand.Reader, publicKey, msg)
+		require.NoError(t, err)
+
+		// Then
+		assert.NotEqual(t, blindedMsg1, blindedMsg2)
+	})
+
+	t.Run("signing the same blinded message twice gives the same signature", func(t *testing.T) {
+		// Given
+		blindedMsg, _, err := blindsig.Blind(rand.Reader, publicKey, []byte("ballot token"))
+		require.NoError(t, err)
+
+		// When
+		blindSignature1, err := blindsig.Sign(privateKey, blindedMsg)
+		require.NoError(t, err)
+		blindSignature2, err := blindsig.Sign(privateKey, blindedMsg)
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, blindSignature1, blindSignature2)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("signature over another message", func(t *testing.T) {
+			// Given
+			blindedMsg, blindingFactor, err := blindsig.Blind(rand.Reader, publicKey, []byte("token 1"))
+			require.NoError(t, err)
+			blindSignature, err := blindsig.Sign(privateKey, blindedMsg)
+			require.NoError(t, err)
+			signature, err := blindsig.Unblind(publicKey, blindSignature, blindingFactor)
+			require.NoError(t, err)
+
+			// When
+			err = blindsig.Verify(publicKey, []byte("token 2"), signature)
+
+			// Then
+			require.ErrorIs(t, err, blindsig.ErrInvalidSignature)
+		})
+
+		t.Run("signature from another key", func(t *testing.T) {
+			// Given
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+			msg := []byte("ballot token")
+			blindedMsg, blindingFactor, err := blindsig.Blind(rand.Reader, &otherKey.PublicKey, msg)
+			require.NoError(t, err)
+			blindSignature, err := blindsig.Sign(otherKey, blindedMsg)
+			require.NoError(t, err)
+			signature, err := blindsig.Unblind(&otherKey.PublicKey, blindSignature, blindingFactor)
+			require.NoError(t, err)
+
+			// When
+			err = blindsig.Verify(publicKey, msg, signature)
+
+			// Then
+			require.ErrorIs(t, err, blindsig.ErrInvalidSignature)
+		})
+
+		t.Run("blinded message of the wrong size", func(t *testing.T) {
+			// When
+			_, err := blindsig.Sign(privateKey, []byte("short"))
+
+			// Then
+			require.ErrorIs(t, err, blindsig.ErrInvalidLength)
+		})
+	})
+}
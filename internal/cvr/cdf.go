@@ -0,0 +1,99 @@
+package cvr
+
+// The types below are the subset of the NIST CVR Common Data Format JSON schema
+// needed to describe a single ranked-choice contest.
+
+type castVoteRecordReport struct {
+	Type                      string            `json:"@type"`
+	Version                   string            `json:"Version"`
+	GeneratedDate             string            `json:"GeneratedDate"`
+	ReportGeneratingDeviceIDs []string          `json:"ReportGeneratingDeviceIds"`
+	ReportType                []string          `json:"ReportType"`
+	OtherReportType           string            `json:"OtherReportType,omitempty"`
+	ReportingDevice           []reportingDevice `json:"ReportingDevice"`
+	GpUnit                    []gpUnit          `json:"GpUnit"`
+	Election                  []election        `json:"Election"`
+	CVR                       []cvr             `json:"CVR"`
+}
+
+type reportingDevice struct {
+	ID          string `json:"@id"`
+	Type        string `json:"@type"`
+	Application string `json:"Application"`
+}
+
+type gpUnit struct {
+	ID                 string   `json:"@id"`
+	Type               string   `json:"@type"`
+	Name               string   `json:"Name"`
+	GpUnitType         string   `json:"Type"`
+	ReportingDeviceIDs []string `json:"ReportingDeviceIds"`
+}
+
+type election struct {
+	ID              string             `json:"@id"`
+	Type            string             `json:"@type"`
+	Name            string             `json:"Name"`
+	ElectionScopeID string             `json:"ElectionScopeId"`
+	Candidate       []candidate        `json:"Candidate"`
+	Contest         []candidateContest `json:"Contest"`
+}
+
+type candidate struct {
+	ID   string `json:"@id"`
+	Type string `json:"@type"`
+	Name string `json:"Name"`
+}
+
+type candidateContest struct {
+	ID               string             `json:"@id"`
+	Type             string             `json:"@type"`
+	Name             string             `json:"Name"`
+	NumberOfWinners  int                `json:"NumberOfWinners"`
+	VotesAllowed     int                `json:"VotesAllowed"`
+	ContestSelection []contestSelection `json:"ContestSelection"`
+}
+
+type contestSelection struct {
+	ID           string   `json:"@id"`
+	Type         string   `json:"@type"`
+	CandidateIDs []string `json:"CandidateIds"`
+}
+
+type cvr struct {
+	Type              string        `json:"@type"`
+	UniqueID          string        `json:"UniqueId"`
+	ElectionID        string        `json:"ElectionId"`
+	BallotStyleUnitID string        `json:"BallotStyleUnitId"`
+	CreatingDeviceID  string        `json:"CreatingDeviceId"`
+	CurrentSnapshotID string        `json:"CurrentSnapshotId"`
+	CVRSnapshot       []cvrSnapshot `json:"CVRSnapshot"`
+}
+
+type cvrSnapshot struct {
+	ID         string       `json:"@id"`
+	Type       string       `json:"@type"`
+	Kind       string       `json:"Type"`
+	CVRContest []cvrContest `json:"CVRContest"`
+}
+
+type cvrContest struct {
+	Type                string                `json:"@type"`
+	ContestID           string                `json:"ContestId"`
+	CVRContestSelection []cvrContestSelection `json:"CVRContestSelection"`
+}
+
+type cvrContestSelection struct {
+	Type               string              `json:"@type"`
+	ContestSelectionID string              `json:"ContestSelectionId"`
+	Rank               int                 `json:"Rank"`
+	SelectionPosition  []selectionPosition `json:"SelectionPosition"`
+}
+
+type selectionPosition struct {
+	Type          string `json:"@type"`
+	HasIndication string `json:"HasIndication"`
+	IsAllocable   string `json:"IsAllocable"`
+	NumberVotes   int    `json:"NumberVotes"`
+	Rank          int    `json:"Rank"`
+}
@@ -0,0 +1,226 @@
+// Package cvr encodes ballots as Cast Vote Records (CVR) so that election
+// results can be audited, and re-tabulated, by third-party tools such as RCTab.
+package cvr
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// Version is the version of the NIST CVR Common Data Format.
+	Version = "1.0.0"
+
+	reportingDeviceID = "vote"
+	gpUnitID          = "gpu-election"
+	snapshotID        = "snapshot-original"
+)
+
+// Contest describes the election that the ballots were cast in. Ballots rank
+// candidates by ProposalID.
+type Contest struct {
+	ElectionID      string
+	Name            string
+	NumberOfWinners int
+	Candidates      []Candidate
+	GeneratedAt     time.Time
+}
+
+type Candidate struct {
+	ProposalID string
+	Name       string
+}
+
+// EncodeJSON returns a NIST SP 1500-103 Cast Vote Record Common Data Format
+// report with one CVR per ballot. Each ballot lists ProposalIDs in order of
+// preference, starting with rank 1.
+func EncodeJSON(contest Contest, ballots [][]string) ([]byte, error) {
+	candidates := withBallotCandidates(contest.Candidates, ballots)
+
+	report := castVoteRecordReport{
+		Type:                      "CVR.CastVoteRecordReport",
+		Version:                   Version,
+		GeneratedDate:             contest.GeneratedAt.UTC().Format(time.RFC3339),
+		ReportGeneratingDeviceIDs: []string{reportingDeviceID},
+		ReportType:                []string{"other"},
+		OtherReportType:           "ranked-choice ballot export",
+		ReportingDevice: []reportingDevice{
+			{ID: reportingDeviceID, Type: "CVR.ReportingDevice", Application: "vote"},
+		},
+		GpUnit: []gpUnit{
+			{ID: gpUnitID, Type: "CVR.GpUnit", Name: contest.Name, GpUnitType: "other", ReportingDeviceIDs: []string{reportingDeviceID}},
+		},
+		Election: []election{
+			{
+				ID:              contest.ElectionID,
+				Type:            "CVR.Election",
+				Name:            contest.Name,
+				ElectionScopeID: gpUnitID,
+				Candidate:       toCandidates(candidates),
+				Contest:         []candidateContest{toCandidateContest(contest, candidates)},
+			},
+		},
+		CVR: make([]cvr, len(ballots)),
+	}
+
+	for i, rankedProposalIDs := range ballots {
+		report.CVR[i] = toCVR(contest.ElectionID, strconv.Itoa(i+1), rankedProposalIDs)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode cast vote records: %w", err)
+	}
+
+	return data, nil
+}
+
+// EncodeCSV returns one row per ballot, with the ProposalID ranked first in the
+// "Rank 1" column, the second in the "Rank 2" column, and so forth.
+func EncodeCSV(ballots [][]string) ([]byte, error) {
+	totalRanks := 1
+	for _, rankedProposalIDs := range ballots {
+		totalRanks = max(totalRanks, len(rankedProposalIDs))
+	}
+
+	header := make([]string, totalRanks+1)
+	header[0] = "BallotID"
+	for rank := 1; rank <= totalRanks; rank++ {
+		header[rank] = "Rank " + strconv.Itoa(rank)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	_ = writer.Write(header)
+	for i, rankedProposalIDs := range ballots {
+		row := make([]string, totalRanks+1)
+		row[0] = strconv.Itoa(i + 1)
+		copy(row[1:], rankedProposalIDs)
+		_ = writer.Write(row)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("unable to encode cast vote records: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// withBallotCandidates appends any ProposalID ranked on a ballot that is not
+// already a candidate, so every contest selection can be resolved.
+func withBallotCandidates(candidates []Candidate, ballots [][]string) []Candidate {
+	isCandidate := make(map[string]struct{}, len(candidates))
+	for _, candidate := range candidates {
+		isCandidate[candidate.ProposalID] = struct{}{}
+	}
+
+	allCandidates := append([]Candidate{}, candidates...)
+	for _, rankedProposalIDs := range ballots {
+		for _, proposalID := range rankedProposalIDs {
+			if _, ok := isCandidate[proposalID]; ok || proposalID == "" {
+				continue
+			}
+
+			isCandidate[proposalID] = struct{}{}
+			allCandidates = append(allCandidates, Candidate{ProposalID: proposalID, Name: proposalID})
+		}
+	}
+
+	return allCandidates
+}
+
+func toCandidates(candidates []Candidate) []candidate {
+	cdfCandidates := make([]candidate, len(candidates))
+	for i, c := range candidates {
+		cdfCandidates[i] = candidate{
+			ID:   c.ProposalID,
+			Type: "CVR.Candidate",
+			Name: c.Name,
+		}
+	}
+	return cdfCandidates
+}
+
+func toCandidateContest(contest Contest, candidates []Candidate) candidateContest {
+	selections := make([]contestSelection, len(candidates))
+	for i, c := range candidates {
+		selections[i] = contestSelection{
+			ID:           contestSelectionID(c.ProposalID),
+			Type:         "CVR.CandidateSelection",
+			CandidateIDs: []string{c.ProposalID},
+		}
+	}
+
+	return candidateContest{
+		ID:               contestID(contest.ElectionID),
+		Type:             "CVR.CandidateContest",
+		Name:             contest.Name,
+		NumberOfWinners:  max(contest.NumberOfWinners, 1),
+		VotesAllowed:     1,
+		ContestSelection: selections,
+	}
+}
+
+// toCVR leaves out skipped ranks, an empty ProposalID, so the ranks after them
+// keep their position on the ballot.
+func toCVR(electionID, uniqueID string, rankedProposalIDs []string) cvr {
+	selections := make([]cvrContestSelection, 0, len(rankedProposalIDs))
+	for i, proposalID := range rankedProposalIDs {
+		if proposalID == "" {
+			continue
+		}
+
+		rank := i + 1
+		selections = append(selections, cvrContestSelection{
+			Type:               "CVR.CVRContestSelection",
+			ContestSelectionID: contestSelectionID(proposalID),
+			Rank:               rank,
+			SelectionPosition: []selectionPosition{
+				{
+					Type:          "CVR.SelectionPosition",
+					HasIndication: "yes",
+					IsAllocable:   "yes",
+					NumberVotes:   1,
+					Rank:          rank,
+				},
+			},
+		})
+	}
+
+	return cvr{
+		Type:              "CVR.CVR",
+		UniqueID:          uniqueID,
+		ElectionID:        electionID,
+		BallotStyleUnitID: gpUnitID,
+		CreatingDeviceID:  reportingDeviceID,
+		CurrentSnapshotID: snapshotID,
+		CVRSnapshot: []cvrSnapshot{
+			{
+				ID:   snapshotID,
+				Type: "CVR.CVRSnapshot",
+				Kind: "original",
+				CVRContest: []cvrContest{
+					{
+						Type:                "CVR.CVRContest",
+						ContestID:           contestID(electionID),
+						CVRContestSelection: selections,
+					},
+				},
+			},
+		},
+	}
+}
+
+func contestID(electionID string) string {
+	return "contest-" + electionID
+}
+
+func contestSelectionID(proposalID string) string {
+	return "selection-" + proposalID
+}
@@ -0,0 +1,224 @@
+package cvr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/cvr"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	t.Run("one row per ballot and one rank per column", func(t *testing.T) {
+		// Given
+		ballots := [][]string{
+			{"A", "B", "C"},
+			{"B"},
+			{"C", "A"},
+		}
+
+		// When
+		data, err := cvr.EncodeCSV(ballots)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "BallotID,Rank 1,Rank 2,Rank 3\n"+
+			"1,A,B,C\n"+
+			"2,B,,\n"+
+			"3,C,A,\n", string(data))
+	})
+
+	t.Run("header only when there are no ballots", func(t *testing.T) {
+		// When
+		data, err := cvr.EncodeCSV(nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "BallotID,Rank 1\n", string(data))
+	})
+}
+
+func TestEncodeJSON(t *testing.T) {
+	t.Run("encodes a NIST CVR common data format report", func(t *testing.T) {
+		// Given
+		contest := cvr.Contest{
+			ElectionID:      "E1",
+			Name:            "Election Name",
+			NumberOfWinners: 1,
+			Candidates: []cvr.Candidate{
+				{ProposalID: "P1", Name: "Proposal 1"},
+				{ProposalID: "P2", Name: "Proposal 2"},
+			},
+			GeneratedAt: time.Unix(1699900000, 0),
+		}
+		ballots := [][]string{
+			{"P1", "P2"},
+			{"P2", "P3"},
+		}
+
+		// When
+		data, err := cvr.EncodeJSON(contest, ballots)
+
+		// Then
+		require.NoError(t, err)
+
+		var report struct {
+			Type          string `json:"@type"`
+			Version       string
+			GeneratedDate string
+			Election      []struct {
+				ID        string `json:"@id"`
+				Candidate []struct {
+					ID   string `json:"@id"`
+					Name string
+				}
+				Contest []struct {
+					ID               string `json:"@id"`
+					NumberOfWinners  int
+					ContestSelection []struct {
+						ID           string `json:"@id"`
+						CandidateIds []string
+					}
+				}
+			}
+			CVR []struct {
+				UniqueId          string
+				ElectionId        string
+				CurrentSnapshotId string
+				CVRSnapshot       []struct {
+					ID         string `json:"@id"`
+					CVRContest []struct {
+						ContestId           string
+						CVRContestSelection []struct {
+							ContestSelectionId string
+							Rank               int
+						}
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(data, &report))
+
+		assert.Equal(t, "CVR.CastVoteRecordReport", report.Type)
+		assert.Equal(t, cvr.Version, report.Version)
+		assert.Equal(t, "2023-11-13T18:26:40Z", report.GeneratedDate)
+
+		require.Len(t, report.Election, 1)
+		election := report.Election[0]
+		assert.Equal(t, "E1", election.ID)
+		require.Len(t, election.Candidate, 3)
+		assert.Equal(t, "Proposal 1", election.Candidate[0].Name)
+		assert.Equal(t, "P3", election.Candidate[2].ID)
+		require.Len(t, election.Contest, 1)
+		assert.Equal(t, 1, election.Contest[0].NumberOfWinners)
+		require.Len(t, election.Contest[0].ContestSelection, 3)
+		assert.Equal(t, []string{"P2"}, election.Contest[0].ContestSelection[1].CandidateIds)
+
+		require.Len(t, report.CVR, 2)
+		record := report.CVR[1]
+		assert.Equal(t, "2", record.UniqueId)
+		assert.Equal(t, "E1", record.ElectionId)
+		require.Len(t, record.CVRSnapshot, 1)
+		assert.Equal(t, record.CurrentSnapshotId, record.CVRSnapshot[0].ID)
+		require.Len(t, record.CVRSnapshot[0].CVRContest, 1)
+		cvrContest := record.CVRSnapshot[0].CVRContest[0]
+		assert.Equal(t, election.Contest[0].ID, cvrContest.ContestId)
+		require.Len(t, cvrContest.CVRContestSelection, 2)
+		assert.Equal(t, election.Contest[0].ContestSelection[1].ID, cvrContest.CVRContestSelection[0].ContestSelectionId)
+		assert.Equal(t, 1, cvrContest.CVRContestSelection[0].Rank)
+		assert.Equal(t, election.Contest[0].ContestSelection[2].ID, cvrContest.CVRContestSelection[1].ContestSelectionId)
+		assert.Equal(t, 2, cvrContest.CVRContestSelection[1].Rank)
+	})
+}
+
+func TestDecodeCSV(t *testing.T) {
+	t.Run("reads ranks in column order and skips blank ranks", func(t *testing.T) {
+		// Given
+		data := []byte("BallotID,Rank 1,Rank 2,Rank 3\n" +
+			"B1,A, ,C\n" +
+			"B2,,,\n")
+
+		// When
+		ballots, err := cvr.DecodeCSV(data)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, []cvr.Ballot{
+			{Row: 1, BallotID: "B1", Choices: []string{"A", "C"}},
+			{Row: 2, BallotID: "B2"},
+		}, ballots)
+	})
+
+	t.Run("errors when missing header", func(t *testing.T) {
+		// When
+		_, err := cvr.DecodeCSV(nil)
+
+		// Then
+		require.ErrorIs(t, err, cvr.ErrInvalidFile)
+	})
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Run("reads ballots written by EncodeJSON", func(t *testing.T) {
+		// Given
+		contest := cvr.Contest{
+			ElectionID: "E1",
+			Name:       "Election Name",
+			Candidates: []cvr.Candidate{
+				{ProposalID: "P1", Name: "Proposal 1"},
+				{ProposalID: "P2", Name: "Proposal 2"},
+			},
+		}
+		data, err := cvr.EncodeJSON(contest, [][]string{
+			{"P2", "P1"},
+			{"P1"},
+		})
+		require.NoError(t, err)
+
+		// When
+		ballots, err := cvr.DecodeJSON(data)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, []cvr.Ballot{
+			{Row: 1, BallotID: "1", Choices: []string{"Proposal 2", "Proposal 1"}},
+			{Row: 2, BallotID: "2", Choices: []string{"Proposal 1"}},
+		}, ballots)
+	})
+
+	t.Run("reads ballots with skipped ranks written by EncodeJSON", func(t *testing.T) {
+		// Given
+		contest := cvr.Contest{
+			ElectionID: "E1",
+			Name:       "Election Name",
+			Candidates: []cvr.Candidate{
+				{ProposalID: "P1", Name: "Proposal 1"},
+				{ProposalID: "P2", Name: "Proposal 2"},
+			},
+		}
+		data, err := cvr.EncodeJSON(contest, [][]string{
+			{"P2", "", "P1"},
+		})
+		require.NoError(t, err)
+
+		// When
+		ballots, err := cvr.DecodeJSON(data)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, []cvr.Ballot{
+			{Row: 1, BallotID: "1", Choices: []string{"Proposal 2", "Proposal 1"}},
+		}, ballots)
+	})
+
+	t.Run("errors on invalid json", func(t *testing.T) {
+		// When
+		_, err := cvr.DecodeJSON([]byte("{"))
+
+		// Then
+		require.ErrorIs(t, err, cvr.ErrInvalidFile)
+	})
+}
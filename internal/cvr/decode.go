@@ -0,0 +1,182 @@
+package cvr
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ballot is a single ballot read from a CSV or CVR file. Choices are candidate
+// names, or ProposalIDs, in order of preference. Row is the 1-based position of
+// the ballot in the file, used when reporting errors.
+type Ballot struct {
+	Row      int
+	BallotID string
+	Choices  []string
+}
+
+// DecodeCSV reads ballots in the format written by EncodeCSV. The first row is
+// a header. A "BallotID" column is optional, and every other column is a rank,
+// in order of preference. Blank ranks are skipped.
+func DecodeCSV(data []byte) ([]Ballot, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidFile)
+	}
+
+	ballotIDColumn := -1
+	for i, column := range rows[0] {
+		if strings.EqualFold(strings.TrimSpace(column), "BallotID") {
+			ballotIDColumn = i
+		}
+	}
+
+	ballots := make([]Ballot, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		ballot := Ballot{
+			Row: i + 1,
+		}
+
+		for column, value := range row {
+			value = strings.TrimSpace(value)
+			if column == ballotIDColumn {
+				ballot.BallotID = value
+				continue
+			}
+
+			if value != "" {
+				ballot.Choices = append(ballot.Choices, value)
+			}
+		}
+
+		ballots = append(ballots, ballot)
+	}
+
+	return ballots, nil
+}
+
+// DecodeJSON reads ballots from a NIST CVR Common Data Format report, such as
+// one written by EncodeJSON. Choices are the names of the candidates, ordered by
+// rank, from the current snapshot of each CVR.
+func DecodeJSON(data []byte) ([]Ballot, error) {
+	var report castVoteRecordReport
+	err := json.Unmarshal(data, &report)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	candidateNames := make(map[string]string)
+	selectionCandidates := make(map[string]string)
+	for _, e := range report.Election {
+		for _, c := range e.Candidate {
+			candidateNames[c.ID] = c.Name
+			if c.Name == "" {
+				candidateNames[c.ID] = c.ID
+			}
+		}
+
+		for _, contest := range e.Contest {
+			for _, selection := range contest.ContestSelection {
+				if len(selection.CandidateIDs) == 1 {
+					selectionCandidates[selection.ID] = selection.CandidateIDs[0]
+				}
+			}
+		}
+	}
+
+	ballots := make([]Ballot, len(report.CVR))
+	for i, record := range report.CVR {
+		ballots[i] = Ballot{
+			Row:      i + 1,
+			BallotID: record.UniqueID,
+		}
+
+		selections := currentSelections(record)
+		sort.SliceStable(selections, func(i, j int) bool {
+			return selectionRank(selections[i]) < selectionRank(selections[j])
+		})
+
+		for _, selection := range selections {
+			candidateID, ok := selectionCandidates[selection.ContestSelectionID]
+			if !ok {
+				return nil, fmt.Errorf("%w: CVR %s has unknown contest selection %s",
+					ErrInvalidFile, ballotLabel(record.UniqueID, i+1), selection.ContestSelectionID)
+			}
+
+			name, ok := candidateNames[candidateID]
+			if !ok {
+				name = candidateID
+			}
+
+			ballots[i].Choices = append(ballots[i].Choices, name)
+		}
+	}
+
+	return ballots, nil
+}
+
+// currentSelections returns the allocable contest selections in the CVR's
+// current snapshot, or its first snapshot when no current snapshot is set.
+func currentSelections(record cvr) []cvrContestSelection {
+	var selections []cvrContestSelection
+
+	for i, snapshot := range record.CVRSnapshot {
+		if snapshot.ID != record.CurrentSnapshotID && (record.CurrentSnapshotID != "" || i > 0) {
+			continue
+		}
+
+		for _, contest := range snapshot.CVRContest {
+			for _, selection := range contest.CVRContestSelection {
+				if isAllocable(selection) {
+					selections = append(selections, selection)
+				}
+			}
+		}
+	}
+
+	return selections
+}
+
+func isAllocable(selection cvrContestSelection) bool {
+	if len(selection.SelectionPosition) == 0 {
+		return true
+	}
+
+	for _, position := range selection.SelectionPosition {
+		if position.IsAllocable != "no" && position.HasIndication != "no" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectionRank returns the rank of the contest selection, falling back to the
+// rank of its first selection position.
+func selectionRank(selection cvrContestSelection) int {
+	if selection.Rank == 0 && len(selection.SelectionPosition) > 0 {
+		return selection.SelectionPosition[0].Rank
+	}
+	return selection.Rank
+}
+
+func ballotLabel(uniqueID string, row int) string {
+	if uniqueID != "" {
+		return uniqueID
+	}
+	return strconv.Itoa(row)
+}
+
+var ErrInvalidFile = fmt.Errorf("invalid cast vote record file")
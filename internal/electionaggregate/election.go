@@ -0,0 +1,263 @@
+package electionaggregate
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+// Election is the event-sourced state of an election, folded from the events
+// in its stream. Version is the number of events applied.
+type Election struct {
+	Election       electionrepository.Election
+	Proposals      []electionrepository.Proposal
+	Votes          []electionrepository.Vote
+	SecretBallots  []electionrepository.SecretBallot
+	EligibleVoters []electionrepository.EligibleVoter
+	BallotTokens   []electionrepository.BallotToken
+	Version        int
+}
+
+func New(electionID string) *Election {
+	return &Election{
+		Election: electionrepository.Election{
+			ElectionID: electionID,
+		},
+	}
+}
+
+// Load replays the election's stream from the event store. ErrElectionNotFound
+// is returned if the stream is empty.
+func Load(ctx context.Context, store eventstore.Store, electionID string) (*Election, error) {
+	records, err := store.Load(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, electionrepository.NewErrElectionNotFound(electionID)
+	}
+
+	election := New(electionID)
+	for _, record := range records {
+		election.Apply(record.Event)
+	}
+
+	return election, nil
+}
+
+// Apply folds a single event into the election state. The election's Version
+// follows the number of events applied, so projections save the version they reflect.
+func (a *Election) Apply(e cqrs.Event) {
+	a.Version++
+	a.Election.Version = a.Version
+
+	switch e := e.(type) {
+	case event.ElectionHasCommenced:
+		a.Election.OrganizerUserID = e.OrganizerUserID
+		a.Election.Name = e.Name
+		a.Election.Description = e.Description
+		a.Election.SeatCount = e.SeatCount
+		a.Election.VotingMethod = e.VotingMethod
+		a.Election.TieBreakPolicy = e.TieBreakPolicy
+		a.Election.RevotePolicy = e.RevotePolicy
+		a.Election.EligibilityPolicy = e.EligibilityPolicy
+		a.Election.BallotSecrecy = e.BallotSecrecy
+		a.Election.MaxRanks = e.MaxRanks
+		a.Election.RequireCompleteRanking = e.RequireCompleteRanking
+		a.Election.AllowDuplicateRanks = e.AllowDuplicateRanks
+		a.Election.AllowSkippedRanks = e.AllowSkippedRanks
+		a.Election.AllowWriteIns = e.AllowWriteIns
+		a.Election.ModerateProposals = e.ModerateProposals
+		a.Election.ProposalDeadline = e.ProposalDeadline
+		a.Election.VotingStartsAt = e.VotingStartsAt
+		a.Election.VotingEndsAt = e.VotingEndsAt
+		a.Election.CommencedAt = e.OccurredAt
+
+	case event.ProposalWasMade:
+		moderationStatus := electionrepository.ModerationStatusApproved
+		if a.Election.ModerateProposals {
+			moderationStatus = electionrepository.ModerationStatusPending
+		}
+
+		a.Proposals = append(a.Proposals, electionrepository.Proposal{
+			ElectionID:       e.ElectionID,
+			ProposalID:       e.ProposalID,
+			OwnerUserID:      e.OwnerUserID,
+			Name:             e.Name,
+			Description:      e.Description,
+			ProposedAt:       e.ProposedAt,
+			ModerationStatus: moderationStatus,
+		})
+
+	case event.ProposalWasUpdated:
+		if i := a.findProposalIndex(e.ProposalID); i >= 0 {
+			a.Proposals[i].Name = e.Name
+			a.Proposals[i].Description = e.Description
+			a.Proposals[i].UpdatedAt = e.OccurredAt
+			if a.Election.ModerateProposals {
+				a.Proposals[i].ModerationStatus = electionrepository.ModerationStatusPending
+				a.Proposals[i].RejectionReason = ""
+			}
+		}
+
+	case event.ProposalWasWithdrawn:
+		if i := a.findProposalIndex(e.ProposalID); i >= 0 {
+			a.Proposals[i].IsWithdrawn = true
+			a.Proposals[i].WithdrawnAt = e.OccurredAt
+		}
+
+	case event.ProposalWasApproved:
+		if i := a.findProposalIndex(e.ProposalID); i >= 0 {
+			a.Proposals[i].ModerationStatus = electionrepository.ModerationStatusApproved
+			a.Proposals[i].ModeratedAt = e.OccurredAt
+		}
+
+	case event.ProposalWasRejected:
+		if i := a.findProposalIndex(e.ProposalID); i >= 0 {
+			a.Proposals[i].ModerationStatus = electionrepository.ModerationStatusRejected
+			a.Proposals[i].RejectionReason = e.Reason
+			a.Proposals[i].ModeratedAt = e.OccurredAt
+		}
+
+	case event.VoteWasCast:
+		a.Votes = append(a.Votes, electionrepository.Vote{
+			VoteID:            e.VoteID,
+			ElectionID:        e.ElectionID,
+			UserID:            e.UserID,
+			RankedProposalIDs: e.RankedProposalIDs,
+			SubmittedAt:       e.OccurredAt,
+			IsImported:        e.IsImported,
+		})
+
+	case event.SecretBallotWasCast:
+		a.SecretBallots = append(a.SecretBallots, electionrepository.SecretBallot{
+			VoteID:            e.VoteID,
+			ElectionID:        e.ElectionID,
+			RankedProposalIDs: e.RankedProposalIDs,
+			BallotTokenHash:   e.BallotTokenHash,
+		})
+
+	case event.VoteWasReplaced:
+		for i, vote := range a.Votes {
+			if vote.VoteID == e.VoteID {
+				a.Votes[i].SupersedesVoteID = e.ReplacedVoteID
+				break
+			}
+		}
+
+	case event.EligibleVoterWasRegistered:
+		if a.isEligibleVoter(e.UserID) {
+			break
+		}
+
+		a.EligibleVoters = append(a.EligibleVoters, electionrepository.EligibleVoter{
+			ElectionID:   e.ElectionID,
+			UserID:       e.UserID,
+			RegisteredAt: e.OccurredAt,
+		})
+
+	case event.EligibleVoterWasRemoved:
+		for i, eligibleVoter := range a.EligibleVoters {
+			if eligibleVoter.UserID == e.UserID {
+				a.EligibleVoters = append(a.EligibleVoters[:i:i], a.EligibleVoters[i+1:]...)
+				break
+			}
+		}
+
+	case event.BallotTokenWasIssued:
+		a.BallotTokens = append(a.BallotTokens, electionrepository.BallotToken{
+			ElectionID:     e.ElectionID,
+			UserID:         e.UserID,
+			BlindSignature: e.BlindSignature,
+			IssuedAt:       e.OccurredAt,
+		})
+
+	case event.ElectionWasClosedByOwner:
+		a.Election.IsClosed = true
+		a.Election.ClosedAt = e.OccurredAt
+		a.Election.BallotChainHead = e.BallotChainHead
+		a.Election.BallotCount = e.BallotCount
+
+	case event.ElectionWasCancelled:
+		a.Election.IsClosed = true
+		a.Election.ClosedAt = e.OccurredAt
+		a.Election.IsCancelled = true
+		a.Election.CancelledAt = e.OccurredAt
+		a.Election.CancellationReason = e.Reason
+
+	case event.ElectionWasReopened:
+		a.Election = a.Election.Reopened(e.VotingEndsAt)
+
+	case event.ElectionWinnerWasSelected:
+		a.Election.WinningProposalID = e.WinningProposalID
+		a.Election.WinningProposalIDs = e.WinningProposalIDs
+		a.Election.TabulationRounds = toTabulationRounds(e.TabulationRounds)
+		a.Election.ReceiptRoot = e.ReceiptRoot
+		a.Election.TieBreakSeed = e.TieBreakSeed
+		a.Election.SelectedAt = e.SelectedAt
+		a.Election.Outcome = electionrepository.OutcomeWinnerSelected
+
+	case event.ElectionClosedWithoutWinner:
+		a.Election.Outcome = e.Outcome
+		a.Election.TabulationRounds = toTabulationRounds(e.TabulationRounds)
+		a.Election.ReceiptRoot = e.ReceiptRoot
+		a.Election.TieBreakSeed = e.TieBreakSeed
+	}
+}
+
+func (a *Election) findProposalIndex(proposalID string) int {
+	for i, proposal := range a.Proposals {
+		if proposal.ProposalID == proposalID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (a *Election) isEligibleVoter(userID string) bool {
+	for _, eligibleVoter := range a.EligibleVoters {
+		if eligibleVoter.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toTabulationRounds(rounds []event.TabulationRound) []electionrepository.TabulationRound {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	tabulationRounds := make([]electionrepository.TabulationRound, len(rounds))
+
+	for i, round := range rounds {
+		proposalCounts := make([]electionrepository.ProposalCount, len(round.ProposalCounts))
+		for j, proposalCount := range round.ProposalCounts {
+			proposalCounts[j] = electionrepository.ProposalCount{
+				ProposalID:    proposalCount.ProposalID,
+				Count:         proposalCount.Count,
+				WeightedCount: proposalCount.WeightedCount,
+			}
+		}
+
+		tabulationRounds[i] = electionrepository.TabulationRound{
+			Number:               round.Number,
+			ProposalCounts:       proposalCounts,
+			EliminatedProposalID: round.EliminatedProposalID,
+			ElectedProposalIDs:   round.ElectedProposalIDs,
+			TransferValue:        round.TransferValue,
+			UsedBordaTieBreaker:  round.UsedBordaTieBreaker,
+			TiedProposalIDs:      round.TiedProposalIDs,
+			ExhaustedBallots:     round.ExhaustedBallots,
+		}
+	}
+
+	return tabulationRounds
+}
@@ -3,48 +3,345 @@ package electionrepository
 import (
 	"context"
 	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const DefaultItemsPerPage = 10
 
+const (
+	// RevotePolicyRejectDuplicate rejects a second ballot from the same voter.
+	RevotePolicyRejectDuplicate = "RejectDuplicate"
+
+	// RevotePolicyReplacePrevious replaces the earlier ballot from the same voter.
+	RevotePolicyReplacePrevious = "ReplacePrevious"
+)
+
+const (
+	// EligibilityPolicyOpen accepts ballots from any user.
+	EligibilityPolicyOpen = "Open"
+
+	// EligibilityPolicyRegisteredVoters only accepts ballots from users on the
+	// election's eligibility roll.
+	EligibilityPolicyRegisteredVoters = "RegisteredVoters"
+)
+
+const (
+	// BallotSecrecyPublic stores each ballot with the UserID that cast it.
+	BallotSecrecyPublic = "Public"
+
+	// BallotSecrecySecret stores ballots without any link to the users who cast
+	// them. Voters prove eligibility with a blind-signed ballot token instead.
+	BallotSecrecySecret = "Secret"
+)
+
+const (
+	// OutcomeWinnerSelected is the outcome of an election closed with a winner.
+	OutcomeWinnerSelected = "WinnerSelected"
+
+	// OutcomeNoVotes is the outcome of an election closed before any ballots were cast.
+	OutcomeNoVotes = "NoVotes"
+
+	// OutcomeNoMajority is the outcome of an election closed without any proposal
+	// reaching the votes needed to win, for example when too many ballots exhausted.
+	OutcomeNoMajority = "NoMajority"
+
+	// OutcomeTie is the outcome of an election closed with more than one proposal
+	// tied for the win. Only voting methods that select the proposal with the
+	// highest score can tie; InstantRunoff and multi-seat elections break every tie
+	// with the election's tie-break policy instead.
+	OutcomeTie = "Tie"
+)
+
+const (
+	// ElectionStatusOpen is the status of an election that accepts proposals and ballots.
+	ElectionStatusOpen = "Open"
+
+	// ElectionStatusClosed is the status of an election that was closed and tabulated.
+	ElectionStatusClosed = "Closed"
+
+	// ElectionStatusCancelled is the status of an election that was cancelled.
+	ElectionStatusCancelled = "Cancelled"
+)
+
+// Election is closed once it is tabulated, and its Outcome records whether a
+// winner was selected. A cancelled election is also closed, without an Outcome,
+// so that it no longer accepts proposals or ballots.
+//
+// BallotChainHead and BallotCount are the VoteHash of the last vote in the ballot
+// chain, and the number of votes in it, when the election was closed by its owner.
+//
+// Version is the version of the election's event stream that the read models
+// reflect. Commands append their events expecting the stream to still be at
+// Version, so a command decided on stale read models is rejected.
 type Election struct {
-	ElectionID        string
-	OrganizerUserID   string
-	Name              string
-	Description       string
-	WinningProposalID string
-	IsClosed          bool
-	CommencedAt       int
-	ClosedAt          int
-	SelectedAt        int
+	ElectionID             string
+	OrganizerUserID        string
+	Name                   string
+	Description            string
+	SeatCount              int
+	VotingMethod           string
+	TieBreakPolicy         string
+	RevotePolicy           string
+	EligibilityPolicy      string
+	BallotSecrecy          string
+	MaxRanks               int
+	RequireCompleteRanking bool
+	AllowDuplicateRanks    bool
+	AllowSkippedRanks      bool
+	AllowWriteIns          bool
+	ModerateProposals      bool
+	ProposalDeadline       int
+	VotingStartsAt         int
+	VotingEndsAt           int
+	WinningProposalID      string
+	WinningProposalIDs     []string
+	Outcome                string
+	IsClosed               bool
+	CommencedAt            int
+	ClosedAt               int
+	SelectedAt             int
+	IsCancelled            bool
+	CancelledAt            int
+	CancellationReason     string
+	ReceiptRoot            string
+	BallotChainHead        string
+	BallotCount            int
+	TieBreakSeed           int64
+	TabulationRounds       []TabulationRound
+	Version                int
+}
+
+// Status returns whether the election is open, closed, or cancelled.
+func (e Election) Status() string {
+	switch {
+	case e.IsCancelled:
+		return ElectionStatusCancelled
+	case e.IsClosed:
+		return ElectionStatusClosed
+	}
+
+	return ElectionStatusOpen
 }
 
+// Reopened returns the election open for voting until votingEndsAt, with the
+// results of its closing, or its cancellation, cleared.
+func (e Election) Reopened(votingEndsAt int) Election {
+	e.VotingEndsAt = votingEndsAt
+	e.IsClosed = false
+	e.ClosedAt = 0
+	e.IsCancelled = false
+	e.CancelledAt = 0
+	e.CancellationReason = ""
+	e.WinningProposalID = ""
+	e.WinningProposalIDs = nil
+	e.Outcome = ""
+	e.TabulationRounds = nil
+	e.SelectedAt = 0
+	e.ReceiptRoot = ""
+	e.BallotChainHead = ""
+	e.BallotCount = 0
+	e.TieBreakSeed = 0
+	return e
+}
+
+type TabulationRound struct {
+	Number               int
+	ProposalCounts       []ProposalCount
+	EliminatedProposalID string
+	ElectedProposalIDs   []string
+	TransferValue        float64
+	UsedBordaTieBreaker  bool
+	TiedProposalIDs      []string
+	ExhaustedBallots     int
+}
+
+type ProposalCount struct {
+	ProposalID    string
+	Count         int
+	WeightedCount float64
+}
+
+const (
+	// ModerationStatusApproved proposals are listed and may be ranked on a ballot.
+	// Proposals in elections without moderation are approved when they are made.
+	ModerationStatusApproved = "Approved"
+
+	// ModerationStatusPending proposals await the organizer's approval.
+	ModerationStatusPending = "Pending"
+
+	// ModerationStatusRejected proposals were turned down by the organizer, with
+	// a RejectionReason.
+	ModerationStatusRejected = "Rejected"
+)
+
+// Proposal is a candidate in an election. A withdrawn, pending, or rejected
+// proposal is kept, so it can still be looked up by ProposalID, but it is not
+// listed by ListProposals and cannot be ranked on a ballot. Repositories save a
+// proposal without a ModerationStatus as approved.
 type Proposal struct {
-	ElectionID  string
-	ProposalID  string
-	OwnerUserID string
-	Name        string
-	Description string
-	ProposedAt  int
+	ElectionID       string
+	ProposalID       string
+	OwnerUserID      string
+	Name             string
+	Description      string
+	ProposedAt       int
+	UpdatedAt        int
+	IsWithdrawn      bool
+	WithdrawnAt      int
+	ModerationStatus string
+	RejectionReason  string
+	ModeratedAt      int
+}
+
+// IsRankable reports whether the proposal is listed and may be ranked on a
+// ballot.
+func (p Proposal) IsRankable() bool {
+	return !p.IsWithdrawn && p.ModerationStatus == ModerationStatusApproved
 }
 
+// Vote is a ballot cast by a user, or imported without a UserID. Secret ballots
+// are stored apart, as SecretBallot.
+//
+// Repositories chain the votes of an election in the order they are saved:
+// PreviousVoteHash is the VoteHash of the vote saved before, and VoteHash covers
+// the vote along with PreviousVoteHash. GetVotes returns votes in chain order.
+//
+// A revote never removes the earlier ballot. It is appended to the chain with
+// SupersedesVoteID set to the voter's previous ballot, and CountedVotes leaves
+// out the ballots that have been superseded.
+//
+// An empty ProposalID in RankedProposalIDs is a rank the voter skipped, and a
+// ProposalID made with writein.New is a write-in candidate.
+//
+// IsImported marks a ballot imported with ImportBallots, such as a paper ballot,
+// which has no UserID and is not checked against the eligibility roll.
 type Vote struct {
 	VoteID            string
 	ElectionID        string
 	UserID            string
 	RankedProposalIDs []string
 	SubmittedAt       int
+	SupersedesVoteID  string
+	PreviousVoteHash  string
+	VoteHash          string
+	IsImported        bool
+}
+
+// CountedVotes returns the votes that count toward the result, the latest
+// ballot of each voter, in chain order.
+func CountedVotes(votes []Vote) []Vote {
+	supersededVoteIDs := make(map[string]struct{})
+	for _, vote := range votes {
+		if vote.SupersedesVoteID != "" {
+			supersededVoteIDs[vote.SupersedesVoteID] = struct{}{}
+		}
+	}
+
+	if len(supersededVoteIDs) == 0 {
+		return votes
+	}
+
+	countedVotes := make([]Vote, 0, len(votes)-len(supersededVoteIDs))
+	for _, vote := range votes {
+		if _, ok := supersededVoteIDs[vote.VoteID]; !ok {
+			countedVotes = append(countedVotes, vote)
+		}
+	}
+
+	return countedVotes
+}
+
+// EligibleVoter is an entry on an election's eligibility roll.
+type EligibleVoter struct {
+	ElectionID   string
+	UserID       string
+	RegisteredAt int
+}
+
+// Turnout compares the number of users on an election's eligibility roll with
+// the number of ballots cast. TotalVoted counts each voter's latest ballot, every
+// secret ballot, and every imported ballot.
+type Turnout struct {
+	ElectionID    string
+	TotalEligible int
+	TotalVoted    int
 }
 
+// SecretBallot is a ballot cast with a ballot token in an election with secret
+// ballots. It is kept apart from the votes, without a UserID, a submission time,
+// or a place in the ballot chain, so it cannot be linked to the user who was
+// issued the token. BallotTokenHash is the hash of the spent ballot token.
+//
+// GetSecretBallots returns secret ballots ordered by BallotTokenHash rather than
+// in the order they were cast.
+type SecretBallot struct {
+	VoteID            string
+	ElectionID        string
+	RankedProposalIDs []string
+	BallotTokenHash   string
+}
+
+// BallotToken records that UserID was issued a ballot token for a secret-ballot
+// election, and holds the blind signature returned to them. It is the only
+// record of who participated in the election.
+type BallotToken struct {
+	ElectionID     string
+	UserID         string
+	BlindSignature []byte
+	IssuedAt       int
+}
+
+// ElectionFilter narrows the elections returned by ListElections. Zero values
+// do not filter. The date ranges are inclusive, and a closed date range only
+// matches elections that have closed. Name matches any election whose name
+// contains it, ignoring case.
+type ElectionFilter struct {
+	Status          string
+	OrganizerUserID string
+	CommencedFrom   int
+	CommencedTo     int
+	ClosedFrom      int
+	ClosedTo        int
+	Name            string
+}
+
+// Repository holds the read models built by the election projection. The Validate
+// methods run the same checks as the matching writes without saving anything, so
+// a command can be rejected before its events are recorded.
 type Repository interface {
 	SaveElection(ctx context.Context, election Election) error
 	GetElection(ctx context.Context, electionID string) (Election, error)
 	SaveProposal(ctx context.Context, proposal Proposal) error
 	GetProposal(ctx context.Context, proposalID string) (Proposal, error)
+	UpdateProposal(ctx context.Context, proposal Proposal) error
+	ValidateProposalUpdate(ctx context.Context, proposal Proposal) error
 	SaveVote(ctx context.Context, vote Vote) error
+	SaveVotes(ctx context.Context, votes []Vote) error
+	ValidateVotes(ctx context.Context, votes []Vote) error
+	GetUserVoteID(ctx context.Context, electionID, userID string) (string, error)
+	GetVote(ctx context.Context, electionID, voteID string) (Vote, error)
 	GetVotes(ctx context.Context, electionID string) ([]Vote, error)
+	SaveSecretBallot(ctx context.Context, secretBallot SecretBallot) error
+	ValidateSecretBallot(ctx context.Context, secretBallot SecretBallot) error
+	GetSecretBallots(ctx context.Context, electionID string) ([]SecretBallot, error)
 	ListOpenElections(ctx context.Context, page, itemsPerPage int, sortBy, sortDirection *string) (int, []Election, error)
+	ListElections(ctx context.Context, filter ElectionFilter, page, itemsPerPage int, sortBy, sortDirection *string) (int, []Election, error)
+	ListElectionsToClose(ctx context.Context, votingEndedBy int) ([]Election, error)
 	ListProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []Proposal, error)
+	ListPendingProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []Proposal, error)
+	SaveEligibleVoters(ctx context.Context, eligibleVoters []EligibleVoter) error
+	RemoveEligibleVoter(ctx context.Context, electionID, userID string) error
+	ValidateEligibleVoterRemoval(ctx context.Context, electionID, userID string) error
+	GetTurnout(ctx context.Context, electionID string) (Turnout, error)
+	SaveBallotKey(ctx context.Context, electionID string, privateKey []byte) error
+	GetBallotKey(ctx context.Context, electionID string) ([]byte, error)
+	SaveBallotToken(ctx context.Context, ballotToken BallotToken) error
+	ValidateBallotToken(ctx context.Context, ballotToken BallotToken) error
+	GetBallotToken(ctx context.Context, electionID, userID string) (BallotToken, error)
+	DeleteElection(ctx context.Context, electionID string) error
+	DeleteAll(ctx context.Context) error
 }
 
 type ErrElectionNotFound struct {
@@ -59,6 +356,10 @@ func (e ErrElectionNotFound) Error() string {
 	return fmt.Sprintf("election (%s) not found", e.electionID)
 }
 
+func (e ErrElectionNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
 type ErrProposalNotFound struct {
 	proposalID string
 }
@@ -71,6 +372,74 @@ func (e ErrProposalNotFound) Error() string {
 	return fmt.Sprintf("proposal (%s) not found", e.proposalID)
 }
 
+func (e ErrProposalNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+type ErrVoteNotFound struct {
+	voteID string
+}
+
+func NewErrVoteNotFound(voteID string) *ErrVoteNotFound {
+	return &ErrVoteNotFound{voteID: voteID}
+}
+
+func (e ErrVoteNotFound) Error() string {
+	return fmt.Sprintf("vote (%s) not found", e.voteID)
+}
+
+func (e ErrVoteNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+type ErrProposalWithdrawn struct {
+	proposalID string
+}
+
+func NewErrProposalWithdrawn(proposalID string) *ErrProposalWithdrawn {
+	return &ErrProposalWithdrawn{proposalID: proposalID}
+}
+
+func (e ErrProposalWithdrawn) Error() string {
+	return fmt.Sprintf("proposal (%s) has been withdrawn", e.proposalID)
+}
+
+func (e ErrProposalWithdrawn) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type ErrProposalNotApproved struct {
+	proposalID string
+}
+
+func NewErrProposalNotApproved(proposalID string) *ErrProposalNotApproved {
+	return &ErrProposalNotApproved{proposalID: proposalID}
+}
+
+func (e ErrProposalNotApproved) Error() string {
+	return fmt.Sprintf("proposal (%s) has not been approved", e.proposalID)
+}
+
+func (e ErrProposalNotApproved) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type ErrProposalHasVotes struct {
+	proposalID string
+}
+
+func NewErrProposalHasVotes(proposalID string) *ErrProposalHasVotes {
+	return &ErrProposalHasVotes{proposalID: proposalID}
+}
+
+func (e ErrProposalHasVotes) Error() string {
+	return fmt.Sprintf("proposal (%s) is ranked on a ballot and cannot be changed", e.proposalID)
+}
+
+func (e ErrProposalHasVotes) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
 type ErrInvalidElectionProposal struct {
 	proposalID string
 	electionID string
@@ -86,3 +455,175 @@ func NewErrInvalidElectionProposal(proposalID, electionID string) *ErrInvalidEle
 func (e ErrInvalidElectionProposal) Error() string {
 	return fmt.Sprintf("invalid proposal (%s) for wrong election (%s)", e.proposalID, e.electionID)
 }
+
+func (e ErrInvalidElectionProposal) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+type ErrDuplicateVote struct {
+	electionID string
+	userID     string
+}
+
+func NewErrDuplicateVote(electionID, userID string) *ErrDuplicateVote {
+	return &ErrDuplicateVote{
+		electionID: electionID,
+		userID:     userID,
+	}
+}
+
+func (e ErrDuplicateVote) Error() string {
+	return fmt.Sprintf("user (%s) has already voted in election (%s)", e.userID, e.electionID)
+}
+
+func (e ErrDuplicateVote) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+type ErrElectionClosed struct {
+	electionID string
+}
+
+func NewErrElectionClosed(electionID string) *ErrElectionClosed {
+	return &ErrElectionClosed{electionID: electionID}
+}
+
+func (e ErrElectionClosed) Error() string {
+	return fmt.Sprintf("election (%s) is closed", e.electionID)
+}
+
+func (e ErrElectionClosed) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type ErrVoterNotEligible struct {
+	electionID string
+	userID     string
+}
+
+func NewErrVoterNotEligible(electionID, userID string) *ErrVoterNotEligible {
+	return &ErrVoterNotEligible{
+		electionID: electionID,
+		userID:     userID,
+	}
+}
+
+func (e ErrVoterNotEligible) Error() string {
+	return fmt.Sprintf("user (%s) is not on the eligibility roll for election (%s)", e.userID, e.electionID)
+}
+
+func (e ErrVoterNotEligible) GRPCStatus() *status.Status {
+	return status.New(codes.PermissionDenied, e.Error())
+}
+
+type ErrEligibleVoterHasVoted struct {
+	electionID string
+	userID     string
+}
+
+func NewErrEligibleVoterHasVoted(electionID, userID string) *ErrEligibleVoterHasVoted {
+	return &ErrEligibleVoterHasVoted{
+		electionID: electionID,
+		userID:     userID,
+	}
+}
+
+func (e ErrEligibleVoterHasVoted) Error() string {
+	return fmt.Sprintf("user (%s) has already voted in election (%s) and cannot be removed from the eligibility roll", e.userID, e.electionID)
+}
+
+func (e ErrEligibleVoterHasVoted) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type ErrBallotKeyNotFound struct {
+	electionID string
+}
+
+func NewErrBallotKeyNotFound(electionID string) *ErrBallotKeyNotFound {
+	return &ErrBallotKeyNotFound{electionID: electionID}
+}
+
+func (e ErrBallotKeyNotFound) Error() string {
+	return fmt.Sprintf("ballot key for election (%s) not found", e.electionID)
+}
+
+func (e ErrBallotKeyNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+type ErrBallotTokenNotFound struct {
+	electionID string
+	userID     string
+}
+
+func NewErrBallotTokenNotFound(electionID, userID string) *ErrBallotTokenNotFound {
+	return &ErrBallotTokenNotFound{
+		electionID: electionID,
+		userID:     userID,
+	}
+}
+
+func (e ErrBallotTokenNotFound) Error() string {
+	return fmt.Sprintf("user (%s) has not been issued a ballot token for election (%s)", e.userID, e.electionID)
+}
+
+func (e ErrBallotTokenNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+type ErrBallotTokenAlreadyIssued struct {
+	electionID string
+	userID     string
+}
+
+func NewErrBallotTokenAlreadyIssued(electionID, userID string) *ErrBallotTokenAlreadyIssued {
+	return &ErrBallotTokenAlreadyIssued{
+		electionID: electionID,
+		userID:     userID,
+	}
+}
+
+func (e ErrBallotTokenAlreadyIssued) Error() string {
+	return fmt.Sprintf("user (%s) has already been issued a ballot token for election (%s)", e.userID, e.electionID)
+}
+
+func (e ErrBallotTokenAlreadyIssued) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+type ErrBallotTokenSpent struct {
+	electionID string
+}
+
+func NewErrBallotTokenSpent(electionID string) *ErrBallotTokenSpent {
+	return &ErrBallotTokenSpent{electionID: electionID}
+}
+
+func (e ErrBallotTokenSpent) Error() string {
+	return fmt.Sprintf("ballot token has already been used in election (%s)", e.electionID)
+}
+
+func (e ErrBallotTokenSpent) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+type ErrBrokenBallotChain struct {
+	electionID string
+	voteID     string
+}
+
+func NewErrBrokenBallotChain(electionID, voteID string) *ErrBrokenBallotChain {
+	return &ErrBrokenBallotChain{
+		electionID: electionID,
+		voteID:     voteID,
+	}
+}
+
+func (e ErrBrokenBallotChain) Error() string {
+	return fmt.Sprintf("ballot chain for election (%s) is broken at vote (%s)", e.electionID, e.voteID)
+}
+
+func (e ErrBrokenBallotChain) GRPCStatus() *status.Status {
+	return status.New(codes.DataLoss, e.Error())
+}
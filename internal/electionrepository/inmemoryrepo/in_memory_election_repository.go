@@ -3,6 +3,7 @@ package inmemoryrepo
 import (
 	"context"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/inklabs/vote/internal/ballotchain"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/writein"
 	"github.com/inklabs/vote/pkg/sleep"
 )
 
@@ -30,13 +33,29 @@ type inMemoryElectionRepository struct {
 
 	// votes key by electionID
 	votes map[string][]electionrepository.Vote
+
+	// secretBallots key by electionID, then ballotTokenHash
+	secretBallots map[string]map[string]electionrepository.SecretBallot
+
+	// eligibleVoters key by electionID, then userID
+	eligibleVoters map[string]map[string]electionrepository.EligibleVoter
+
+	// ballotKeys key by electionID
+	ballotKeys map[string][]byte
+
+	// ballotTokens key by electionID, then userID
+	ballotTokens map[string]map[string]electionrepository.BallotToken
 }
 
 func New() *inMemoryElectionRepository {
 	return &inMemoryElectionRepository{
-		elections: make(map[string]electionrepository.Election),
-		proposals: make(map[string]electionrepository.Proposal),
-		votes:     make(map[string][]electionrepository.Vote),
+		elections:      make(map[string]electionrepository.Election),
+		proposals:      make(map[string]electionrepository.Proposal),
+		votes:          make(map[string][]electionrepository.Vote),
+		secretBallots:  make(map[string]map[string]electionrepository.SecretBallot),
+		eligibleVoters: make(map[string]map[string]electionrepository.EligibleVoter),
+		ballotKeys:     make(map[string][]byte),
+		ballotTokens:   make(map[string]map[string]electionrepository.BallotToken),
 	}
 }
 
@@ -82,13 +101,16 @@ func (r *inMemoryElectionRepository) SaveProposal(ctx context.Context, proposal
 
 	sleep.Rand(2 * time.Millisecond)
 
-	if _, ok := r.elections[proposal.ElectionID]; !ok {
-		err := electionrepository.NewErrElectionNotFound(proposal.ElectionID)
+	err := r.validateOpenElection(proposal.ElectionID)
+	if err != nil {
 		recordSpanError(span, err)
-
 		return err
 	}
 
+	if proposal.ModerationStatus == "" {
+		proposal.ModerationStatus = electionrepository.ModerationStatusApproved
+	}
+
 	r.proposals[proposal.ProposalID] = proposal
 
 	return nil
@@ -113,6 +135,76 @@ func (r *inMemoryElectionRepository) GetProposal(ctx context.Context, proposalID
 	return electionrepository.Proposal{}, err
 }
 
+// UpdateProposal replaces a saved proposal. A proposal cannot be changed once
+// any ballot in its election ranks it.
+func (r *inMemoryElectionRepository) UpdateProposal(ctx context.Context, proposal electionrepository.Proposal) error {
+	_, span := tracer.Start(ctx, "db.update-proposal")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	err := r.validateProposalUpdate(proposal)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	r.proposals[proposal.ProposalID] = proposal
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) ValidateProposalUpdate(ctx context.Context, proposal electionrepository.Proposal) error {
+	_, span := tracer.Start(ctx, "db.validate-proposal-update")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	err := r.validateProposalUpdate(proposal)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateProposalUpdate(proposal electionrepository.Proposal) error {
+	err := r.validateOpenElection(proposal.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	savedProposal, ok := r.proposals[proposal.ProposalID]
+	if !ok || savedProposal.ElectionID != proposal.ElectionID {
+		return electionrepository.NewErrProposalNotFound(proposal.ProposalID)
+	}
+
+	if r.isProposalRanked(proposal.ElectionID, proposal.ProposalID) {
+		return electionrepository.NewErrProposalHasVotes(proposal.ProposalID)
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) isProposalRanked(electionID, proposalID string) bool {
+	for _, vote := range r.votes[electionID] {
+		for _, rankedProposalID := range vote.RankedProposalIDs {
+			if rankedProposalID == proposalID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (r *inMemoryElectionRepository) SaveVote(ctx context.Context, vote electionrepository.Vote) error {
 	_, span := tracer.Start(ctx, "db.save-vote")
 	defer span.End()
@@ -122,34 +214,229 @@ func (r *inMemoryElectionRepository) SaveVote(ctx context.Context, vote election
 
 	sleep.Rand(2 * time.Millisecond)
 
-	if _, ok := r.elections[vote.ElectionID]; !ok {
-		err := electionrepository.NewErrElectionNotFound(vote.ElectionID)
+	err := r.validateVotes([]electionrepository.Vote{vote})
+	if err != nil {
 		recordSpanError(span, err)
+		return err
+	}
 
+	r.appendVote(vote)
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) SaveVotes(ctx context.Context, votes []electionrepository.Vote) error {
+	_, span := tracer.Start(ctx, "db.save-votes")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	err := r.validateVotes(votes)
+	if err != nil {
+		recordSpanError(span, err)
 		return err
 	}
 
-	for _, proposalID := range vote.RankedProposalIDs {
-		if proposal, ok := r.proposals[proposalID]; ok {
-			if proposal.ElectionID != vote.ElectionID {
-				err := electionrepository.NewErrInvalidElectionProposal(proposal.ProposalID, vote.ElectionID)
-				recordSpanError(span, err)
+	for _, vote := range votes {
+		r.appendVote(vote)
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) ValidateVotes(ctx context.Context, votes []electionrepository.Vote) error {
+	_, span := tracer.Start(ctx, "db.validate-votes")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	err := r.validateVotes(votes)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
 
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateVotes(votes []electionrepository.Vote) error {
+	batchUserVoteIDs := make(map[string]string)
+	for _, vote := range votes {
+		err := r.validateVote(vote)
+		if err != nil {
+			return err
+		}
+
+		if vote.IsImported {
+			err = validateSupersededVote(vote, "")
+			if err != nil {
 				return err
 			}
-		} else {
-			err := electionrepository.NewErrProposalNotFound(proposalID)
-			recordSpanError(span, err)
 
+			continue
+		}
+
+		batchUserID := vote.ElectionID + "/" + vote.UserID
+		userVoteID, isInBatch := batchUserVoteIDs[batchUserID]
+		if !isInBatch {
+			userVoteID = r.getUserVoteID(vote.ElectionID, vote.UserID)
+		}
+
+		err = validateSupersededVote(vote, userVoteID)
+		if err != nil {
+			return err
+		}
+
+		batchUserVoteIDs[batchUserID] = vote.VoteID
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) GetUserVoteID(ctx context.Context, electionID, userID string) (string, error) {
+	_, span := tracer.Start(ctx, "db.get-user-vote-id")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	return r.getUserVoteID(electionID, userID), nil
+}
+
+// appendVote links vote to the last vote in its election's ballot chain.
+func (r *inMemoryElectionRepository) appendVote(vote electionrepository.Vote) {
+	votes := r.votes[vote.ElectionID]
+
+	previousVoteHash := ""
+	if len(votes) > 0 {
+		previousVoteHash = votes[len(votes)-1].VoteHash
+	}
+
+	r.votes[vote.ElectionID] = append(votes, ballotchain.Link(vote, previousVoteHash))
+}
+
+func (r *inMemoryElectionRepository) validateVote(vote electionrepository.Vote) error {
+	err := r.validateOpenElection(vote.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	if !vote.IsImported {
+		err = r.validateEligibleVoter(vote.ElectionID, vote.UserID)
+		if err != nil {
 			return err
 		}
 	}
 
-	r.votes[vote.ElectionID] = append(r.votes[vote.ElectionID], vote)
+	return r.validateRankedProposals(vote.ElectionID, vote.RankedProposalIDs)
+}
+
+func (r *inMemoryElectionRepository) validateRankedProposals(electionID string, rankedProposalIDs []string) error {
+	for _, proposalID := range rankedProposalIDs {
+		if proposalID == "" || writein.Is(proposalID) {
+			continue
+		}
+
+		if proposal, ok := r.proposals[proposalID]; ok {
+			if proposal.ElectionID != electionID {
+				return electionrepository.NewErrInvalidElectionProposal(proposal.ProposalID, electionID)
+			}
+
+			if proposal.IsWithdrawn {
+				return electionrepository.NewErrProposalWithdrawn(proposalID)
+			}
+
+			if !proposal.IsRankable() {
+				return electionrepository.NewErrProposalNotApproved(proposalID)
+			}
+		} else {
+			return electionrepository.NewErrProposalNotFound(proposalID)
+		}
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateOpenElection(electionID string) error {
+	election, ok := r.elections[electionID]
+	if !ok {
+		return electionrepository.NewErrElectionNotFound(electionID)
+	}
+
+	if election.IsClosed {
+		return electionrepository.NewErrElectionClosed(electionID)
+	}
+
+	return nil
+}
+
+// validateEligibleVoter rejects users who are not on the eligibility roll of a
+// RegisteredVoters election. Imported ballots are not checked.
+func (r *inMemoryElectionRepository) validateEligibleVoter(electionID, userID string) error {
+	if r.elections[electionID].EligibilityPolicy != electionrepository.EligibilityPolicyRegisteredVoters {
+		return nil
+	}
+
+	if _, ok := r.eligibleVoters[electionID][userID]; !ok {
+		return electionrepository.NewErrVoterNotEligible(electionID, userID)
+	}
+
+	return nil
+}
+
+// getUserVoteID returns the user's latest vote in the election, or an empty
+// VoteID if the user has not voted. Imported votes are never matched.
+func (r *inMemoryElectionRepository) getUserVoteID(electionID, userID string) string {
+	votes := r.votes[electionID]
+	for i := len(votes) - 1; i >= 0; i-- {
+		if votes[i].UserID == userID && !votes[i].IsImported {
+			return votes[i].VoteID
+		}
+	}
+
+	return ""
+}
+
+// validateSupersededVote rejects a second vote by the same user, unless it
+// supersedes the user's latest vote, userVoteID. An imported vote never
+// supersedes another.
+func validateSupersededVote(vote electionrepository.Vote, userVoteID string) error {
+	if vote.SupersedesVoteID != userVoteID {
+		return electionrepository.NewErrDuplicateVote(vote.ElectionID, vote.UserID)
+	}
 
 	return nil
 }
 
+func (r *inMemoryElectionRepository) GetVote(ctx context.Context, electionID, voteID string) (electionrepository.Vote, error) {
+	_, span := tracer.Start(ctx, "db.get-vote")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	for _, vote := range r.votes[electionID] {
+		if vote.VoteID == voteID {
+			return vote, nil
+		}
+	}
+
+	err := electionrepository.NewErrVoteNotFound(voteID)
+	recordSpanError(span, err)
+
+	return electionrepository.Vote{}, err
+}
+
 func (r *inMemoryElectionRepository) GetVotes(ctx context.Context, electionID string) ([]electionrepository.Vote, error) {
 	_, span := tracer.Start(ctx, "db.get-votes")
 	defer span.End()
@@ -163,12 +450,105 @@ func (r *inMemoryElectionRepository) GetVotes(ctx context.Context, electionID st
 		return votes, nil
 	}
 
+	if _, ok := r.elections[electionID]; ok {
+		return nil, nil
+	}
+
 	err := electionrepository.NewErrElectionNotFound(electionID)
 	recordSpanError(span, err)
 
 	return nil, err
 }
 
+func (r *inMemoryElectionRepository) SaveSecretBallot(ctx context.Context, secretBallot electionrepository.SecretBallot) error {
+	_, span := tracer.Start(ctx, "db.save-secret-ballot")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	err := r.validateSecretBallot(secretBallot)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	secretBallots, ok := r.secretBallots[secretBallot.ElectionID]
+	if !ok {
+		secretBallots = make(map[string]electionrepository.SecretBallot)
+		r.secretBallots[secretBallot.ElectionID] = secretBallots
+	}
+
+	secretBallots[secretBallot.BallotTokenHash] = secretBallot
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) ValidateSecretBallot(ctx context.Context, secretBallot electionrepository.SecretBallot) error {
+	_, span := tracer.Start(ctx, "db.validate-secret-ballot")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	err := r.validateSecretBallot(secretBallot)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateSecretBallot(secretBallot electionrepository.SecretBallot) error {
+	err := r.validateOpenElection(secretBallot.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateRankedProposals(secretBallot.ElectionID, secretBallot.RankedProposalIDs)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.secretBallots[secretBallot.ElectionID][secretBallot.BallotTokenHash]; ok {
+		return electionrepository.NewErrBallotTokenSpent(secretBallot.ElectionID)
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) GetSecretBallots(ctx context.Context, electionID string) ([]electionrepository.SecretBallot, error) {
+	_, span := tracer.Start(ctx, "db.get-secret-ballots")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	if _, ok := r.elections[electionID]; !ok {
+		err := electionrepository.NewErrElectionNotFound(electionID)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	var secretBallots []electionrepository.SecretBallot
+	for _, secretBallot := range r.secretBallots[electionID] {
+		secretBallots = append(secretBallots, secretBallot)
+	}
+
+	sort.Slice(secretBallots, func(i, j int) bool {
+		return secretBallots[i].BallotTokenHash < secretBallots[j].BallotTokenHash
+	})
+
+	return secretBallots, nil
+}
+
 func (r *inMemoryElectionRepository) ListOpenElections(ctx context.Context, page, itemsPerPage int, sortBy, sortDirection *string) (int, []electionrepository.Election, error) {
 	_, span := tracer.Start(ctx, "db.list-open-elections")
 	defer span.End()
@@ -192,10 +572,375 @@ func (r *inMemoryElectionRepository) ListOpenElections(ctx context.Context, page
 	return totalResults, pageEntity(openElections, page, itemsPerPage), nil
 }
 
+func (r *inMemoryElectionRepository) ListElections(ctx context.Context, filter electionrepository.ElectionFilter, page, itemsPerPage int, sortBy, sortDirection *string) (int, []electionrepository.Election, error) {
+	_, span := tracer.Start(ctx, "db.list-elections")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	var elections []electionrepository.Election
+
+	for _, election := range r.elections {
+		if matchesElectionFilter(election, filter) {
+			elections = append(elections, election)
+		}
+	}
+
+	sortElections(elections, sortBy, sortDirection)
+
+	totalResults := len(elections)
+	return totalResults, pageEntity(elections, page, itemsPerPage), nil
+}
+
+func matchesElectionFilter(election electionrepository.Election, filter electionrepository.ElectionFilter) bool {
+	if filter.Status != "" && election.Status() != filter.Status {
+		return false
+	}
+
+	if filter.OrganizerUserID != "" && election.OrganizerUserID != filter.OrganizerUserID {
+		return false
+	}
+
+	if filter.CommencedFrom > 0 && election.CommencedAt < filter.CommencedFrom {
+		return false
+	}
+
+	if filter.CommencedTo > 0 && election.CommencedAt > filter.CommencedTo {
+		return false
+	}
+
+	if filter.ClosedFrom > 0 || filter.ClosedTo > 0 {
+		if !election.IsClosed {
+			return false
+		}
+
+		if filter.ClosedFrom > 0 && election.ClosedAt < filter.ClosedFrom {
+			return false
+		}
+
+		if filter.ClosedTo > 0 && election.ClosedAt > filter.ClosedTo {
+			return false
+		}
+	}
+
+	if filter.Name != "" && !strings.Contains(strings.ToLower(election.Name), strings.ToLower(filter.Name)) {
+		return false
+	}
+
+	return true
+}
+
+func (r *inMemoryElectionRepository) ListElectionsToClose(ctx context.Context, votingEndedBy int) ([]electionrepository.Election, error) {
+	_, span := tracer.Start(ctx, "db.list-elections-to-close")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	var elections []electionrepository.Election
+
+	for _, election := range r.elections {
+		if !election.IsClosed && election.VotingEndsAt > 0 && election.VotingEndsAt <= votingEndedBy {
+			elections = append(elections, election)
+		}
+	}
+
+	sort.Slice(elections, func(i, j int) bool {
+		return elections[i].VotingEndsAt < elections[j].VotingEndsAt
+	})
+
+	return elections, nil
+}
+
+func (r *inMemoryElectionRepository) SaveEligibleVoters(ctx context.Context, eligibleVoters []electionrepository.EligibleVoter) error {
+	_, span := tracer.Start(ctx, "db.save-eligible-voters")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	for _, eligibleVoter := range eligibleVoters {
+		err := r.validateOpenElection(eligibleVoter.ElectionID)
+		if err != nil {
+			recordSpanError(span, err)
+			return err
+		}
+	}
+
+	for _, eligibleVoter := range eligibleVoters {
+		roll, ok := r.eligibleVoters[eligibleVoter.ElectionID]
+		if !ok {
+			roll = make(map[string]electionrepository.EligibleVoter)
+			r.eligibleVoters[eligibleVoter.ElectionID] = roll
+		}
+
+		if _, ok := roll[eligibleVoter.UserID]; !ok {
+			roll[eligibleVoter.UserID] = eligibleVoter
+		}
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) RemoveEligibleVoter(ctx context.Context, electionID, userID string) error {
+	_, span := tracer.Start(ctx, "db.remove-eligible-voter")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	err := r.validateEligibleVoterRemoval(electionID, userID)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	delete(r.eligibleVoters[electionID], userID)
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) ValidateEligibleVoterRemoval(ctx context.Context, electionID, userID string) error {
+	_, span := tracer.Start(ctx, "db.validate-eligible-voter-removal")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	err := r.validateEligibleVoterRemoval(electionID, userID)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateEligibleVoterRemoval(electionID, userID string) error {
+	err := r.validateOpenElection(electionID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.eligibleVoters[electionID][userID]; !ok {
+		return electionrepository.NewErrVoterNotEligible(electionID, userID)
+	}
+
+	_, hasBallotToken := r.ballotTokens[electionID][userID]
+	if hasBallotToken || r.getUserVoteID(electionID, userID) != "" {
+		return electionrepository.NewErrEligibleVoterHasVoted(electionID, userID)
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) GetTurnout(ctx context.Context, electionID string) (electionrepository.Turnout, error) {
+	_, span := tracer.Start(ctx, "db.get-turnout")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	if _, ok := r.elections[electionID]; !ok {
+		err := electionrepository.NewErrElectionNotFound(electionID)
+		recordSpanError(span, err)
+		return electionrepository.Turnout{}, err
+	}
+
+	return electionrepository.Turnout{
+		ElectionID:    electionID,
+		TotalEligible: len(r.eligibleVoters[electionID]),
+		TotalVoted:    len(electionrepository.CountedVotes(r.votes[electionID])) + len(r.secretBallots[electionID]),
+	}, nil
+}
+
+func (r *inMemoryElectionRepository) SaveBallotKey(ctx context.Context, electionID string, privateKey []byte) error {
+	_, span := tracer.Start(ctx, "db.save-ballot-key")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	r.ballotKeys[electionID] = privateKey
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) GetBallotKey(ctx context.Context, electionID string) ([]byte, error) {
+	_, span := tracer.Start(ctx, "db.get-ballot-key")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	if privateKey, ok := r.ballotKeys[electionID]; ok {
+		return privateKey, nil
+	}
+
+	err := electionrepository.NewErrBallotKeyNotFound(electionID)
+	recordSpanError(span, err)
+
+	return nil, err
+}
+
+func (r *inMemoryElectionRepository) SaveBallotToken(ctx context.Context, ballotToken electionrepository.BallotToken) error {
+	_, span := tracer.Start(ctx, "db.save-ballot-token")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	sleep.Rand(2 * time.Millisecond)
+
+	err := r.validateBallotToken(ballotToken)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	ballotTokens, ok := r.ballotTokens[ballotToken.ElectionID]
+	if !ok {
+		ballotTokens = make(map[string]electionrepository.BallotToken)
+		r.ballotTokens[ballotToken.ElectionID] = ballotTokens
+	}
+
+	ballotTokens[ballotToken.UserID] = ballotToken
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) ValidateBallotToken(ctx context.Context, ballotToken electionrepository.BallotToken) error {
+	_, span := tracer.Start(ctx, "db.validate-ballot-token")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	err := r.validateBallotToken(ballotToken)
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) validateBallotToken(ballotToken electionrepository.BallotToken) error {
+	err := r.validateOpenElection(ballotToken.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateEligibleVoter(ballotToken.ElectionID, ballotToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.ballotTokens[ballotToken.ElectionID][ballotToken.UserID]; ok {
+		return electionrepository.NewErrBallotTokenAlreadyIssued(ballotToken.ElectionID, ballotToken.UserID)
+	}
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) GetBallotToken(ctx context.Context, electionID, userID string) (electionrepository.BallotToken, error) {
+	_, span := tracer.Start(ctx, "db.get-ballot-token")
+	defer span.End()
+
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	sleep.Rand(1 * time.Millisecond)
+
+	if ballotToken, ok := r.ballotTokens[electionID][userID]; ok {
+		return ballotToken, nil
+	}
+
+	err := electionrepository.NewErrBallotTokenNotFound(electionID, userID)
+	recordSpanError(span, err)
+
+	return electionrepository.BallotToken{}, err
+}
+
+func (r *inMemoryElectionRepository) DeleteElection(ctx context.Context, electionID string) error {
+	_, span := tracer.Start(ctx, "db.delete-election")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.elections, electionID)
+	for proposalID, proposal := range r.proposals {
+		if proposal.ElectionID == electionID {
+			delete(r.proposals, proposalID)
+		}
+	}
+	delete(r.votes, electionID)
+	delete(r.secretBallots, electionID)
+	delete(r.eligibleVoters, electionID)
+	delete(r.ballotTokens, electionID)
+
+	return nil
+}
+
+func (r *inMemoryElectionRepository) DeleteAll(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "db.delete-all")
+	defer span.End()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.elections = make(map[string]electionrepository.Election)
+	r.proposals = make(map[string]electionrepository.Proposal)
+	r.votes = make(map[string][]electionrepository.Vote)
+	r.secretBallots = make(map[string]map[string]electionrepository.SecretBallot)
+	r.eligibleVoters = make(map[string]map[string]electionrepository.EligibleVoter)
+	r.ballotTokens = make(map[string]map[string]electionrepository.BallotToken)
+
+	// Ballot keys are kept, as they are not recorded in the event log and
+	// cannot be rebuilt from it.
+
+	return nil
+}
+
 func (r *inMemoryElectionRepository) ListProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
 	_, span := tracer.Start(ctx, "db.list-proposals")
 	defer span.End()
 
+	return r.listProposals(span, electionID, page, itemsPerPage, electionrepository.Proposal.IsRankable)
+}
+
+// ListPendingProposals returns the proposals awaiting moderation, in the order
+// they were made.
+func (r *inMemoryElectionRepository) ListPendingProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
+	_, span := tracer.Start(ctx, "db.list-pending-proposals")
+	defer span.End()
+
+	return r.listProposals(span, electionID, page, itemsPerPage, func(proposal electionrepository.Proposal) bool {
+		return !proposal.IsWithdrawn && proposal.ModerationStatus == electionrepository.ModerationStatusPending
+	})
+}
+
+func (r *inMemoryElectionRepository) listProposals(span trace.Span, electionID string, page, itemsPerPage int, include func(electionrepository.Proposal) bool) (int, []electionrepository.Proposal, error) {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
 
@@ -211,7 +956,7 @@ func (r *inMemoryElectionRepository) ListProposals(ctx context.Context, election
 	var proposals []electionrepository.Proposal
 
 	for _, proposal := range r.proposals {
-		if proposal.ElectionID == electionID {
+		if proposal.ElectionID == electionID && include(proposal) {
 			proposals = append(proposals, proposal)
 		}
 	}
@@ -250,6 +995,16 @@ func sortElections(elections []electionrepository.Election, by, direction *strin
 				return elections[i].CommencedAt > elections[j].CommencedAt
 			}
 		}
+	case "ClosedAt":
+		if sortDirection == "ascending" {
+			sortFunction = func(i, j int) bool {
+				return elections[i].ClosedAt < elections[j].ClosedAt
+			}
+		} else {
+			sortFunction = func(i, j int) bool {
+				return elections[i].ClosedAt > elections[j].ClosedAt
+			}
+		}
 	}
 
 	sort.Slice(elections, sortFunction)
@@ -3,6 +3,8 @@ package postgresrepo
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -13,7 +15,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/inklabs/vote/internal/ballotchain"
 	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/writein"
 )
 
 const instrumentationName = "github.com/inklabs/vote/internal/electionrepository/postgres"
@@ -50,31 +54,95 @@ func (r *postgresRepository) SaveElection(ctx context.Context, election election
 						OrganizerUserID,
 						Name,
 						Description,
+						SeatCount,
+						VotingMethod,
+						TieBreakPolicy,
+						RevotePolicy,
+						EligibilityPolicy,
+						BallotSecrecy,
+						MaxRanks,
+						RequireCompleteRanking,
+						AllowDuplicateRanks,
+						AllowSkippedRanks,
+						AllowWriteIns,
+						ModerateProposals,
+						ProposalDeadline,
+						VotingStartsAt,
+						VotingEndsAt,
 						WinningProposalID,
+						WinningProposalIDs,
+						Outcome,
 						IsClosed,
 						CommencedAt,
 						ClosedAt,
-						SelectedAt
-                     ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+						SelectedAt,
+						IsCancelled,
+						CancelledAt,
+						CancellationReason,
+						ReceiptRoot,
+						BallotChainHead,
+						BallotCount,
+						TieBreakSeed,
+						TabulationRounds,
+						Version
+                     ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35)
                      ON CONFLICT (ElectionID)
 					 DO UPDATE SET
 					     Name = EXCLUDED.Name,
 					     Description = EXCLUDED.Description,
 					     WinningProposalID = EXCLUDED.WinningProposalID,
+					     WinningProposalIDs = EXCLUDED.WinningProposalIDs,
+					     Outcome = EXCLUDED.Outcome,
 					     IsClosed = EXCLUDED.IsClosed,
 					     ClosedAt = EXCLUDED.ClosedAt,
-					     SelectedAt = EXCLUDED.SelectedAt`
+					     SelectedAt = EXCLUDED.SelectedAt,
+					     IsCancelled = EXCLUDED.IsCancelled,
+					     CancelledAt = EXCLUDED.CancelledAt,
+					     CancellationReason = EXCLUDED.CancellationReason,
+					     VotingEndsAt = EXCLUDED.VotingEndsAt,
+					     ReceiptRoot = EXCLUDED.ReceiptRoot,
+					     BallotChainHead = EXCLUDED.BallotChainHead,
+					     BallotCount = EXCLUDED.BallotCount,
+					     TieBreakSeed = EXCLUDED.TieBreakSeed,
+					     TabulationRounds = EXCLUDED.TabulationRounds,
+					     Version = EXCLUDED.Version`
 
 	_, err := r.db.ExecContext(ctx, sqlStatement,
 		election.ElectionID,
 		election.OrganizerUserID,
 		election.Name,
 		election.Description,
+		election.SeatCount,
+		election.VotingMethod,
+		election.TieBreakPolicy,
+		election.RevotePolicy,
+		election.EligibilityPolicy,
+		election.BallotSecrecy,
+		election.MaxRanks,
+		election.RequireCompleteRanking,
+		election.AllowDuplicateRanks,
+		election.AllowSkippedRanks,
+		election.AllowWriteIns,
+		election.ModerateProposals,
+		election.ProposalDeadline,
+		election.VotingStartsAt,
+		election.VotingEndsAt,
 		election.WinningProposalID,
+		pq.Array(election.WinningProposalIDs),
+		election.Outcome,
 		election.IsClosed,
 		election.CommencedAt,
 		election.ClosedAt,
 		election.SelectedAt,
+		election.IsCancelled,
+		election.CancelledAt,
+		election.CancellationReason,
+		election.ReceiptRoot,
+		election.BallotChainHead,
+		election.BallotCount,
+		election.TieBreakSeed,
+		tabulationRounds(election.TabulationRounds),
+		election.Version,
 	)
 	if err != nil {
 		recordSpanError(span, err)
@@ -93,11 +161,37 @@ func (r *postgresRepository) GetElection(ctx context.Context, electionID string)
 						OrganizerUserID,
 						Name,
 						Description,
+						SeatCount,
+						VotingMethod,
+						TieBreakPolicy,
+						RevotePolicy,
+						EligibilityPolicy,
+						BallotSecrecy,
+						MaxRanks,
+						RequireCompleteRanking,
+						AllowDuplicateRanks,
+						AllowSkippedRanks,
+						AllowWriteIns,
+						ModerateProposals,
+						ProposalDeadline,
+						VotingStartsAt,
+						VotingEndsAt,
 						WinningProposalID,
+						WinningProposalIDs,
+						Outcome,
 						IsClosed,
 						CommencedAt,
 						ClosedAt,
-						SelectedAt
+						SelectedAt,
+						IsCancelled,
+						CancelledAt,
+						CancellationReason,
+						ReceiptRoot,
+						BallotChainHead,
+						BallotCount,
+						TieBreakSeed,
+						TabulationRounds,
+						Version
                      FROM election
                      WHERE ElectionID = $1`
 
@@ -115,11 +209,37 @@ func (r *postgresRepository) GetElection(ctx context.Context, electionID string)
 		&election.OrganizerUserID,
 		&election.Name,
 		&election.Description,
+		&election.SeatCount,
+		&election.VotingMethod,
+		&election.TieBreakPolicy,
+		&election.RevotePolicy,
+		&election.EligibilityPolicy,
+		&election.BallotSecrecy,
+		&election.MaxRanks,
+		&election.RequireCompleteRanking,
+		&election.AllowDuplicateRanks,
+		&election.AllowSkippedRanks,
+		&election.AllowWriteIns,
+		&election.ModerateProposals,
+		&election.ProposalDeadline,
+		&election.VotingStartsAt,
+		&election.VotingEndsAt,
 		&election.WinningProposalID,
+		pq.Array(&election.WinningProposalIDs),
+		&election.Outcome,
 		&election.IsClosed,
 		&election.CommencedAt,
 		&election.ClosedAt,
 		&election.SelectedAt,
+		&election.IsCancelled,
+		&election.CancelledAt,
+		&election.CancellationReason,
+		&election.ReceiptRoot,
+		&election.BallotChainHead,
+		&election.BallotCount,
+		&election.TieBreakSeed,
+		(*tabulationRounds)(&election.TabulationRounds),
+		&election.Version,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -138,28 +258,62 @@ func (r *postgresRepository) SaveProposal(ctx context.Context, proposal election
 	_, span := tracer.Start(ctx, "db.save-election")
 	defer span.End()
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	err = r.lockOpenElection(ctx, tx, proposal.ElectionID)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
+	}
+
 	sqlStatement := `INSERT INTO proposal (
                       	ProposalID,
 						ElectionID,
 						OwnerUserID,
 						Name,
 						Description,
-						ProposedAt
-                     ) VALUES ($1, $2, $3, $4, $5, $6)`
+						ProposedAt,
+						UpdatedAt,
+						IsWithdrawn,
+						WithdrawnAt,
+						ModerationStatus,
+						RejectionReason,
+						ModeratedAt
+                     ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
-	_, err := r.db.ExecContext(ctx, sqlStatement,
+	_, err = tx.ExecContext(ctx, sqlStatement,
 		proposal.ProposalID,
 		proposal.ElectionID,
 		proposal.OwnerUserID,
 		proposal.Name,
 		proposal.Description,
 		proposal.ProposedAt,
+		proposal.UpdatedAt,
+		proposal.IsWithdrawn,
+		proposal.WithdrawnAt,
+		moderationStatus(proposal),
+		proposal.RejectionReason,
+		proposal.ModeratedAt,
 	)
 	if err != nil {
 		recordSpanError(span, err)
+		_ = tx.Rollback()
 		return fmt.Errorf("unable to save proposal: %w", err)
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -173,7 +327,13 @@ func (r *postgresRepository) GetProposal(ctx context.Context, proposalID string)
 						OwnerUserID,
 						Name,
 						Description,
-						ProposedAt
+						ProposedAt,
+						UpdatedAt,
+						IsWithdrawn,
+						WithdrawnAt,
+						ModerationStatus,
+						RejectionReason,
+						ModeratedAt
                      FROM proposal
                      WHERE ProposalID = $1`
 
@@ -193,6 +353,12 @@ func (r *postgresRepository) GetProposal(ctx context.Context, proposalID string)
 		&proposal.Name,
 		&proposal.Description,
 		&proposal.ProposedAt,
+		&proposal.UpdatedAt,
+		&proposal.IsWithdrawn,
+		&proposal.WithdrawnAt,
+		&proposal.ModerationStatus,
+		&proposal.RejectionReason,
+		&proposal.ModeratedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -206,8 +372,11 @@ func (r *postgresRepository) GetProposal(ctx context.Context, proposalID string)
 	return proposal, nil
 }
 
-func (r *postgresRepository) SaveVote(ctx context.Context, vote electionrepository.Vote) error {
-	_, span := tracer.Start(ctx, "db.save-vote")
+// UpdateProposal replaces a saved proposal. A proposal cannot be changed once
+// any ballot in its election ranks it. The ballot chain lock keeps a vote from
+// ranking the proposal while it is being changed.
+func (r *postgresRepository) UpdateProposal(ctx context.Context, proposal electionrepository.Proposal) error {
+	_, span := tracer.Start(ctx, "db.update-proposal")
 	defer span.End()
 
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -217,35 +386,7 @@ func (r *postgresRepository) SaveVote(ctx context.Context, vote electionreposito
 		return err
 	}
 
-	sqlStatement := `INSERT INTO vote (
-                      	VoteID,
-						ElectionID,
-						UserID,
-						SubmittedAt
-                     ) VALUES ($1, $2, $3, $4)`
-
-	_, err = tx.ExecContext(ctx, sqlStatement,
-		vote.VoteID,
-		vote.ElectionID,
-		vote.UserID,
-		vote.SubmittedAt,
-	)
-	if err != nil {
-		var pqError *pq.Error
-		if errors.As(err, &pqError) {
-			if pqError.Code == "23503" && pqError.Constraint == "vote_electionid_fkey" {
-				err = electionrepository.NewErrElectionNotFound(vote.ElectionID)
-				recordSpanError(span, err)
-				_ = tx.Rollback()
-				return err
-			}
-		}
-		recordSpanError(span, err)
-		_ = tx.Rollback()
-		return fmt.Errorf("unable to save vote: %w", err)
-	}
-
-	err = r.saveRankedProposals(ctx, tx, vote)
+	err = r.updateProposal(ctx, tx, proposal)
 	if err != nil {
 		recordSpanError(span, err)
 		_ = tx.Rollback()
@@ -262,227 +403,1626 @@ func (r *postgresRepository) SaveVote(ctx context.Context, vote electionreposito
 	return nil
 }
 
-func (r *postgresRepository) saveRankedProposals(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
-	var valueStrings []string
-	var valueArgs []interface{}
+func (r *postgresRepository) ValidateProposalUpdate(ctx context.Context, proposal electionrepository.Proposal) error {
+	_, span := tracer.Start(ctx, "db.validate-proposal-update")
+	defer span.End()
 
-	i := 0
-	for _, proposalID := range vote.RankedProposalIDs {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d)", i*4+1, i*4+2, i*4+3, i*4+4))
-		valueArgs = append(valueArgs,
-			vote.VoteID,
-			proposalID,
-			vote.ElectionID,
-			i,
-		)
-		i++
+	return r.validate(ctx, span, func(tx *sql.Tx) error {
+		return r.updateProposal(ctx, tx, proposal)
+	})
+}
+
+func (r *postgresRepository) updateProposal(ctx context.Context, tx *sql.Tx, proposal electionrepository.Proposal) error {
+	err := r.lockOpenElection(ctx, tx, proposal.ElectionID)
+	if err != nil {
+		return err
 	}
 
-	sqlStatement := fmt.Sprintf(
-		"INSERT INTO vote_ranked_proposal (VoteID, ProposalID, ElectionID, Position) VALUES %s",
-		strings.Join(valueStrings, ","))
+	err = r.lockBallotChain(ctx, tx, proposal.ElectionID)
+	if err != nil {
+		return err
+	}
 
-	_, err := tx.ExecContext(ctx, sqlStatement, valueArgs...)
+	var isRanked bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM vote_ranked_proposal WHERE ElectionID = $1 AND ProposalID = $2)`,
+		proposal.ElectionID,
+		proposal.ProposalID,
+	).Scan(&isRanked)
 	if err != nil {
-		var pqError *pq.Error
-		if errors.As(err, &pqError) {
-			if pqError.Code == "23503" && pqError.Constraint == "vote_ranked_proposal_proposalid_fkey" {
-				proposalID := uuidRegex.FindString(pqError.Detail)
-				err = electionrepository.NewErrProposalNotFound(proposalID)
-				return err
-			}
-			if pqError.Code == "23503" && pqError.Constraint == "vote_ranked_proposal_proposalid_electionid_fkey" {
-				uuids := uuidRegex.FindAllString(pqError.Detail, -1)
-				var proposalID, electionID string
-				if len(uuids) == 2 {
-					proposalID = uuids[0]
-					electionID = uuids[1]
-				}
-				err = electionrepository.NewErrInvalidElectionProposal(proposalID, electionID)
-				return err
-			}
-		}
-		return fmt.Errorf("unable to save ranked proposals: %w", err)
+		return fmt.Errorf("unable to check ranked proposal: %w", err)
+	}
+
+	if isRanked {
+		return electionrepository.NewErrProposalHasVotes(proposal.ProposalID)
+	}
+
+	sqlStatement := `UPDATE proposal SET
+						Name = $3,
+						Description = $4,
+						UpdatedAt = $5,
+						IsWithdrawn = $6,
+						WithdrawnAt = $7,
+						ModerationStatus = $8,
+						RejectionReason = $9,
+						ModeratedAt = $10
+                     WHERE ProposalID = $1 AND ElectionID = $2`
+
+	result, err := tx.ExecContext(ctx, sqlStatement,
+		proposal.ProposalID,
+		proposal.ElectionID,
+		proposal.Name,
+		proposal.Description,
+		proposal.UpdatedAt,
+		proposal.IsWithdrawn,
+		proposal.WithdrawnAt,
+		moderationStatus(proposal),
+		proposal.RejectionReason,
+		proposal.ModeratedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to update proposal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to update proposal: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return electionrepository.NewErrProposalNotFound(proposal.ProposalID)
 	}
 
 	return nil
 }
 
-func (r *postgresRepository) GetVotes(ctx context.Context, electionID string) ([]electionrepository.Vote, error) {
-	_, span := tracer.Start(ctx, "db.get-votes")
+func (r *postgresRepository) SaveVote(ctx context.Context, vote electionrepository.Vote) error {
+	_, span := tracer.Start(ctx, "db.save-vote")
 	defer span.End()
 
-	sqlStatement := `SELECT
-						v.VoteID,
-						v.ElectionID,
-						v.UserID,
-						ARRAY_REMOVE(ARRAY_AGG(vrp.ProposalID ORDER BY vrp.Position), NULL),
-						v.SubmittedAt
-                     FROM vote AS v
-                     LEFT JOIN vote_ranked_proposal AS vrp ON vrp.VoteID = v.VoteID
-                     WHERE v.ElectionID = $1
-                     GROUP BY v.VoteID`
-
-	rows, err := r.db.QueryContext(ctx, sqlStatement, electionID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		err = fmt.Errorf("unable to get votes: %w", err)
+		err = fmt.Errorf("unable to create transaction: %w", err)
 		recordSpanError(span, err)
-		return nil, err
+		return err
 	}
 
-	var votes []electionrepository.Vote
-
-	for rows.Next() {
-		var vote electionrepository.Vote
-
-		err = rows.Scan(
-			&vote.VoteID,
-			&vote.ElectionID,
-			&vote.UserID,
-			pq.Array(&vote.RankedProposalIDs),
-			&vote.SubmittedAt,
-		)
-		if err != nil {
-			err = fmt.Errorf("unable to get vote data: %w", err)
-			recordSpanError(span, err)
-			return nil, err
-		}
-
-		votes = append(votes, vote)
+	err = r.saveVote(ctx, tx, vote)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
 	}
 
-	if rows.Err() != nil {
-		err = fmt.Errorf("unable to get votes: %w", rows.Err())
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
 		recordSpanError(span, err)
-		return nil, err
+		return err
 	}
 
-	return votes, nil
+	return nil
 }
 
-func (r *postgresRepository) ListOpenElections(ctx context.Context, page, itemsPerPage int, sortBy, sortDirection *string) (int, []electionrepository.Election, error) {
-	_, span := tracer.Start(ctx, "db.list-open-elections")
+// SaveVotes saves all votes in a single transaction. No votes are saved if any
+// vote is invalid.
+func (r *postgresRepository) SaveVotes(ctx context.Context, votes []electionrepository.Vote) error {
+	_, span := tracer.Start(ctx, "db.save-votes")
 	defer span.End()
 
-	orderBy := getOrderBy(sortBy, sortDirection, "CommencedAt", "ASC")
-	limit, offset := getLimitOffset(page, itemsPerPage)
-
-	sqlStatement := `SELECT
-						ElectionID,
-						OrganizerUserID,
-						Name,
-						Description,
-						WinningProposalID,
-						IsClosed,
-						CommencedAt,
-						ClosedAt,
-						SelectedAt,
-						count(*) OVER()
-                     FROM election
-					 WHERE IsClosed = FALSE
-                     ` + orderBy + `
-                     LIMIT $1 OFFSET $2`
-
-	rows, err := r.db.QueryContext(ctx, sqlStatement, limit, offset)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		err = fmt.Errorf("unable to list open elections: %w", err)
+		err = fmt.Errorf("unable to create transaction: %w", err)
 		recordSpanError(span, err)
-		return 0, nil, err
+		return err
 	}
 
-	var elections []electionrepository.Election
-	var totalResults int
-
-	for rows.Next() {
-		var election electionrepository.Election
-
-		err = rows.Scan(
-			&election.ElectionID,
-			&election.OrganizerUserID,
-			&election.Name,
-			&election.Description,
-			&election.WinningProposalID,
-			&election.IsClosed,
-			&election.CommencedAt,
-			&election.ClosedAt,
-			&election.SelectedAt,
-			&totalResults,
-		)
+	for _, vote := range votes {
+		err = r.saveVote(ctx, tx, vote)
 		if err != nil {
-			err = fmt.Errorf("unable to get election data: %w", err)
 			recordSpanError(span, err)
-			return 0, nil, err
+			_ = tx.Rollback()
+			return err
 		}
-
-		elections = append(elections, election)
 	}
 
-	if rows.Err() != nil {
-		err = fmt.Errorf("unable to get elections: %w", rows.Err())
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
 		recordSpanError(span, err)
-		return 0, nil, err
+		return err
 	}
 
-	return totalResults, elections, nil
+	return nil
 }
 
-func (r *postgresRepository) ListProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
-	_, span := tracer.Start(ctx, "db.list-proposals")
+func (r *postgresRepository) ValidateVotes(ctx context.Context, votes []electionrepository.Vote) error {
+	_, span := tracer.Start(ctx, "db.validate-votes")
 	defer span.End()
 
-	limit, offset := getLimitOffset(page, itemsPerPage)
+	return r.validate(ctx, span, func(tx *sql.Tx) error {
+		for _, vote := range votes {
+			err := r.saveVote(ctx, tx, vote)
+			if err != nil {
+				return err
+			}
+		}
 
-	sqlStatement := `SELECT
-						ProposalID,
-						ElectionID,
-						OwnerUserID,
-						Name,
-						Description,
-						ProposedAt,
-						count(*) OVER()
-                     FROM proposal
-                     WHERE electionID = $1
-                     ORDER BY ProposedAt ASC
-                     LIMIT $2 OFFSET $3`
+		return nil
+	})
+}
+
+func (r *postgresRepository) GetUserVoteID(ctx context.Context, electionID, userID string) (string, error) {
+	_, span := tracer.Start(ctx, "db.get-user-vote-id")
+	defer span.End()
 
-	rows, err := r.db.QueryContext(ctx, sqlStatement, electionID, limit, offset)
+	var voteID string
+	err := r.validate(ctx, span, func(tx *sql.Tx) error {
+		var err error
+		voteID, err = r.getUserVoteID(ctx, tx, electionID, userID)
+		return err
+	})
 	if err != nil {
-		err = fmt.Errorf("unable to list proposals: %w", err)
-		recordSpanError(span, err)
-		return 0, nil, err
+		return "", err
 	}
 
-	var proposals []electionrepository.Proposal
-	var totalResults int
+	return voteID, nil
+}
 
-	for rows.Next() {
-		var proposal electionrepository.Proposal
+// validate runs write in a transaction that is always rolled back, making the
+// same checks as the write without saving anything.
+func (r *postgresRepository) validate(ctx context.Context, span trace.Span, write func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
 
-		err = rows.Scan(
-			&proposal.ProposalID,
-			&proposal.ElectionID,
+	err = write(tx)
+	_ = tx.Rollback()
+	if err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// lockOpenElection holds a shared lock on the election row until the transaction
+// ends, so the election cannot be closed while a proposal or vote is being saved.
+func (r *postgresRepository) lockOpenElection(ctx context.Context, tx *sql.Tx, electionID string) error {
+	sqlStatement := `SELECT IsClosed
+                     FROM election
+                     WHERE ElectionID = $1
+                     FOR SHARE`
+
+	var isClosed bool
+	err := tx.QueryRowContext(ctx, sqlStatement, electionID).Scan(&isClosed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return electionrepository.NewErrElectionNotFound(electionID)
+		}
+		return fmt.Errorf("unable to lock election: %w", err)
+	}
+
+	if isClosed {
+		return electionrepository.NewErrElectionClosed(electionID)
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) saveVote(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
+	err := r.lockOpenElection(ctx, tx, vote.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateEligibleVoter(ctx, tx, vote)
+	if err != nil {
+		return err
+	}
+
+	err = r.lockBallotChain(ctx, tx, vote.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateRankedProposals(ctx, tx, vote)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateSupersededVote(ctx, tx, vote)
+	if err != nil {
+		return err
+	}
+
+	previousVoteHash, err := r.getLastVoteHash(ctx, tx, vote.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	vote = ballotchain.Link(vote, previousVoteHash)
+
+	sqlStatement := `INSERT INTO vote (
+                      	VoteID,
+						ElectionID,
+						UserID,
+						SubmittedAt,
+						SupersedesVoteID,
+						PreviousVoteHash,
+						VoteHash,
+						IsImported
+                     ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = tx.ExecContext(ctx, sqlStatement,
+		vote.VoteID,
+		vote.ElectionID,
+		vote.UserID,
+		vote.SubmittedAt,
+		vote.SupersedesVoteID,
+		vote.PreviousVoteHash,
+		vote.VoteHash,
+		vote.IsImported,
+	)
+	if err != nil {
+		var pqError *pq.Error
+		if errors.As(err, &pqError) {
+			if pqError.Code == "23503" && pqError.Constraint == "vote_electionid_fkey" {
+				return electionrepository.NewErrElectionNotFound(vote.ElectionID)
+			}
+			if pqError.Code == "23505" && (pqError.Constraint == "idx_vote_election_user_ballot" || pqError.Constraint == "idx_vote_supersedes_vote_id") {
+				return electionrepository.NewErrDuplicateVote(vote.ElectionID, vote.UserID)
+			}
+		}
+		return fmt.Errorf("unable to save vote: %w", err)
+	}
+
+	return r.saveRankedProposals(ctx, tx, vote)
+}
+
+// validateEligibleVoter rejects ballots from users who are not on the eligibility
+// roll of a RegisteredVoters election. Imported ballots are not checked.
+func (r *postgresRepository) validateEligibleVoter(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
+	if vote.IsImported {
+		return nil
+	}
+
+	sqlStatement := `SELECT e.EligibilityPolicy <> $3
+						OR EXISTS (
+							SELECT 1
+							FROM eligible_voter AS ev
+							WHERE ev.ElectionID = e.ElectionID AND ev.UserID = $2
+						)
+                     FROM election AS e
+                     WHERE e.ElectionID = $1`
+
+	var isEligible bool
+	err := tx.QueryRowContext(ctx, sqlStatement,
+		vote.ElectionID,
+		vote.UserID,
+		electionrepository.EligibilityPolicyRegisteredVoters,
+	).Scan(&isEligible)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return electionrepository.NewErrElectionNotFound(vote.ElectionID)
+		}
+		return fmt.Errorf("unable to check eligibility: %w", err)
+	}
+
+	if !isEligible {
+		return electionrepository.NewErrVoterNotEligible(vote.ElectionID, vote.UserID)
+	}
+
+	return nil
+}
+
+// validateRankedProposals rejects ballots that rank a withdrawn proposal, or one
+// the organizer has not approved. Unknown proposals are reported when the ranked
+// proposals are saved.
+func (r *postgresRepository) validateRankedProposals(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
+	sqlStatement := `SELECT ProposalID, IsWithdrawn
+                     FROM proposal
+                     WHERE ProposalID = ANY($1) AND (IsWithdrawn = TRUE OR ModerationStatus <> $2)
+                     LIMIT 1`
+
+	var proposalID string
+	var isWithdrawn bool
+	err := tx.QueryRowContext(ctx, sqlStatement,
+		pq.Array(vote.RankedProposalIDs),
+		electionrepository.ModerationStatusApproved,
+	).Scan(&proposalID, &isWithdrawn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("unable to check ranked proposals: %w", err)
+	}
+
+	if isWithdrawn {
+		return electionrepository.NewErrProposalWithdrawn(proposalID)
+	}
+
+	return electionrepository.NewErrProposalNotApproved(proposalID)
+}
+
+// moderationStatus returns the proposal's ModerationStatus, defaulting to
+// approved like the column.
+func moderationStatus(proposal electionrepository.Proposal) string {
+	if proposal.ModerationStatus == "" {
+		return electionrepository.ModerationStatusApproved
+	}
+
+	return proposal.ModerationStatus
+}
+
+// lockBallotChain serializes appends to the election's ballot chain until the
+// transaction ends, so two votes cannot link to the same previous vote.
+func (r *postgresRepository) lockBallotChain(ctx context.Context, tx *sql.Tx, electionID string) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "ballot-chain:"+electionID)
+	if err != nil {
+		return fmt.Errorf("unable to lock ballot chain: %w", err)
+	}
+
+	return nil
+}
+
+// getLastVoteHash returns the VoteHash at the end of the election's ballot chain,
+// or an empty string if no votes have been saved.
+func (r *postgresRepository) getLastVoteHash(ctx context.Context, tx *sql.Tx, electionID string) (string, error) {
+	sqlStatement := `SELECT VoteHash
+                     FROM vote
+                     WHERE ElectionID = $1
+                     ORDER BY ChainSequence DESC
+                     LIMIT 1`
+
+	var voteHash string
+	err := tx.QueryRowContext(ctx, sqlStatement, electionID).Scan(&voteHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to get last vote hash: %w", err)
+	}
+
+	return voteHash, nil
+}
+
+// getUserVoteID returns the user's latest vote, the one no other vote supersedes,
+// or an empty VoteID if the user has not voted. Imported votes are never matched.
+func (r *postgresRepository) getUserVoteID(ctx context.Context, tx *sql.Tx, electionID, userID string) (string, error) {
+	sqlStatement := `SELECT v.VoteID
+                     FROM vote AS v
+                     WHERE v.ElectionID = $1
+                       AND v.UserID = $2
+                       AND NOT v.IsImported
+                       AND NOT EXISTS (
+                         SELECT 1
+                         FROM vote AS s
+                         WHERE s.SupersedesVoteID = v.VoteID
+                       )`
+
+	var voteID string
+	err := tx.QueryRowContext(ctx, sqlStatement, electionID, userID).Scan(&voteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to get user vote: %w", err)
+	}
+
+	return voteID, nil
+}
+
+// validateSupersededVote rejects a vote that supersedes a ballot other than one
+// of the same user's in the same election, and an imported vote that supersedes
+// any ballot. The unique indexes on first votes and on SupersedesVoteID reject a
+// second vote that does not supersede the user's latest vote.
+func (r *postgresRepository) validateSupersededVote(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
+	if vote.SupersedesVoteID == "" {
+		return nil
+	}
+
+	if vote.IsImported {
+		return electionrepository.NewErrDuplicateVote(vote.ElectionID, vote.UserID)
+	}
+
+	sqlStatement := `SELECT EXISTS (
+						SELECT 1
+						FROM vote
+						WHERE VoteID = $1 AND ElectionID = $2 AND UserID = $3 AND NOT IsImported
+					 )`
+
+	var isUserVote bool
+	err := tx.QueryRowContext(ctx, sqlStatement, vote.SupersedesVoteID, vote.ElectionID, vote.UserID).Scan(&isUserVote)
+	if err != nil {
+		return fmt.Errorf("unable to check superseded vote: %w", err)
+	}
+
+	if !isUserVote {
+		return electionrepository.NewErrDuplicateVote(vote.ElectionID, vote.UserID)
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) saveRankedProposals(ctx context.Context, tx *sql.Tx, vote electionrepository.Vote) error {
+	var valueStrings []string
+	var valueArgs []interface{}
+
+	i := 0
+	for _, proposalID := range vote.RankedProposalIDs {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+		valueArgs = append(valueArgs,
+			vote.VoteID,
+			rankedProposalID(proposalID),
+			vote.ElectionID,
+			i,
+			rankedWriteIn(proposalID),
+		)
+		i++
+	}
+
+	sqlStatement := fmt.Sprintf(
+		"INSERT INTO vote_ranked_proposal (VoteID, ProposalID, ElectionID, Position, WriteIn) VALUES %s",
+		strings.Join(valueStrings, ","))
+
+	_, err := tx.ExecContext(ctx, sqlStatement, valueArgs...)
+	if err != nil {
+		var pqError *pq.Error
+		if errors.As(err, &pqError) {
+			if pqError.Code == "23503" && pqError.Constraint == "vote_ranked_proposal_proposalid_fkey" {
+				proposalID := uuidRegex.FindString(pqError.Detail)
+				err = electionrepository.NewErrProposalNotFound(proposalID)
+				return err
+			}
+			if pqError.Code == "23503" && pqError.Constraint == "vote_ranked_proposal_proposalid_electionid_fkey" {
+				uuids := uuidRegex.FindAllString(pqError.Detail, -1)
+				var proposalID, electionID string
+				if len(uuids) == 2 {
+					proposalID = uuids[0]
+					electionID = uuids[1]
+				}
+				err = electionrepository.NewErrInvalidElectionProposal(proposalID, electionID)
+				return err
+			}
+		}
+		return fmt.Errorf("unable to save ranked proposals: %w", err)
+	}
+
+	return nil
+}
+
+// rankedProposalID stores a skipped rank, an empty ProposalID, or a write-in as
+// NULL so it is not checked against the proposal table.
+func rankedProposalID(proposalID string) sql.NullString {
+	return sql.NullString{
+		String: proposalID,
+		Valid:  proposalID != "" && !writein.Is(proposalID),
+	}
+}
+
+// rankedWriteIn stores a write-in in place of its ProposalID.
+func rankedWriteIn(proposalID string) string {
+	if writein.Is(proposalID) {
+		return proposalID
+	}
+
+	return ""
+}
+
+func (r *postgresRepository) GetVote(ctx context.Context, electionID, voteID string) (electionrepository.Vote, error) {
+	_, span := tracer.Start(ctx, "db.get-vote")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						v.VoteID,
+						v.ElectionID,
+						v.UserID,
+						COALESCE(ARRAY_AGG(COALESCE(vrp.ProposalID, vrp.WriteIn) ORDER BY vrp.Position) FILTER (WHERE vrp.VoteID IS NOT NULL), '{}'),
+						v.SubmittedAt,
+						v.SupersedesVoteID,
+						v.PreviousVoteHash,
+						v.VoteHash,
+						v.IsImported
+                     FROM vote AS v
+                     LEFT JOIN vote_ranked_proposal AS vrp ON vrp.VoteID = v.VoteID
+                     WHERE v.ElectionID = $1 AND v.VoteID = $2
+                     GROUP BY v.VoteID`
+
+	var vote electionrepository.Vote
+
+	err := r.db.QueryRowContext(ctx, sqlStatement, electionID, voteID).Scan(
+		&vote.VoteID,
+		&vote.ElectionID,
+		&vote.UserID,
+		pq.Array(&vote.RankedProposalIDs),
+		&vote.SubmittedAt,
+		&vote.SupersedesVoteID,
+		&vote.PreviousVoteHash,
+		&vote.VoteHash,
+		&vote.IsImported,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = electionrepository.NewErrVoteNotFound(voteID)
+			recordSpanError(span, err)
+			return electionrepository.Vote{}, err
+		}
+
+		err = fmt.Errorf("unable to get vote: %w", err)
+		recordSpanError(span, err)
+		return electionrepository.Vote{}, err
+	}
+
+	return vote, nil
+}
+
+func (r *postgresRepository) GetVotes(ctx context.Context, electionID string) ([]electionrepository.Vote, error) {
+	_, span := tracer.Start(ctx, "db.get-votes")
+	defer span.End()
+
+	votes, err := getVotes(ctx, r.db, electionID)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// getVotes returns the election's votes in ballot chain order.
+func getVotes(ctx context.Context, db querier, electionID string) ([]electionrepository.Vote, error) {
+	sqlStatement := `SELECT
+						v.VoteID,
+						v.ElectionID,
+						v.UserID,
+						COALESCE(ARRAY_AGG(COALESCE(vrp.ProposalID, vrp.WriteIn) ORDER BY vrp.Position) FILTER (WHERE vrp.VoteID IS NOT NULL), '{}'),
+						v.SubmittedAt,
+						v.SupersedesVoteID,
+						v.PreviousVoteHash,
+						v.VoteHash,
+						v.IsImported
+                     FROM vote AS v
+                     LEFT JOIN vote_ranked_proposal AS vrp ON vrp.VoteID = v.VoteID
+                     WHERE v.ElectionID = $1
+                     GROUP BY v.VoteID
+                     ORDER BY v.ChainSequence`
+
+	rows, err := db.QueryContext(ctx, sqlStatement, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get votes: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []electionrepository.Vote
+
+	for rows.Next() {
+		var vote electionrepository.Vote
+
+		err = rows.Scan(
+			&vote.VoteID,
+			&vote.ElectionID,
+			&vote.UserID,
+			pq.Array(&vote.RankedProposalIDs),
+			&vote.SubmittedAt,
+			&vote.SupersedesVoteID,
+			&vote.PreviousVoteHash,
+			&vote.VoteHash,
+			&vote.IsImported,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get vote data: %w", err)
+		}
+
+		votes = append(votes, vote)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("unable to get votes: %w", rows.Err())
+	}
+
+	return votes, nil
+}
+
+// SaveSecretBallot stores a secret ballot apart from the votes, without a
+// submission time or a sequence number, so the order of the rows does not tell
+// when each ballot was cast.
+func (r *postgresRepository) SaveSecretBallot(ctx context.Context, secretBallot electionrepository.SecretBallot) error {
+	_, span := tracer.Start(ctx, "db.save-secret-ballot")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	err = r.saveSecretBallot(ctx, tx, secretBallot)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) ValidateSecretBallot(ctx context.Context, secretBallot electionrepository.SecretBallot) error {
+	_, span := tracer.Start(ctx, "db.validate-secret-ballot")
+	defer span.End()
+
+	return r.validate(ctx, span, func(tx *sql.Tx) error {
+		return r.saveSecretBallot(ctx, tx, secretBallot)
+	})
+}
+
+func (r *postgresRepository) saveSecretBallot(ctx context.Context, tx *sql.Tx, secretBallot electionrepository.SecretBallot) error {
+	err := r.lockOpenElection(ctx, tx, secretBallot.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateRankedProposals(ctx, tx, electionrepository.Vote{
+		ElectionID:        secretBallot.ElectionID,
+		RankedProposalIDs: secretBallot.RankedProposalIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `INSERT INTO secret_ballot (
+						VoteID,
+						ElectionID,
+						RankedProposalIDs,
+						BallotTokenHash
+                     ) VALUES ($1, $2, $3, $4)`
+
+	_, err = tx.ExecContext(ctx, sqlStatement,
+		secretBallot.VoteID,
+		secretBallot.ElectionID,
+		pq.Array(secretBallot.RankedProposalIDs),
+		secretBallot.BallotTokenHash,
+	)
+	if err != nil {
+		var pqError *pq.Error
+		if errors.As(err, &pqError) && pqError.Code == "23505" && pqError.Constraint == "unique_secret_ballot_token" {
+			return electionrepository.NewErrBallotTokenSpent(secretBallot.ElectionID)
+		}
+		return fmt.Errorf("unable to save secret ballot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) GetSecretBallots(ctx context.Context, electionID string) ([]electionrepository.SecretBallot, error) {
+	_, span := tracer.Start(ctx, "db.get-secret-ballots")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						VoteID,
+						ElectionID,
+						RankedProposalIDs,
+						BallotTokenHash
+                     FROM secret_ballot
+                     WHERE ElectionID = $1
+                     ORDER BY BallotTokenHash`
+
+	rows, err := r.db.QueryContext(ctx, sqlStatement, electionID)
+	if err != nil {
+		err = fmt.Errorf("unable to get secret ballots: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var secretBallots []electionrepository.SecretBallot
+
+	for rows.Next() {
+		var secretBallot electionrepository.SecretBallot
+
+		err = rows.Scan(
+			&secretBallot.VoteID,
+			&secretBallot.ElectionID,
+			pq.Array(&secretBallot.RankedProposalIDs),
+			&secretBallot.BallotTokenHash,
+		)
+		if err != nil {
+			err = fmt.Errorf("unable to get secret ballot data: %w", err)
+			recordSpanError(span, err)
+			return nil, err
+		}
+
+		secretBallots = append(secretBallots, secretBallot)
+	}
+
+	if rows.Err() != nil {
+		err = fmt.Errorf("unable to get secret ballots: %w", rows.Err())
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return secretBallots, nil
+}
+
+func (r *postgresRepository) ListOpenElections(ctx context.Context, page, itemsPerPage int, sortBy, sortDirection *string) (int, []electionrepository.Election, error) {
+	_, span := tracer.Start(ctx, "db.list-open-elections")
+	defer span.End()
+
+	orderBy := getOrderBy(sortBy, sortDirection, "CommencedAt", "ASC")
+	limit, offset := getLimitOffset(page, itemsPerPage)
+
+	sqlStatement := `SELECT
+						ElectionID,
+						OrganizerUserID,
+						Name,
+						Description,
+						SeatCount,
+						VotingMethod,
+						TieBreakPolicy,
+						RevotePolicy,
+						EligibilityPolicy,
+						BallotSecrecy,
+						MaxRanks,
+						RequireCompleteRanking,
+						AllowDuplicateRanks,
+						AllowSkippedRanks,
+						AllowWriteIns,
+						ModerateProposals,
+						ProposalDeadline,
+						VotingStartsAt,
+						VotingEndsAt,
+						WinningProposalID,
+						WinningProposalIDs,
+						Outcome,
+						IsClosed,
+						CommencedAt,
+						ClosedAt,
+						SelectedAt,
+						IsCancelled,
+						CancelledAt,
+						CancellationReason,
+						ReceiptRoot,
+						BallotChainHead,
+						BallotCount,
+						TieBreakSeed,
+						TabulationRounds,
+						count(*) OVER()
+                     FROM election
+					 WHERE IsClosed = FALSE
+                     ` + orderBy + `
+                     LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.QueryContext(ctx, sqlStatement, limit, offset)
+	if err != nil {
+		err = fmt.Errorf("unable to list open elections: %w", err)
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	var elections []electionrepository.Election
+	var totalResults int
+
+	for rows.Next() {
+		var election electionrepository.Election
+
+		err = rows.Scan(
+			&election.ElectionID,
+			&election.OrganizerUserID,
+			&election.Name,
+			&election.Description,
+			&election.SeatCount,
+			&election.VotingMethod,
+			&election.TieBreakPolicy,
+			&election.RevotePolicy,
+			&election.EligibilityPolicy,
+			&election.BallotSecrecy,
+			&election.MaxRanks,
+			&election.RequireCompleteRanking,
+			&election.AllowDuplicateRanks,
+			&election.AllowSkippedRanks,
+			&election.AllowWriteIns,
+			&election.ModerateProposals,
+			&election.ProposalDeadline,
+			&election.VotingStartsAt,
+			&election.VotingEndsAt,
+			&election.WinningProposalID,
+			pq.Array(&election.WinningProposalIDs),
+			&election.Outcome,
+			&election.IsClosed,
+			&election.CommencedAt,
+			&election.ClosedAt,
+			&election.SelectedAt,
+			&election.IsCancelled,
+			&election.CancelledAt,
+			&election.CancellationReason,
+			&election.ReceiptRoot,
+			&election.BallotChainHead,
+			&election.BallotCount,
+			&election.TieBreakSeed,
+			(*tabulationRounds)(&election.TabulationRounds),
+			&totalResults,
+		)
+		if err != nil {
+			err = fmt.Errorf("unable to get election data: %w", err)
+			recordSpanError(span, err)
+			return 0, nil, err
+		}
+
+		elections = append(elections, election)
+	}
+
+	if rows.Err() != nil {
+		err = fmt.Errorf("unable to get elections: %w", rows.Err())
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	return totalResults, elections, nil
+}
+
+func (r *postgresRepository) ListElections(ctx context.Context, filter electionrepository.ElectionFilter, page, itemsPerPage int, sortBy, sortDirection *string) (int, []electionrepository.Election, error) {
+	_, span := tracer.Start(ctx, "db.list-elections")
+	defer span.End()
+
+	orderBy := getOrderBy(sortBy, sortDirection, "CommencedAt", "ASC")
+	limit, offset := getLimitOffset(page, itemsPerPage)
+	where, args := getElectionFilterWhere(filter)
+	args = append(args, limit, offset)
+
+	sqlStatement := `SELECT
+						ElectionID,
+						OrganizerUserID,
+						Name,
+						Description,
+						SeatCount,
+						VotingMethod,
+						TieBreakPolicy,
+						RevotePolicy,
+						EligibilityPolicy,
+						BallotSecrecy,
+						MaxRanks,
+						RequireCompleteRanking,
+						AllowDuplicateRanks,
+						AllowSkippedRanks,
+						AllowWriteIns,
+						ModerateProposals,
+						ProposalDeadline,
+						VotingStartsAt,
+						VotingEndsAt,
+						WinningProposalID,
+						WinningProposalIDs,
+						Outcome,
+						IsClosed,
+						CommencedAt,
+						ClosedAt,
+						SelectedAt,
+						IsCancelled,
+						CancelledAt,
+						CancellationReason,
+						ReceiptRoot,
+						BallotChainHead,
+						BallotCount,
+						TieBreakSeed,
+						TabulationRounds,
+						count(*) OVER()
+                     FROM election
+                     ` + where + `
+                     ` + orderBy + `
+                     LIMIT $` + fmt.Sprint(len(args)-1) + ` OFFSET $` + fmt.Sprint(len(args))
+
+	rows, err := r.db.QueryContext(ctx, sqlStatement, args...)
+	if err != nil {
+		err = fmt.Errorf("unable to list elections: %w", err)
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	var elections []electionrepository.Election
+	var totalResults int
+
+	for rows.Next() {
+		var election electionrepository.Election
+
+		err = rows.Scan(
+			&election.ElectionID,
+			&election.OrganizerUserID,
+			&election.Name,
+			&election.Description,
+			&election.SeatCount,
+			&election.VotingMethod,
+			&election.TieBreakPolicy,
+			&election.RevotePolicy,
+			&election.EligibilityPolicy,
+			&election.BallotSecrecy,
+			&election.MaxRanks,
+			&election.RequireCompleteRanking,
+			&election.AllowDuplicateRanks,
+			&election.AllowSkippedRanks,
+			&election.AllowWriteIns,
+			&election.ModerateProposals,
+			&election.ProposalDeadline,
+			&election.VotingStartsAt,
+			&election.VotingEndsAt,
+			&election.WinningProposalID,
+			pq.Array(&election.WinningProposalIDs),
+			&election.Outcome,
+			&election.IsClosed,
+			&election.CommencedAt,
+			&election.ClosedAt,
+			&election.SelectedAt,
+			&election.IsCancelled,
+			&election.CancelledAt,
+			&election.CancellationReason,
+			&election.ReceiptRoot,
+			&election.BallotChainHead,
+			&election.BallotCount,
+			&election.TieBreakSeed,
+			(*tabulationRounds)(&election.TabulationRounds),
+			&totalResults,
+		)
+		if err != nil {
+			err = fmt.Errorf("unable to get election data: %w", err)
+			recordSpanError(span, err)
+			return 0, nil, err
+		}
+
+		elections = append(elections, election)
+	}
+
+	if rows.Err() != nil {
+		err = fmt.Errorf("unable to get elections: %w", rows.Err())
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	return totalResults, elections, nil
+}
+
+// getElectionFilterWhere returns the WHERE clause for the filter, and its
+// arguments numbered from $1.
+func getElectionFilterWhere(filter electionrepository.ElectionFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	switch filter.Status {
+	case electionrepository.ElectionStatusOpen:
+		conditions = append(conditions, "IsClosed = FALSE")
+	case electionrepository.ElectionStatusClosed:
+		conditions = append(conditions, "IsClosed = TRUE AND IsCancelled = FALSE")
+	case electionrepository.ElectionStatusCancelled:
+		conditions = append(conditions, "IsCancelled = TRUE")
+	}
+
+	if filter.OrganizerUserID != "" {
+		addCondition("OrganizerUserID = $%d", filter.OrganizerUserID)
+	}
+
+	if filter.CommencedFrom > 0 {
+		addCondition("CommencedAt >= $%d", filter.CommencedFrom)
+	}
+
+	if filter.CommencedTo > 0 {
+		addCondition("CommencedAt <= $%d", filter.CommencedTo)
+	}
+
+	if filter.ClosedFrom > 0 || filter.ClosedTo > 0 {
+		conditions = append(conditions, "IsClosed = TRUE")
+	}
+
+	if filter.ClosedFrom > 0 {
+		addCondition("ClosedAt >= $%d", filter.ClosedFrom)
+	}
+
+	if filter.ClosedTo > 0 {
+		addCondition("ClosedAt <= $%d", filter.ClosedTo)
+	}
+
+	if filter.Name != "" {
+		addCondition("Name ILIKE $%d", "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// likeEscaper escapes the LIKE wildcards, so a name search matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *postgresRepository) ListElectionsToClose(ctx context.Context, votingEndedBy int) ([]electionrepository.Election, error) {
+	_, span := tracer.Start(ctx, "db.list-elections-to-close")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						ElectionID,
+						OrganizerUserID,
+						Name,
+						Description,
+						SeatCount,
+						VotingMethod,
+						TieBreakPolicy,
+						RevotePolicy,
+						EligibilityPolicy,
+						BallotSecrecy,
+						MaxRanks,
+						RequireCompleteRanking,
+						AllowDuplicateRanks,
+						AllowSkippedRanks,
+						AllowWriteIns,
+						ModerateProposals,
+						ProposalDeadline,
+						VotingStartsAt,
+						VotingEndsAt,
+						WinningProposalID,
+						WinningProposalIDs,
+						Outcome,
+						IsClosed,
+						CommencedAt,
+						ClosedAt,
+						SelectedAt,
+						IsCancelled,
+						CancelledAt,
+						CancellationReason,
+						ReceiptRoot,
+						BallotChainHead,
+						BallotCount,
+						TieBreakSeed,
+						TabulationRounds
+                     FROM election
+					 WHERE IsClosed = FALSE
+					   AND VotingEndsAt > 0
+					   AND VotingEndsAt <= $1
+                     ORDER BY VotingEndsAt ASC`
+
+	rows, err := r.db.QueryContext(ctx, sqlStatement, votingEndedBy)
+	if err != nil {
+		err = fmt.Errorf("unable to list elections to close: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	var elections []electionrepository.Election
+
+	for rows.Next() {
+		var election electionrepository.Election
+
+		err = rows.Scan(
+			&election.ElectionID,
+			&election.OrganizerUserID,
+			&election.Name,
+			&election.Description,
+			&election.SeatCount,
+			&election.VotingMethod,
+			&election.TieBreakPolicy,
+			&election.RevotePolicy,
+			&election.EligibilityPolicy,
+			&election.BallotSecrecy,
+			&election.MaxRanks,
+			&election.RequireCompleteRanking,
+			&election.AllowDuplicateRanks,
+			&election.AllowSkippedRanks,
+			&election.AllowWriteIns,
+			&election.ModerateProposals,
+			&election.ProposalDeadline,
+			&election.VotingStartsAt,
+			&election.VotingEndsAt,
+			&election.WinningProposalID,
+			pq.Array(&election.WinningProposalIDs),
+			&election.Outcome,
+			&election.IsClosed,
+			&election.CommencedAt,
+			&election.ClosedAt,
+			&election.SelectedAt,
+			&election.IsCancelled,
+			&election.CancelledAt,
+			&election.CancellationReason,
+			&election.ReceiptRoot,
+			&election.BallotChainHead,
+			&election.BallotCount,
+			&election.TieBreakSeed,
+			(*tabulationRounds)(&election.TabulationRounds),
+		)
+		if err != nil {
+			err = fmt.Errorf("unable to get election data: %w", err)
+			recordSpanError(span, err)
+			return nil, err
+		}
+
+		elections = append(elections, election)
+	}
+
+	if rows.Err() != nil {
+		err = fmt.Errorf("unable to get elections: %w", rows.Err())
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return elections, nil
+}
+
+func (r *postgresRepository) ListProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
+	_, span := tracer.Start(ctx, "db.list-proposals")
+	defer span.End()
+
+	totalResults, proposals, err := r.listProposals(ctx, electionID, electionrepository.ModerationStatusApproved, page, itemsPerPage)
+	if err != nil {
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	return totalResults, proposals, nil
+}
+
+// ListPendingProposals returns the proposals awaiting moderation, in the order
+// they were made.
+func (r *postgresRepository) ListPendingProposals(ctx context.Context, electionID string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
+	_, span := tracer.Start(ctx, "db.list-pending-proposals")
+	defer span.End()
+
+	totalResults, proposals, err := r.listProposals(ctx, electionID, electionrepository.ModerationStatusPending, page, itemsPerPage)
+	if err != nil {
+		recordSpanError(span, err)
+		return 0, nil, err
+	}
+
+	return totalResults, proposals, nil
+}
+
+// listProposals returns the proposals in an election with moderationStatus that
+// have not been withdrawn.
+func (r *postgresRepository) listProposals(ctx context.Context, electionID, moderationStatus string, page, itemsPerPage int) (int, []electionrepository.Proposal, error) {
+	limit, offset := getLimitOffset(page, itemsPerPage)
+
+	sqlStatement := `SELECT
+						ProposalID,
+						ElectionID,
+						OwnerUserID,
+						Name,
+						Description,
+						ProposedAt,
+						UpdatedAt,
+						IsWithdrawn,
+						WithdrawnAt,
+						ModerationStatus,
+						RejectionReason,
+						ModeratedAt,
+						count(*) OVER()
+                     FROM proposal
+                     WHERE electionID = $1 AND IsWithdrawn = FALSE AND ModerationStatus = $2
+                     ORDER BY ProposedAt ASC
+                     LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, sqlStatement, electionID, moderationStatus, limit, offset)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to list proposals: %w", err)
+	}
+
+	var proposals []electionrepository.Proposal
+	var totalResults int
+
+	for rows.Next() {
+		var proposal electionrepository.Proposal
+
+		err = rows.Scan(
+			&proposal.ProposalID,
+			&proposal.ElectionID,
 			&proposal.OwnerUserID,
 			&proposal.Name,
 			&proposal.Description,
 			&proposal.ProposedAt,
+			&proposal.UpdatedAt,
+			&proposal.IsWithdrawn,
+			&proposal.WithdrawnAt,
+			&proposal.ModerationStatus,
+			&proposal.RejectionReason,
+			&proposal.ModeratedAt,
 			&totalResults,
 		)
 		if err != nil {
-			err = fmt.Errorf("unable to get election data: %w", err)
+			return 0, nil, fmt.Errorf("unable to get election data: %w", err)
+		}
+
+		proposals = append(proposals, proposal)
+	}
+
+	if rows.Err() != nil {
+		return 0, nil, fmt.Errorf("unable to get proposals: %w", rows.Err())
+	}
+
+	return totalResults, proposals, nil
+}
+
+// SaveEligibleVoters adds users to eligibility rolls in a single transaction.
+// Users already on a roll keep their original RegisteredAt.
+func (r *postgresRepository) SaveEligibleVoters(ctx context.Context, eligibleVoters []electionrepository.EligibleVoter) error {
+	_, span := tracer.Start(ctx, "db.save-eligible-voters")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	lockedElectionIDs := make(map[string]struct{})
+
+	for _, eligibleVoter := range eligibleVoters {
+		if _, ok := lockedElectionIDs[eligibleVoter.ElectionID]; !ok {
+			err = r.lockOpenElection(ctx, tx, eligibleVoter.ElectionID)
+			if err != nil {
+				recordSpanError(span, err)
+				_ = tx.Rollback()
+				return err
+			}
+			lockedElectionIDs[eligibleVoter.ElectionID] = struct{}{}
+		}
+
+		sqlStatement := `INSERT INTO eligible_voter (
+							ElectionID,
+							UserID,
+							RegisteredAt
+						 ) VALUES ($1, $2, $3)
+						 ON CONFLICT (ElectionID, UserID) DO NOTHING`
+
+		_, err = tx.ExecContext(ctx, sqlStatement,
+			eligibleVoter.ElectionID,
+			eligibleVoter.UserID,
+			eligibleVoter.RegisteredAt,
+		)
+		if err != nil {
+			err = fmt.Errorf("unable to save eligible voter: %w", err)
 			recordSpanError(span, err)
-			return 0, nil, err
+			_ = tx.Rollback()
+			return err
 		}
+	}
 
-		proposals = append(proposals, proposal)
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
 	}
 
-	if rows.Err() != nil {
-		err = fmt.Errorf("unable to get proposals: %w", rows.Err())
+	return nil
+}
+
+func (r *postgresRepository) RemoveEligibleVoter(ctx context.Context, electionID, userID string) error {
+	_, span := tracer.Start(ctx, "db.remove-eligible-voter")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
 		recordSpanError(span, err)
-		return 0, nil, err
+		return err
 	}
 
-	return totalResults, proposals, nil
+	err = r.removeEligibleVoter(ctx, tx, electionID, userID)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) ValidateEligibleVoterRemoval(ctx context.Context, electionID, userID string) error {
+	_, span := tracer.Start(ctx, "db.validate-eligible-voter-removal")
+	defer span.End()
+
+	return r.validate(ctx, span, func(tx *sql.Tx) error {
+		return r.removeEligibleVoter(ctx, tx, electionID, userID)
+	})
+}
+
+func (r *postgresRepository) removeEligibleVoter(ctx context.Context, tx *sql.Tx, electionID, userID string) error {
+	err := r.lockOpenElection(ctx, tx, electionID)
+	if err != nil {
+		return err
+	}
+
+	var hasVoted bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM vote WHERE ElectionID = $1 AND UserID = $2)
+			OR EXISTS (SELECT 1 FROM ballot_token WHERE ElectionID = $1 AND UserID = $2)`,
+		electionID,
+		userID,
+	).Scan(&hasVoted)
+	if err != nil {
+		return fmt.Errorf("unable to check existing vote: %w", err)
+	}
+
+	if hasVoted {
+		return electionrepository.NewErrEligibleVoterHasVoted(electionID, userID)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM eligible_voter WHERE ElectionID = $1 AND UserID = $2`,
+		electionID,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to remove eligible voter: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to remove eligible voter: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return electionrepository.NewErrVoterNotEligible(electionID, userID)
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) GetTurnout(ctx context.Context, electionID string) (electionrepository.Turnout, error) {
+	_, span := tracer.Start(ctx, "db.get-turnout")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						(SELECT count(*) FROM eligible_voter WHERE ElectionID = e.ElectionID),
+						(SELECT count(*) FROM vote AS v WHERE v.ElectionID = e.ElectionID AND NOT EXISTS (
+							SELECT 1 FROM vote AS s WHERE s.SupersedesVoteID = v.VoteID
+						)) + (SELECT count(*) FROM secret_ballot WHERE ElectionID = e.ElectionID)
+                     FROM election AS e
+                     WHERE e.ElectionID = $1`
+
+	turnout := electionrepository.Turnout{
+		ElectionID: electionID,
+	}
+
+	err := r.db.QueryRowContext(ctx, sqlStatement, electionID).Scan(
+		&turnout.TotalEligible,
+		&turnout.TotalVoted,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return electionrepository.Turnout{}, electionrepository.NewErrElectionNotFound(electionID)
+		}
+
+		err = fmt.Errorf("unable to get turnout: %w", err)
+		recordSpanError(span, err)
+		return electionrepository.Turnout{}, err
+	}
+
+	return turnout, nil
+}
+
+func (r *postgresRepository) SaveBallotKey(ctx context.Context, electionID string, privateKey []byte) error {
+	_, span := tracer.Start(ctx, "db.save-ballot-key")
+	defer span.End()
+
+	sqlStatement := `INSERT INTO ballot_key (
+						ElectionID,
+						PrivateKey
+                     ) VALUES ($1, $2)
+                     ON CONFLICT (ElectionID)
+					 DO UPDATE SET PrivateKey = EXCLUDED.PrivateKey`
+
+	_, err := r.db.ExecContext(ctx, sqlStatement, electionID, privateKey)
+	if err != nil {
+		err = fmt.Errorf("unable to save ballot key: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) GetBallotKey(ctx context.Context, electionID string) ([]byte, error) {
+	_, span := tracer.Start(ctx, "db.get-ballot-key")
+	defer span.End()
+
+	sqlStatement := `SELECT PrivateKey
+                     FROM ballot_key
+                     WHERE ElectionID = $1`
+
+	var privateKey []byte
+	err := r.db.QueryRowContext(ctx, sqlStatement, electionID).Scan(&privateKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, electionrepository.NewErrBallotKeyNotFound(electionID)
+		}
+
+		err = fmt.Errorf("unable to get ballot key: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return privateKey, nil
+}
+
+func (r *postgresRepository) SaveBallotToken(ctx context.Context, ballotToken electionrepository.BallotToken) error {
+	_, span := tracer.Start(ctx, "db.save-ballot-token")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	err = r.saveBallotToken(ctx, tx, ballotToken)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) ValidateBallotToken(ctx context.Context, ballotToken electionrepository.BallotToken) error {
+	_, span := tracer.Start(ctx, "db.validate-ballot-token")
+	defer span.End()
+
+	return r.validate(ctx, span, func(tx *sql.Tx) error {
+		return r.saveBallotToken(ctx, tx, ballotToken)
+	})
+}
+
+func (r *postgresRepository) saveBallotToken(ctx context.Context, tx *sql.Tx, ballotToken electionrepository.BallotToken) error {
+	err := r.lockOpenElection(ctx, tx, ballotToken.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateEligibleVoter(ctx, tx, electionrepository.Vote{
+		ElectionID: ballotToken.ElectionID,
+		UserID:     ballotToken.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `INSERT INTO ballot_token (
+						ElectionID,
+						UserID,
+						BlindSignature,
+						IssuedAt
+                     ) VALUES ($1, $2, $3, $4)`
+
+	_, err = tx.ExecContext(ctx, sqlStatement,
+		ballotToken.ElectionID,
+		ballotToken.UserID,
+		ballotToken.BlindSignature,
+		ballotToken.IssuedAt,
+	)
+	if err != nil {
+		var pqError *pq.Error
+		if errors.As(err, &pqError) && pqError.Code == "23505" {
+			return electionrepository.NewErrBallotTokenAlreadyIssued(ballotToken.ElectionID, ballotToken.UserID)
+		}
+		return fmt.Errorf("unable to save ballot token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) GetBallotToken(ctx context.Context, electionID, userID string) (electionrepository.BallotToken, error) {
+	_, span := tracer.Start(ctx, "db.get-ballot-token")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						ElectionID,
+						UserID,
+						BlindSignature,
+						IssuedAt
+                     FROM ballot_token
+                     WHERE ElectionID = $1 AND UserID = $2`
+
+	var ballotToken electionrepository.BallotToken
+
+	err := r.db.QueryRowContext(ctx, sqlStatement, electionID, userID).Scan(
+		&ballotToken.ElectionID,
+		&ballotToken.UserID,
+		&ballotToken.BlindSignature,
+		&ballotToken.IssuedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ballotToken, electionrepository.NewErrBallotTokenNotFound(electionID, userID)
+		}
+
+		err = fmt.Errorf("unable to get ballot token: %w", err)
+		recordSpanError(span, err)
+		return ballotToken, err
+	}
+
+	return ballotToken, nil
+}
+
+// DeleteAll removes all projected data. Ballot keys are kept, as they are not
+// recorded in the event log and cannot be rebuilt from it.
+func (r *postgresRepository) DeleteElection(ctx context.Context, electionID string) error {
+	_, span := tracer.Start(ctx, "db.delete-election")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	// The ballot key is kept, as it is not recorded in the event log.
+	tables := []string{"ballot_token", "eligible_voter", "secret_ballot", "vote_ranked_proposal", "vote", "proposal", "election"}
+	for _, table := range tables {
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE ElectionID = $1`, electionID)
+		if err != nil {
+			_ = tx.Rollback()
+			err = fmt.Errorf("unable to delete election from %s: %w", table, err)
+			recordSpanError(span, err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) DeleteAll(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "db.delete-all")
+	defer span.End()
+
+	sqlStatement := `TRUNCATE TABLE ballot_token, eligible_voter, secret_ballot, vote_ranked_proposal, vote, proposal, election`
+
+	_, err := r.db.ExecContext(ctx, sqlStatement)
+	if err != nil {
+		err = fmt.Errorf("unable to delete all elections: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
 }
 
 func NewDB(config Config) (*sql.DB, error) {
@@ -506,11 +2046,37 @@ func (r *postgresRepository) InitDB(ctx context.Context) error {
 			OrganizerUserID TEXT,
             Name TEXT,
             Description TEXT,
+            SeatCount INT NOT NULL DEFAULT 1,
+            VotingMethod TEXT NOT NULL DEFAULT 'InstantRunoff',
+            TieBreakPolicy TEXT NOT NULL DEFAULT 'Borda',
+            RevotePolicy TEXT NOT NULL DEFAULT 'RejectDuplicate',
+            EligibilityPolicy TEXT NOT NULL DEFAULT 'Open',
+            BallotSecrecy TEXT NOT NULL DEFAULT 'Public',
+            MaxRanks INT NOT NULL DEFAULT 0,
+            RequireCompleteRanking BOOLEAN NOT NULL DEFAULT FALSE,
+            AllowDuplicateRanks BOOLEAN NOT NULL DEFAULT FALSE,
+            AllowSkippedRanks BOOLEAN NOT NULL DEFAULT FALSE,
+            AllowWriteIns BOOLEAN NOT NULL DEFAULT FALSE,
+            ModerateProposals BOOLEAN NOT NULL DEFAULT FALSE,
+            ProposalDeadline BIGINT NOT NULL DEFAULT 0,
+            VotingStartsAt BIGINT NOT NULL DEFAULT 0,
+            VotingEndsAt BIGINT NOT NULL DEFAULT 0,
             WinningProposalID TEXT,
+            WinningProposalIDs TEXT[],
+            Outcome TEXT NOT NULL DEFAULT '',
             IsClosed BOOLEAN,
             CommencedAt BIGINT,
             ClosedAt BIGINT,
-            SelectedAt BIGINT
+            SelectedAt BIGINT,
+            IsCancelled BOOLEAN NOT NULL DEFAULT FALSE,
+            CancelledAt BIGINT NOT NULL DEFAULT 0,
+            CancellationReason TEXT NOT NULL DEFAULT '',
+            ReceiptRoot TEXT NOT NULL DEFAULT '',
+            BallotChainHead TEXT NOT NULL DEFAULT '',
+            BallotCount INT NOT NULL DEFAULT 0,
+            TieBreakSeed BIGINT NOT NULL DEFAULT 0,
+            TabulationRounds JSONB,
+            Version INT NOT NULL DEFAULT 0
 		);`,
 		`CREATE TABLE IF NOT EXISTS proposal (
 			ProposalID TEXT PRIMARY KEY,
@@ -519,6 +2085,12 @@ func (r *postgresRepository) InitDB(ctx context.Context) error {
             Name TEXT,
             Description TEXT,
             ProposedAt BIGINT,
+            UpdatedAt BIGINT NOT NULL DEFAULT 0,
+            IsWithdrawn BOOLEAN NOT NULL DEFAULT FALSE,
+            WithdrawnAt BIGINT NOT NULL DEFAULT 0,
+            ModerationStatus TEXT NOT NULL DEFAULT 'Approved',
+            RejectionReason TEXT NOT NULL DEFAULT '',
+            ModeratedAt BIGINT NOT NULL DEFAULT 0,
     		CONSTRAINT unique_proposal_election UNIQUE (ProposalID, ElectionID)
 		);`,
 		`CREATE TABLE IF NOT EXISTS vote (
@@ -526,6 +2098,10 @@ func (r *postgresRepository) InitDB(ctx context.Context) error {
 			ElectionID TEXT REFERENCES election (ElectionID),
 			UserID TEXT,
     		SubmittedAt BIGINT,
+    		SupersedesVoteID TEXT NOT NULL DEFAULT '',
+    		PreviousVoteHash TEXT NOT NULL DEFAULT '',
+    		VoteHash TEXT NOT NULL DEFAULT '',
+    		ChainSequence BIGSERIAL,
     		CONSTRAINT unique_vote_election UNIQUE (VoteID, ElectionID)
 		);`,
 		`CREATE TABLE IF NOT EXISTS vote_ranked_proposal (
@@ -533,12 +2109,101 @@ func (r *postgresRepository) InitDB(ctx context.Context) error {
 			ProposalID TEXT REFERENCES proposal (ProposalID),
     		ElectionID TEXT REFERENCES election (ElectionID),
 			Position SMALLINT,
-    		PRIMARY KEY (VoteID, ProposalID),
+			WriteIn TEXT NOT NULL DEFAULT '',
+    		PRIMARY KEY (VoteID, Position),
 	        FOREIGN KEY (VoteID, ElectionID) REFERENCES vote (VoteID, ElectionID),
 		    FOREIGN KEY (ProposalID, ElectionID) REFERENCES proposal (ProposalID, ElectionID)
 		);`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS TabulationRounds JSONB;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS SeatCount INT NOT NULL DEFAULT 1;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS WinningProposalIDs TEXT[];`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS VotingMethod TEXT NOT NULL DEFAULT 'InstantRunoff';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS RevotePolicy TEXT NOT NULL DEFAULT 'RejectDuplicate';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS ProposalDeadline BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS VotingStartsAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS VotingEndsAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS EligibilityPolicy TEXT NOT NULL DEFAULT 'Open';`,
+		`CREATE TABLE IF NOT EXISTS eligible_voter (
+			ElectionID TEXT REFERENCES election (ElectionID),
+			UserID TEXT,
+			RegisteredAt BIGINT,
+			PRIMARY KEY (ElectionID, UserID)
+		);`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS BallotSecrecy TEXT NOT NULL DEFAULT 'Public';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS ReceiptRoot TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS BallotChainHead TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS BallotCount INT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE vote ADD COLUMN IF NOT EXISTS PreviousVoteHash TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE vote ADD COLUMN IF NOT EXISTS VoteHash TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE vote ADD COLUMN IF NOT EXISTS ChainSequence BIGSERIAL;`,
+		`ALTER TABLE vote ADD COLUMN IF NOT EXISTS SupersedesVoteID TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS TieBreakPolicy TEXT NOT NULL DEFAULT 'Borda';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS TieBreakSeed BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS MaxRanks INT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS RequireCompleteRanking BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS AllowDuplicateRanks BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS AllowSkippedRanks BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM pg_constraint
+				WHERE conname = 'vote_ranked_proposal_pkey'
+				AND pg_get_constraintdef(oid) = 'PRIMARY KEY (voteid, proposalid)'
+			) THEN
+				ALTER TABLE vote_ranked_proposal DROP CONSTRAINT vote_ranked_proposal_pkey;
+				ALTER TABLE vote_ranked_proposal ADD PRIMARY KEY (VoteID, Position);
+			END IF;
+		END $$;`,
+		`ALTER TABLE vote_ranked_proposal ALTER COLUMN ProposalID DROP NOT NULL;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS AllowWriteIns BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE vote_ranked_proposal ADD COLUMN IF NOT EXISTS WriteIn TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS UpdatedAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS IsWithdrawn BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS WithdrawnAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS ModerateProposals BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS ModerationStatus TEXT NOT NULL DEFAULT 'Approved';`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS RejectionReason TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE proposal ADD COLUMN IF NOT EXISTS ModeratedAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS IsCancelled BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS CancelledAt BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS CancellationReason TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS Outcome TEXT NOT NULL DEFAULT '';`,
+		`ALTER TABLE election ADD COLUMN IF NOT EXISTS Version INT NOT NULL DEFAULT 0;`,
+		`CREATE TABLE IF NOT EXISTS ballot_key (
+			ElectionID TEXT PRIMARY KEY,
+			PrivateKey BYTEA
+		);`,
+		`CREATE TABLE IF NOT EXISTS secret_ballot (
+			VoteID TEXT PRIMARY KEY,
+			ElectionID TEXT REFERENCES election (ElectionID),
+			RankedProposalIDs TEXT[] NOT NULL,
+			BallotTokenHash TEXT NOT NULL,
+			CONSTRAINT unique_secret_ballot_token UNIQUE (ElectionID, BallotTokenHash)
+		);`,
+		`CREATE TABLE IF NOT EXISTS ballot_token (
+			ElectionID TEXT REFERENCES election (ElectionID),
+			UserID TEXT,
+			BlindSignature BYTEA,
+			IssuedAt BIGINT,
+			PRIMARY KEY (ElectionID, UserID)
+		);`,
 		`CREATE INDEX IF NOT EXISTS idx_proposal_election_id ON proposal(ElectionID);`,
+		`ALTER TABLE vote ADD COLUMN IF NOT EXISTS IsImported BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`UPDATE vote SET IsImported = TRUE WHERE UserID = '';`,
 		`CREATE INDEX IF NOT EXISTS idx_vote_election_id ON vote(ElectionID);`,
+		`CREATE INDEX IF NOT EXISTS idx_vote_election_chain_sequence ON vote(ElectionID, ChainSequence);`,
+		`DROP INDEX IF EXISTS idx_vote_election_user;`,
+		`DROP INDEX IF EXISTS idx_vote_election_user_id;`,
+		`DROP INDEX IF EXISTS idx_vote_election_user_first;`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_vote_election_user_ballot ON vote(ElectionID, UserID) WHERE NOT IsImported AND SupersedesVoteID = '';`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_vote_supersedes_vote_id ON vote(SupersedesVoteID) WHERE SupersedesVoteID <> '';`,
+		`DROP INDEX IF EXISTS idx_vote_election_ballot_token_hash;`,
+		`ALTER TABLE vote DROP COLUMN IF EXISTS BallotTokenHash;`,
+		`CREATE INDEX IF NOT EXISTS idx_election_voting_ends_at ON election(VotingEndsAt) WHERE IsClosed = FALSE AND VotingEndsAt > 0;`,
+		`CREATE INDEX IF NOT EXISTS idx_election_organizer_user_id ON election(OrganizerUserID);`,
+		`CREATE INDEX IF NOT EXISTS idx_election_commenced_at ON election(CommencedAt);`,
+		`CREATE INDEX IF NOT EXISTS idx_election_closed_at ON election(ClosedAt) WHERE IsClosed = TRUE;`,
+		`CREATE INDEX IF NOT EXISTS idx_election_status ON election(IsClosed, IsCancelled);`,
 	}
 
 	for _, statement := range sqlStatements {
@@ -576,6 +2241,36 @@ func getOrderBy(sortBy, sortDirection *string, defaultSort, defaultDirection str
 	return fmt.Sprintf("ORDER BY %s %s", *sortBy, direction)
 }
 
+// tabulationRounds persists the round-by-round tabulation report as JSONB.
+type tabulationRounds []electionrepository.TabulationRound
+
+func (t tabulationRounds) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (t *tabulationRounds) Scan(src any) error {
+	if src == nil {
+		*t = nil
+		return nil
+	}
+
+	data, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected tabulation rounds type: %T", src)
+	}
+
+	return json.Unmarshal(data, t)
+}
+
 func recordSpanError(span trace.Span, err error) {
 	span.SetStatus(codes.Error, err.Error())
 	span.RecordError(err)
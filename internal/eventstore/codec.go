@@ -0,0 +1,86 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/event"
+)
+
+// Codec serializes events to JSON, keyed by the name of the event type.
+type Codec struct {
+	types map[string]reflect.Type
+}
+
+func NewCodec(events ...cqrs.Event) *Codec {
+	types := make(map[string]reflect.Type, len(events))
+	for _, e := range events {
+		types[EventType(e)] = reflect.TypeOf(e)
+	}
+
+	return &Codec{
+		types: types,
+	}
+}
+
+// NewElectionCodec returns a Codec for the events in the event package.
+func NewElectionCodec() *Codec {
+	return NewCodec(
+		event.ElectionHasCommenced{},
+		event.ProposalWasMade{},
+		event.ProposalWasUpdated{},
+		event.ProposalWasWithdrawn{},
+		event.ProposalWasApproved{},
+		event.ProposalWasRejected{},
+		event.VoteWasCast{},
+		event.VoteWasReplaced{},
+		event.SecretBallotWasCast{},
+		event.EligibleVoterWasRegistered{},
+		event.EligibleVoterWasRemoved{},
+		event.BallotTokenWasIssued{},
+		event.ElectionWasClosedByOwner{},
+		event.ElectionWasCancelled{},
+		event.ElectionWasReopened{},
+		event.ElectionWinnerWasSelected{},
+		event.ElectionClosedWithoutWinner{},
+	)
+}
+
+func (c *Codec) Encode(e cqrs.Event) (string, []byte, error) {
+	eventType := EventType(e)
+	if _, ok := c.types[eventType]; !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to encode event %s: %w", eventType, err)
+	}
+
+	return eventType, data, nil
+}
+
+func (c *Codec) Decode(eventType string, data []byte) (cqrs.Event, error) {
+	t, ok := c.types[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+
+	value := reflect.New(t)
+	err := json.Unmarshal(data, value.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode event %s: %w", eventType, err)
+	}
+
+	return value.Elem().Interface(), nil
+}
+
+// EventType returns the name of the event type, such as "VoteWasCast".
+func EventType(e cqrs.Event) string {
+	return reflect.TypeOf(e).Name()
+}
+
+var ErrUnknownEventType = fmt.Errorf("unknown event type")
@@ -0,0 +1,57 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inklabs/cqrs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AnyVersion appends events without checking the current version of the stream.
+const AnyVersion = -1
+
+// Record is a single event in the append-only log. Position orders all events
+// across streams, and Version orders events within a stream, starting at 1.
+type Record struct {
+	Position  int
+	StreamID  string
+	Version   int
+	EventType string
+	Event     cqrs.Event
+}
+
+// Store is an append-only log of events, grouped into streams by StreamID.
+// Lock holds a stream for a single writer until unlock is called, so a writer can
+// read its state, append events and project them without another writer of the
+// same stream interleaving. Appends do not take the lock themselves.
+type Store interface {
+	Append(ctx context.Context, streamID string, expectedVersion int, events ...cqrs.Event) error
+	Load(ctx context.Context, streamID string) ([]Record, error)
+	LoadAll(ctx context.Context, afterPosition, limit int) ([]Record, error)
+	Version(ctx context.Context, streamID string) (int, error)
+	Lock(ctx context.Context, streamID string) (unlock func(), err error)
+}
+
+type ErrVersionConflict struct {
+	streamID        string
+	expectedVersion int
+	actualVersion   int
+}
+
+func NewErrVersionConflict(streamID string, expectedVersion, actualVersion int) *ErrVersionConflict {
+	return &ErrVersionConflict{
+		streamID:        streamID,
+		expectedVersion: expectedVersion,
+		actualVersion:   actualVersion,
+	}
+}
+
+func (e ErrVersionConflict) Error() string {
+	return fmt.Sprintf("stream (%s) is at version %d, expected version %d", e.streamID, e.actualVersion, e.expectedVersion)
+}
+
+func (e ErrVersionConflict) GRPCStatus() *status.Status {
+	return status.New(codes.Aborted, e.Error())
+}
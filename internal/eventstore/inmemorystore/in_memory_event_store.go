@@ -0,0 +1,147 @@
+package inmemorystore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/inklabs/cqrs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+const instrumentationName = "github.com/inklabs/vote/internal/eventstore/in-memory"
+
+var tracer = otel.Tracer(instrumentationName)
+
+type inMemoryEventStore struct {
+	mux sync.RWMutex
+
+	records []eventstore.Record
+
+	// streams key by streamID, holding indexes into records
+	streams map[string][]int
+
+	locksMux sync.Mutex
+
+	// locks key by streamID, holding a buffered channel of size one
+	locks map[string]chan struct{}
+}
+
+func New() *inMemoryEventStore {
+	return &inMemoryEventStore{
+		streams: make(map[string][]int),
+		locks:   make(map[string]chan struct{}),
+	}
+}
+
+// IsInMemory reports whether store is an in-memory event store, whose events
+// do not outlive the process.
+func IsInMemory(store eventstore.Store) bool {
+	_, ok := store.(*inMemoryEventStore)
+	return ok
+}
+
+func (s *inMemoryEventStore) Append(ctx context.Context, streamID string, expectedVersion int, events ...cqrs.Event) error {
+	_, span := tracer.Start(ctx, "event-store.append")
+	defer span.End()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	version := len(s.streams[streamID])
+	if expectedVersion != eventstore.AnyVersion && expectedVersion != version {
+		err := eventstore.NewErrVersionConflict(streamID, expectedVersion, version)
+		recordSpanError(span, err)
+		return err
+	}
+
+	for _, e := range events {
+		version++
+		s.streams[streamID] = append(s.streams[streamID], len(s.records))
+		s.records = append(s.records, eventstore.Record{
+			Position:  len(s.records) + 1,
+			StreamID:  streamID,
+			Version:   version,
+			EventType: eventstore.EventType(e),
+			Event:     e,
+		})
+	}
+
+	return nil
+}
+
+func (s *inMemoryEventStore) Load(ctx context.Context, streamID string) ([]eventstore.Record, error) {
+	_, span := tracer.Start(ctx, "event-store.load")
+	defer span.End()
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	indexes := s.streams[streamID]
+	records := make([]eventstore.Record, len(indexes))
+	for i, index := range indexes {
+		records[i] = s.records[index]
+	}
+
+	return records, nil
+}
+
+func (s *inMemoryEventStore) LoadAll(ctx context.Context, afterPosition, limit int) ([]eventstore.Record, error) {
+	_, span := tracer.Start(ctx, "event-store.load-all")
+	defer span.End()
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if afterPosition >= len(s.records) {
+		return nil, nil
+	}
+
+	endPosition := min(afterPosition+limit, len(s.records))
+
+	return append([]eventstore.Record{}, s.records[afterPosition:endPosition]...), nil
+}
+
+func (s *inMemoryEventStore) Version(ctx context.Context, streamID string) (int, error) {
+	_, span := tracer.Start(ctx, "event-store.version")
+	defer span.End()
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return len(s.streams[streamID]), nil
+}
+
+func (s *inMemoryEventStore) Lock(ctx context.Context, streamID string) (func(), error) {
+	_, span := tracer.Start(ctx, "event-store.lock")
+	defer span.End()
+
+	s.locksMux.Lock()
+	lock, ok := s.locks[streamID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		s.locks[streamID] = lock
+	}
+	s.locksMux.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		err := ctx.Err()
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-lock })
+	}, nil
+}
+
+func recordSpanError(span trace.Span, err error) {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+}
@@ -0,0 +1,155 @@
+package inmemorystore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inklabs/cqrs/cqrstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/eventstore/inmemorystore"
+)
+
+func TestInMemoryEventStore(t *testing.T) {
+	const (
+		electionID1 = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+		electionID2 = "1b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e"
+	)
+
+	t.Run("appends and loads events by stream", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		commenced := event.ElectionHasCommenced{ElectionID: electionID1}
+		closed := event.ElectionWasClosedByOwner{ElectionID: electionID1, OccurredAt: 2}
+		require.NoError(t, store.Append(ctx, electionID1, 0, commenced))
+		require.NoError(t, store.Append(ctx, electionID2, 0, event.ElectionHasCommenced{ElectionID: electionID2}))
+
+		// When
+		err := store.Append(ctx, electionID1, 1, closed)
+
+		// Then
+		require.NoError(t, err)
+		records, err := store.Load(ctx, electionID1)
+		require.NoError(t, err)
+		assert.Equal(t, []eventstore.Record{
+			{
+				Position:  1,
+				StreamID:  electionID1,
+				Version:   1,
+				EventType: "ElectionHasCommenced",
+				Event:     commenced,
+			},
+			{
+				Position:  3,
+				StreamID:  electionID1,
+				Version:   2,
+				EventType: "ElectionWasClosedByOwner",
+				Event:     closed,
+			},
+		}, records)
+	})
+
+	t.Run("errors on version conflict", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		require.NoError(t, store.Append(ctx, electionID1, 0, event.ElectionHasCommenced{ElectionID: electionID1}))
+
+		// When
+		err := store.Append(ctx, electionID1, 0, event.ElectionWasClosedByOwner{ElectionID: electionID1})
+
+		// Then
+		require.Equal(t, eventstore.NewErrVersionConflict(electionID1, 0, 1), err)
+		records, err := store.Load(ctx, electionID1)
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("loads all events in pages", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		require.NoError(t, store.Append(ctx, electionID1, eventstore.AnyVersion,
+			event.ElectionHasCommenced{ElectionID: electionID1},
+			event.ElectionWasClosedByOwner{ElectionID: electionID1},
+		))
+		require.NoError(t, store.Append(ctx, electionID2, eventstore.AnyVersion,
+			event.ElectionHasCommenced{ElectionID: electionID2},
+		))
+
+		// When
+		page1, err1 := store.LoadAll(ctx, 0, 2)
+		page2, err2 := store.LoadAll(ctx, 2, 2)
+		page3, err3 := store.LoadAll(ctx, 3, 2)
+
+		// Then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		require.Len(t, page1, 2)
+		require.Len(t, page2, 1)
+		assert.Empty(t, page3)
+		assert.Equal(t, 1, page1[0].Position)
+		assert.Equal(t, 2, page1[1].Position)
+		assert.Equal(t, electionID2, page2[0].StreamID)
+		assert.Equal(t, 1, page2[0].Version)
+	})
+
+	t.Run("returns stream version", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		require.NoError(t, store.Append(ctx, electionID1, 0,
+			event.ElectionHasCommenced{ElectionID: electionID1},
+			event.ElectionWasClosedByOwner{ElectionID: electionID1},
+		))
+
+		// When
+		version1, err1 := store.Version(ctx, electionID1)
+		version2, err2 := store.Version(ctx, electionID2)
+
+		// Then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, 2, version1)
+		assert.Equal(t, 0, version2)
+	})
+
+	t.Run("locks stream for a single writer", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		unlock, err := store.Lock(ctx, electionID1)
+		require.NoError(t, err)
+		unlock2, err := store.Lock(ctx, electionID2)
+		require.NoError(t, err)
+		defer unlock2()
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		// When
+		_, err = store.Lock(cancelledCtx, electionID1)
+
+		// Then
+		require.ErrorIs(t, err, context.Canceled)
+		unlock()
+		unlock()
+		unlockAgain, err := store.Lock(ctx, electionID1)
+		require.NoError(t, err)
+		unlockAgain()
+	})
+}
+
+func TestIsInMemory(t *testing.T) {
+	t.Run("reports an in-memory store", func(t *testing.T) {
+		assert.True(t, inmemorystore.IsInMemory(inmemorystore.New()))
+	})
+
+	t.Run("reports a missing store as not in-memory", func(t *testing.T) {
+		assert.False(t, inmemorystore.IsInMemory(nil))
+	})
+}
@@ -0,0 +1,293 @@
+package postgresstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/inklabs/cqrs"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+const instrumentationName = "github.com/inklabs/vote/internal/eventstore/postgres"
+
+var tracer = otel.Tracer(instrumentationName)
+
+// streamWriterLockClass is the first key of the advisory locks taken by Lock.
+const streamWriterLockClass = 1
+
+type postgresEventStore struct {
+	db    *sql.DB
+	codec *eventstore.Codec
+}
+
+func NewFromDB(db *sql.DB, codec *eventstore.Codec) *postgresEventStore {
+	return &postgresEventStore{
+		db:    db,
+		codec: codec,
+	}
+}
+
+func (s *postgresEventStore) Append(ctx context.Context, streamID string, expectedVersion int, events ...cqrs.Event) error {
+	_, span := tracer.Start(ctx, "db.append-events")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("unable to create transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	err = s.appendEvents(ctx, tx, streamID, expectedVersion, events)
+	if err != nil {
+		recordSpanError(span, err)
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+		recordSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *postgresEventStore) appendEvents(ctx context.Context, tx *sql.Tx, streamID string, expectedVersion int, events []cqrs.Event) error {
+	// serialize appends to the same stream for the rest of the transaction
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, streamID)
+	if err != nil {
+		return fmt.Errorf("unable to lock stream: %w", err)
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(Version), 0) FROM event_log WHERE StreamID = $1`,
+		streamID,
+	).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("unable to get stream version: %w", err)
+	}
+
+	if expectedVersion != eventstore.AnyVersion && expectedVersion != version {
+		return eventstore.NewErrVersionConflict(streamID, expectedVersion, version)
+	}
+
+	sqlStatement := `INSERT INTO event_log (
+						StreamID,
+						Version,
+						EventType,
+						Data
+                     ) VALUES ($1, $2, $3, $4)`
+
+	for _, e := range events {
+		eventType, data, err := s.codec.Encode(e)
+		if err != nil {
+			return err
+		}
+
+		version++
+
+		_, err = tx.ExecContext(ctx, sqlStatement,
+			streamID,
+			version,
+			eventType,
+			string(data),
+		)
+		if err != nil {
+			var pqError *pq.Error
+			if errors.As(err, &pqError) && pqError.Code == "23505" {
+				return eventstore.NewErrVersionConflict(streamID, expectedVersion, version)
+			}
+			return fmt.Errorf("unable to append event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *postgresEventStore) Load(ctx context.Context, streamID string) ([]eventstore.Record, error) {
+	_, span := tracer.Start(ctx, "db.load-events")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						Position,
+						StreamID,
+						Version,
+						EventType,
+						Data
+                     FROM event_log
+                     WHERE StreamID = $1
+                     ORDER BY Version ASC`
+
+	rows, err := s.db.QueryContext(ctx, sqlStatement, streamID)
+	if err != nil {
+		err = fmt.Errorf("unable to load events: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	records, err := s.scanRecords(rows)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *postgresEventStore) Version(ctx context.Context, streamID string) (int, error) {
+	_, span := tracer.Start(ctx, "db.stream-version")
+	defer span.End()
+
+	var version int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(Version), 0) FROM event_log WHERE StreamID = $1`,
+		streamID,
+	).Scan(&version)
+	if err != nil {
+		err = fmt.Errorf("unable to get stream version: %w", err)
+		recordSpanError(span, err)
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// Lock takes a session advisory lock on a dedicated connection, held until unlock is
+// called. The lock uses the two key form, which does not overlap the single key lock
+// that Append takes, so a writer holding the stream may still append to it.
+func (s *postgresEventStore) Lock(ctx context.Context, streamID string) (func(), error) {
+	_, span := tracer.Start(ctx, "db.lock-stream")
+	defer span.End()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		err = fmt.Errorf("unable to get connection: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	_, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1, hashtext($2))`, streamWriterLockClass, streamID)
+	if err != nil {
+		_ = conn.Close()
+		err = fmt.Errorf("unable to lock stream: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1, hashtext($2))`, streamWriterLockClass, streamID)
+			if err != nil {
+				// discard the connection, which releases its session locks
+				_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			}
+			_ = conn.Close()
+		})
+	}, nil
+}
+
+func (s *postgresEventStore) LoadAll(ctx context.Context, afterPosition, limit int) ([]eventstore.Record, error) {
+	_, span := tracer.Start(ctx, "db.load-all-events")
+	defer span.End()
+
+	sqlStatement := `SELECT
+						Position,
+						StreamID,
+						Version,
+						EventType,
+						Data
+                     FROM event_log
+                     WHERE Position > $1
+                     ORDER BY Position ASC
+                     LIMIT $2`
+
+	rows, err := s.db.QueryContext(ctx, sqlStatement, afterPosition, limit)
+	if err != nil {
+		err = fmt.Errorf("unable to load events: %w", err)
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	records, err := s.scanRecords(rows)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *postgresEventStore) scanRecords(rows *sql.Rows) ([]eventstore.Record, error) {
+	var records []eventstore.Record
+
+	for rows.Next() {
+		var record eventstore.Record
+		var data []byte
+
+		err := rows.Scan(
+			&record.Position,
+			&record.StreamID,
+			&record.Version,
+			&record.EventType,
+			&data,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get event data: %w", err)
+		}
+
+		record.Event, err = s.codec.Decode(record.EventType, data)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("unable to get events: %w", rows.Err())
+	}
+
+	return records, nil
+}
+
+func (s *postgresEventStore) InitDB(ctx context.Context) error {
+	sqlStatements := []string{
+		`CREATE TABLE IF NOT EXISTS event_log (
+			Position BIGSERIAL PRIMARY KEY,
+			StreamID TEXT NOT NULL,
+			Version INT NOT NULL,
+			EventType TEXT NOT NULL,
+			Data JSONB NOT NULL,
+			RecordedAt TIMESTAMPTZ NOT NULL DEFAULT now(),
+			CONSTRAINT unique_event_log_stream_version UNIQUE (StreamID, Version)
+		);`,
+	}
+
+	for _, statement := range sqlStatements {
+		_, err := s.db.ExecContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func recordSpanError(span trace.Span, err error) {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+}
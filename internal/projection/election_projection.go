@@ -0,0 +1,191 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/inklabs/cqrs"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/electionaggregate"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+// ElectionProjection builds the read models behind GetElection, ListOpenElections,
+// and ListProposals from the events in the event store.
+type ElectionProjection struct {
+	repository electionrepository.Repository
+}
+
+func NewElectionProjection(repository electionrepository.Repository) *ElectionProjection {
+	return &ElectionProjection{
+		repository: repository,
+	}
+}
+
+// Project writes the state of an election aggregate to the read models. The
+// election is saved as open first so that its proposals and votes are accepted,
+// and the eligibility roll is saved before the ballot tokens and votes it permits.
+func (p *ElectionProjection) Project(ctx context.Context, election *electionaggregate.Election) error {
+	openElection := election.Election
+	openElection.IsClosed = false
+
+	err := p.repository.SaveElection(ctx, openElection)
+	if err != nil {
+		return err
+	}
+
+	for _, proposal := range election.Proposals {
+		err = p.repository.SaveProposal(ctx, proposal)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(election.EligibleVoters) > 0 {
+		err = p.repository.SaveEligibleVoters(ctx, election.EligibleVoters)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ballotToken := range election.BallotTokens {
+		err = p.repository.SaveBallotToken(ctx, ballotToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, vote := range election.Votes {
+		err = p.repository.SaveVote(ctx, vote)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, secretBallot := range election.SecretBallots {
+		err = p.repository.SaveSecretBallot(ctx, secretBallot)
+		if err != nil {
+			return err
+		}
+	}
+
+	if election.Election.IsClosed {
+		return p.repository.SaveElection(ctx, election.Election)
+	}
+
+	return nil
+}
+
+// Rebuild replaces the read models of a single election with the state of its aggregate,
+// such as when events were recorded but their projection failed.
+func (p *ElectionProjection) Rebuild(ctx context.Context, election *electionaggregate.Election) error {
+	err := p.repository.DeleteElection(ctx, election.Election.ElectionID)
+	if err != nil {
+		return err
+	}
+
+	return p.Project(ctx, election)
+}
+
+// ProjectEvents writes the events a command recorded to the read models. The events
+// are applied to election, the read model the command was decided against, and the
+// election is saved last with the stream version after the events, so the next
+// command expects the stream at that version.
+func (p *ElectionProjection) ProjectEvents(ctx context.Context, election electionrepository.Election, events ...cqrs.Event) error {
+	aggregate := &electionaggregate.Election{
+		Election: election,
+		Version:  election.Version,
+	}
+
+	updatedProposalIDs := make(map[string]bool)
+	var removedUserIDs []string
+
+	for _, e := range events {
+		proposalID := getUpdatedProposalID(e)
+		if proposalID != "" && !updatedProposalIDs[proposalID] {
+			proposal, err := p.repository.GetProposal(ctx, proposalID)
+			if err != nil {
+				return err
+			}
+
+			aggregate.Proposals = append(aggregate.Proposals, proposal)
+			updatedProposalIDs[proposalID] = true
+		}
+
+		if e, ok := e.(event.EligibleVoterWasRemoved); ok {
+			removedUserIDs = append(removedUserIDs, e.UserID)
+		}
+
+		aggregate.Apply(e)
+	}
+
+	for _, proposal := range aggregate.Proposals {
+		var err error
+		if updatedProposalIDs[proposal.ProposalID] {
+			err = p.repository.UpdateProposal(ctx, proposal)
+		} else {
+			err = p.repository.SaveProposal(ctx, proposal)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(aggregate.EligibleVoters) > 0 {
+		err := p.repository.SaveEligibleVoters(ctx, aggregate.EligibleVoters)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, userID := range removedUserIDs {
+		err := p.repository.RemoveEligibleVoter(ctx, election.ElectionID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ballotToken := range aggregate.BallotTokens {
+		err := p.repository.SaveBallotToken(ctx, ballotToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(aggregate.Votes) > 0 {
+		err := p.repository.SaveVotes(ctx, aggregate.Votes)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, secretBallot := range aggregate.SecretBallots {
+		err := p.repository.SaveSecretBallot(ctx, secretBallot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.repository.SaveElection(ctx, aggregate.Election)
+}
+
+// getUpdatedProposalID returns the ProposalID of an event that changes an existing proposal.
+func getUpdatedProposalID(e cqrs.Event) string {
+	switch e := e.(type) {
+	case event.ProposalWasUpdated:
+		return e.ProposalID
+	case event.ProposalWasWithdrawn:
+		return e.ProposalID
+	case event.ProposalWasApproved:
+		return e.ProposalID
+	case event.ProposalWasRejected:
+		return e.ProposalID
+	}
+
+	return ""
+}
+
+// Reset removes the read models so they can be rebuilt from the event store.
+func (p *ElectionProjection) Reset(ctx context.Context) error {
+	return p.repository.DeleteAll(ctx)
+}
@@ -0,0 +1,97 @@
+package projection
+
+import (
+	"context"
+	"sort"
+
+	"github.com/inklabs/vote/internal/electionaggregate"
+	"github.com/inklabs/vote/internal/eventstore"
+)
+
+const replayBatchSize = 500
+
+// Projector builds a read model from election aggregates.
+type Projector interface {
+	Reset(ctx context.Context) error
+	Project(ctx context.Context, election *electionaggregate.Election) error
+}
+
+// Progress receives the number of elections to project, and is incremented as
+// each election is projected. cqrs.AsyncCommandLogger satisfies Progress.
+type Progress interface {
+	SetTotalToProcess(totalToProcess int)
+	IncrementTotalProcessed()
+}
+
+// Replay folds every event in the store, in order, into election aggregates.
+// Each projector is then reset and rebuilt from those aggregates. The read
+// models are left untouched if the event store cannot be read.
+func Replay(ctx context.Context, store eventstore.Store, progress Progress, projectors ...Projector) error {
+	elections, err := loadElections(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	for _, projector := range projectors {
+		err = projector.Reset(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	progress.SetTotalToProcess(len(elections))
+
+	for _, election := range elections {
+		for _, projector := range projectors {
+			err = projector.Project(ctx, election)
+			if err != nil {
+				return err
+			}
+		}
+
+		progress.IncrementTotalProcessed()
+	}
+
+	return nil
+}
+
+// loadElections returns the election aggregates in the store, sorted by ElectionID.
+func loadElections(ctx context.Context, store eventstore.Store) ([]*electionaggregate.Election, error) {
+	elections := make(map[string]*electionaggregate.Election)
+
+	afterPosition := 0
+	for {
+		records, err := store.LoadAll(ctx, afterPosition, replayBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		for _, record := range records {
+			election, ok := elections[record.StreamID]
+			if !ok {
+				election = electionaggregate.New(record.StreamID)
+				elections[record.StreamID] = election
+			}
+
+			election.Apply(record.Event)
+			afterPosition = record.Position
+		}
+	}
+
+	electionIDs := make([]string, 0, len(elections))
+	for electionID := range elections {
+		electionIDs = append(electionIDs, electionID)
+	}
+	sort.Strings(electionIDs)
+
+	sortedElections := make([]*electionaggregate.Election, len(electionIDs))
+	for i, electionID := range electionIDs {
+		sortedElections[i] = elections[electionID]
+	}
+
+	return sortedElections, nil
+}
@@ -0,0 +1,524 @@
+package projection_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/inklabs/cqrs/cqrstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/event"
+	"github.com/inklabs/vote/internal/ballotchain"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/electionrepository/inmemoryrepo"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/eventstore/inmemorystore"
+	"github.com/inklabs/vote/internal/projection"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("rebuilds read models from the event store", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID      = "8b6f4a1e-2c3d-4e5f-9a0b-1c2d3e4f5a6b"
+			organizerUserID = "9c7a5b2f-3d4e-4f6a-8b1c-2d3e4f5a6b7c"
+			proposalID1     = "ad8b6c3a-4e5f-4a7b-9c2d-3e4f5a6b7c8d"
+			proposalID2     = "be9c7d4b-5f6a-4b8c-8d3e-4f5a6b7c8d9e"
+			userID          = "cfad8e5c-6a7b-4c9d-9e4f-5a6b7c8d9eaf"
+			voteID1         = "d0be9f6d-7b8c-4dae-8f5a-6b7c8d9eafb0"
+			voteID2         = "e1cfa07e-8c9d-4ebf-9a6b-7c8d9eafb0c1"
+		)
+		require.NoError(t, store.Append(ctx, electionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID:      electionID,
+				OrganizerUserID: organizerUserID,
+				Name:            "Election Name",
+				Description:     "Election Description",
+				SeatCount:       1,
+				VotingMethod:    "InstantRunoff",
+				RevotePolicy:    electionrepository.RevotePolicyReplacePrevious,
+				OccurredAt:      1,
+			},
+			event.ProposalWasMade{
+				ElectionID:  electionID,
+				ProposalID:  proposalID1,
+				OwnerUserID: organizerUserID,
+				Name:        "Proposal Name 1",
+				Description: "Proposal Description 1",
+				ProposedAt:  2,
+			},
+			event.ProposalWasMade{
+				ElectionID:  electionID,
+				ProposalID:  proposalID2,
+				OwnerUserID: organizerUserID,
+				Name:        "Proposal Name 2",
+				Description: "Proposal Description 2",
+				ProposedAt:  3,
+			},
+			event.VoteWasCast{
+				VoteID:            voteID1,
+				ElectionID:        electionID,
+				UserID:            userID,
+				RankedProposalIDs: []string{proposalID1, proposalID2},
+				OccurredAt:        4,
+			},
+			event.VoteWasCast{
+				VoteID:            voteID2,
+				ElectionID:        electionID,
+				UserID:            userID,
+				RankedProposalIDs: []string{proposalID2, proposalID1},
+				OccurredAt:        5,
+			},
+			event.VoteWasReplaced{
+				ElectionID:     electionID,
+				UserID:         userID,
+				VoteID:         voteID2,
+				ReplacedVoteID: voteID1,
+				OccurredAt:     5,
+			},
+			event.ElectionWasClosedByOwner{
+				ElectionID: electionID,
+				OccurredAt: 6,
+			},
+			event.ElectionWinnerWasSelected{
+				ElectionID:         electionID,
+				WinningProposalID:  proposalID2,
+				WinningProposalIDs: []string{proposalID2},
+				SelectedAt:         6,
+			},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, progress.totalToProcess)
+		assert.Equal(t, 1, progress.totalProcessed)
+
+		actualElection, err := repository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Election{
+			ElectionID:         electionID,
+			OrganizerUserID:    organizerUserID,
+			Name:               "Election Name",
+			Description:        "Election Description",
+			SeatCount:          1,
+			VotingMethod:       "InstantRunoff",
+			RevotePolicy:       electionrepository.RevotePolicyReplacePrevious,
+			WinningProposalID:  proposalID2,
+			WinningProposalIDs: []string{proposalID2},
+			Outcome:            electionrepository.OutcomeWinnerSelected,
+			IsClosed:           true,
+			CommencedAt:        1,
+			ClosedAt:           6,
+			SelectedAt:         6,
+			Version:            8,
+		}, actualElection)
+
+		totalProposals, proposals, err := repository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, totalProposals)
+		assert.Equal(t, proposalID1, proposals[0].ProposalID)
+		assert.Equal(t, proposalID2, proposals[1].ProposalID)
+
+		votes, err := repository.GetVotes(ctx, electionID)
+		require.NoError(t, err)
+		vote1 := ballotchain.Link(electionrepository.Vote{
+			VoteID:            voteID1,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID1, proposalID2},
+			SubmittedAt:       4,
+		}, "")
+		vote2 := ballotchain.Link(electionrepository.Vote{
+			VoteID:            voteID2,
+			ElectionID:        electionID,
+			UserID:            userID,
+			RankedProposalIDs: []string{proposalID2, proposalID1},
+			SubmittedAt:       5,
+			SupersedesVoteID:  voteID1,
+		}, vote1.VoteHash)
+		assert.Equal(t, []electionrepository.Vote{vote1, vote2}, votes)
+	})
+
+	t.Run("rebuilds eligibility roll before the votes it permits", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID  = "25a3e4c2-c0d1-4cf3-9eaf-b0c1d2e3f4a5"
+			proposalID  = "36b4f5d3-d1e2-4da4-8fb0-c1d2e3f4a5b6"
+			userID1     = "47c506e4-e2f3-4eb5-90c1-d2e3f4a5b6c7"
+			userID2     = "58d617f5-f3a4-4fc6-81d2-e3f4a5b6c7d8"
+			removedUser = "69e72806-04b5-40d7-92e3-f4a5b6c7d8e9"
+		)
+		require.NoError(t, store.Append(ctx, electionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID:        electionID,
+				Name:              "Election Name",
+				EligibilityPolicy: electionrepository.EligibilityPolicyRegisteredVoters,
+			},
+			event.ProposalWasMade{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			},
+			event.EligibleVoterWasRegistered{ElectionID: electionID, UserID: userID1, OccurredAt: 1},
+			event.EligibleVoterWasRegistered{ElectionID: electionID, UserID: userID2, OccurredAt: 1},
+			event.EligibleVoterWasRegistered{ElectionID: electionID, UserID: removedUser, OccurredAt: 1},
+			event.EligibleVoterWasRemoved{ElectionID: electionID, UserID: removedUser, OccurredAt: 2},
+			event.VoteWasCast{
+				VoteID:            "7af83917-15c6-41e8-83f4-a5b6c7d8e9fa",
+				ElectionID:        electionID,
+				UserID:            userID1,
+				RankedProposalIDs: []string{proposalID},
+				OccurredAt:        3,
+			},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		turnout, err := repository.GetTurnout(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Turnout{
+			ElectionID:    electionID,
+			TotalEligible: 2,
+			TotalVoted:    1,
+		}, turnout)
+	})
+
+	t.Run("rebuilds ballot tokens and secret ballots", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID = "8b0a4a28-26d7-42f9-b405-b6c7d8e9fa0b"
+			proposalID = "9c1b5b39-37e8-430a-8516-c7d8e9fa0b1c"
+			userID     = "ad2c6c4a-48f9-441b-9627-d8e9fa0b1c2d"
+		)
+		secretBallot := electionrepository.SecretBallot{
+			VoteID:            "be3d7d5b-590a-452c-a738-e9fa0b1c2d3e",
+			ElectionID:        electionID,
+			RankedProposalIDs: []string{proposalID},
+			BallotTokenHash:   "35c52d324787d320ea24adcf4bc9fcf852d60abc954ce6e1da9c1171803326f3",
+		}
+		require.NoError(t, store.Append(ctx, electionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID:    electionID,
+				Name:          "Election Name",
+				BallotSecrecy: electionrepository.BallotSecrecySecret,
+			},
+			event.ProposalWasMade{
+				ElectionID: electionID,
+				ProposalID: proposalID,
+			},
+			event.BallotTokenWasIssued{
+				ElectionID:     electionID,
+				UserID:         userID,
+				BlindSignature: []byte("blind signature"),
+				OccurredAt:     1,
+			},
+			event.SecretBallotWasCast{
+				VoteID:            secretBallot.VoteID,
+				ElectionID:        electionID,
+				RankedProposalIDs: secretBallot.RankedProposalIDs,
+				BallotTokenHash:   secretBallot.BallotTokenHash,
+			},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		ballotToken, err := repository.GetBallotToken(ctx, electionID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.BallotToken{
+			ElectionID:     electionID,
+			UserID:         userID,
+			BlindSignature: []byte("blind signature"),
+			IssuedAt:       1,
+		}, ballotToken)
+		secretBallots, err := repository.GetSecretBallots(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, []electionrepository.SecretBallot{secretBallot}, secretBallots)
+	})
+
+	t.Run("rebuilds updated and withdrawn proposals", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID  = "cf4e8e6c-6a1b-463d-b849-fa0b1c2d3e4f"
+			proposalID1 = "d05f9f7d-7b2c-474e-895a-0b1c2d3e4f5a"
+			proposalID2 = "e160a08e-8c3d-485f-9a6b-1c2d3e4f5a6b"
+		)
+		require.NoError(t, store.Append(ctx, electionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID: electionID,
+				Name:       "Election Name",
+			},
+			event.ProposalWasMade{
+				ElectionID: electionID,
+				ProposalID: proposalID1,
+				Name:       "Proposal Name 1",
+				ProposedAt: 1,
+			},
+			event.ProposalWasMade{
+				ElectionID: electionID,
+				ProposalID: proposalID2,
+				Name:       "Proposal Name 2",
+				ProposedAt: 2,
+			},
+			event.ProposalWasUpdated{
+				ElectionID:  electionID,
+				ProposalID:  proposalID1,
+				Name:        "Updated Name",
+				Description: "Updated Description",
+				OccurredAt:  3,
+			},
+			event.ProposalWasWithdrawn{
+				ElectionID: electionID,
+				ProposalID: proposalID2,
+				OccurredAt: 4,
+			},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		totalProposals, proposals, err := repository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalProposals)
+		assert.Equal(t, []electionrepository.Proposal{
+			{
+				ElectionID:       electionID,
+				ProposalID:       proposalID1,
+				Name:             "Updated Name",
+				Description:      "Updated Description",
+				ProposedAt:       1,
+				UpdatedAt:        3,
+				ModerationStatus: electionrepository.ModerationStatusApproved,
+			},
+		}, proposals)
+		withdrawnProposal, err := repository.GetProposal(ctx, proposalID2)
+		require.NoError(t, err)
+		assert.True(t, withdrawnProposal.IsWithdrawn)
+		assert.Equal(t, 4, withdrawnProposal.WithdrawnAt)
+	})
+
+	t.Run("rebuilds moderated proposals", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID  = "f271b19f-9d4e-496a-ab7c-2d3e4f5a6b7c"
+			proposalID1 = "0382c2a0-ae5f-4a7b-8c8d-3e4f5a6b7c8d"
+			proposalID2 = "1493d3b1-bf6a-4b8c-9d9e-4f5a6b7c8d9e"
+			proposalID3 = "25a4e4c2-c07b-4c9d-8eaf-5a6b7c8d9eaf"
+			proposalID4 = "36b5f5d3-d18c-4dae-9fb0-6b7c8d9eafb0"
+		)
+		require.NoError(t, store.Append(ctx, electionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID:        electionID,
+				Name:              "Election Name",
+				ModerateProposals: true,
+			},
+			event.ProposalWasMade{ElectionID: electionID, ProposalID: proposalID1, ProposedAt: 1},
+			event.ProposalWasMade{ElectionID: electionID, ProposalID: proposalID2, ProposedAt: 2},
+			event.ProposalWasMade{ElectionID: electionID, ProposalID: proposalID3, ProposedAt: 3},
+			event.ProposalWasApproved{ElectionID: electionID, ProposalID: proposalID1, OccurredAt: 4},
+			event.ProposalWasRejected{ElectionID: electionID, ProposalID: proposalID2, Reason: "Spam", OccurredAt: 5},
+			event.ProposalWasMade{ElectionID: electionID, ProposalID: proposalID4, ProposedAt: 6},
+			event.ProposalWasApproved{ElectionID: electionID, ProposalID: proposalID4, OccurredAt: 7},
+			event.ProposalWasUpdated{ElectionID: electionID, ProposalID: proposalID4, Name: "Updated Name", OccurredAt: 8},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		_, proposals, err := repository.ListProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, proposals, 1)
+		assert.Equal(t, proposalID1, proposals[0].ProposalID)
+		_, pendingProposals, err := repository.ListPendingProposals(ctx, electionID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, pendingProposals, 2)
+		assert.Equal(t, proposalID3, pendingProposals[0].ProposalID)
+		assert.Equal(t, proposalID4, pendingProposals[1].ProposalID)
+		rejectedProposal, err := repository.GetProposal(ctx, proposalID2)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.ModerationStatusRejected, rejectedProposal.ModerationStatus)
+		assert.Equal(t, "Spam", rejectedProposal.RejectionReason)
+	})
+
+	t.Run("rebuilds cancelled and reopened elections", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			cancelledElectionID = "f271b19f-9d4e-4960-8b7c-2d3e4f5a6b7c"
+			reopenedElectionID  = "0382c2a0-ae5f-4a71-9c8d-3e4f5a6b7c8d"
+		)
+		require.NoError(t, store.Append(ctx, cancelledElectionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID: cancelledElectionID,
+				Name:       "Cancelled Election",
+			},
+			event.ElectionWasCancelled{
+				ElectionID: cancelledElectionID,
+				Reason:     "Wrong voting method",
+				OccurredAt: 1,
+			},
+		))
+		require.NoError(t, store.Append(ctx, reopenedElectionID, 0,
+			event.ElectionHasCommenced{
+				ElectionID: reopenedElectionID,
+				Name:       "Reopened Election",
+			},
+			event.ElectionWasCancelled{
+				ElectionID: reopenedElectionID,
+				OccurredAt: 1,
+			},
+			event.ElectionWasReopened{
+				ElectionID:   reopenedElectionID,
+				VotingEndsAt: 10,
+				OccurredAt:   2,
+			},
+		))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		cancelledElection, err := repository.GetElection(ctx, cancelledElectionID)
+		require.NoError(t, err)
+		assert.Equal(t, electionrepository.Election{
+			ElectionID:         cancelledElectionID,
+			Name:               "Cancelled Election",
+			IsClosed:           true,
+			ClosedAt:           1,
+			IsCancelled:        true,
+			CancelledAt:        1,
+			CancellationReason: "Wrong voting method",
+			Version:            2,
+		}, cancelledElection)
+		totalOpenElections, openElections, err := repository.ListOpenElections(ctx, 1, 10, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalOpenElections)
+		assert.Equal(t, []electionrepository.Election{
+			{
+				ElectionID:   reopenedElectionID,
+				Name:         "Reopened Election",
+				VotingEndsAt: 10,
+				Version:      3,
+			},
+		}, openElections)
+	})
+
+	t.Run("rebuilds across multiple batches", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const electionID = "f2d0b18f-9dae-4fc0-8b7c-8d9eafb0c1d2"
+		require.NoError(t, store.Append(ctx, electionID, eventstore.AnyVersion, event.ElectionHasCommenced{
+			ElectionID: electionID,
+			Name:       "Election Name",
+		}))
+
+		const totalProposals = 1200
+		for i := 0; i < totalProposals; i++ {
+			require.NoError(t, store.Append(ctx, electionID, eventstore.AnyVersion, event.ProposalWasMade{
+				ElectionID: electionID,
+				ProposalID: fmt.Sprintf("proposal-%04d", i),
+				ProposedAt: i,
+			}))
+		}
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		actualTotal, _, err := repository.ListProposals(ctx, electionID, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, totalProposals, actualTotal)
+	})
+
+	t.Run("removes read models that are not in the event store", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		store := inmemorystore.New()
+		repository := inmemoryrepo.New()
+		progress := &recordingProgress{}
+
+		const (
+			electionID      = "03e1c2a0-aebf-4ad1-9c8d-9eafb0c1d2e3"
+			staleElectionID = "14f2d3b1-bfc0-4be2-8d9e-afb0c1d2e3f4"
+		)
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID: staleElectionID,
+			Name:       "Stale Election",
+		}))
+		require.NoError(t, store.Append(ctx, electionID, 0, event.ElectionHasCommenced{
+			ElectionID: electionID,
+			Name:       "Election Name",
+		}))
+
+		// When
+		err := projection.Replay(ctx, store, progress, projection.NewElectionProjection(repository))
+
+		// Then
+		require.NoError(t, err)
+		_, err = repository.GetElection(ctx, staleElectionID)
+		require.Equal(t, electionrepository.NewErrElectionNotFound(staleElectionID), err)
+		actualElection, err := repository.GetElection(ctx, electionID)
+		require.NoError(t, err)
+		assert.Equal(t, "Election Name", actualElection.Name)
+	})
+}
+
+type recordingProgress struct {
+	totalToProcess int
+	totalProcessed int
+}
+
+func (p *recordingProgress) SetTotalToProcess(totalToProcess int) {
+	p.totalToProcess = totalToProcess
+}
+
+func (p *recordingProgress) IncrementTotalProcessed() {
+	p.totalProcessed++
+}
@@ -0,0 +1,32 @@
+package rcv
+
+type approval struct {
+	ballots Ballots
+}
+
+// NewApproval is an approval voting tabulator. Every proposal listed on a ballot
+// is approved regardless of its rank, and the proposal with the most approvals wins.
+// For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Approval_voting).
+func NewApproval(ballots Ballots) *approval {
+	return &approval{
+		ballots: ballots,
+	}
+}
+
+// GetWinningProposal returns the proposal with the most approvals.
+// ErrTie is returned if the most approvals are tied.
+func (t *approval) GetWinningProposal() (string, error) {
+	approvalCount := make(map[string]int)
+
+	for _, rankedProposalIDs := range t.ballots {
+		approved := make(map[string]struct{})
+		for _, proposalID := range rankedProposalIDs {
+			if _, ok := approved[proposalID]; !ok {
+				approved[proposalID] = struct{}{}
+				approvalCount[proposalID]++
+			}
+		}
+	}
+
+	return getMaxProposal(approvalCount)
+}
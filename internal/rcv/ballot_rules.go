@@ -0,0 +1,155 @@
+package rcv
+
+import (
+	"fmt"
+
+	"github.com/inklabs/vote/internal/writein"
+)
+
+// SkippedRank marks a rank the voter left blank, with a lower rank filled in.
+const SkippedRank = ""
+
+// BallotRules limit how a ballot may rank proposals. MaxRanks is the most
+// proposals a ballot may rank, or 0 for no limit. RequireCompleteRanking
+// requires every proposal to be ranked, up to MaxRanks. AllowDuplicateRanks
+// accepts a proposal ranked more than once, and AllowSkippedRanks accepts a
+// SkippedRank before the last rank. AllowWriteIns accepts write-in candidates,
+// ranked as labels made with writein.New.
+//
+// Each rank holds a single ProposalID, so a ballot cannot be overvoted by
+// ranking two proposals equally.
+type BallotRules struct {
+	MaxRanks               int
+	RequireCompleteRanking bool
+	AllowDuplicateRanks    bool
+	AllowSkippedRanks      bool
+	AllowWriteIns          bool
+}
+
+// Validate checks rankedProposalIDs against the rules. proposalIDs are the
+// proposals in the election, and are only needed when RequireCompleteRanking.
+// A ballot without any ranked proposal (an undervote) is never valid.
+func (r BallotRules) Validate(rankedProposalIDs []string, proposalIDs []string) error {
+	if r.MaxRanks > 0 && len(rankedProposalIDs) > r.MaxRanks {
+		return fmt.Errorf("%w: %d of %d", ErrTooManyRanks, len(rankedProposalIDs), r.MaxRanks)
+	}
+
+	isRanked := make(map[string]struct{}, len(rankedProposalIDs))
+
+	for rank, proposalID := range rankedProposalIDs {
+		if proposalID == SkippedRank {
+			if !r.AllowSkippedRanks {
+				return fmt.Errorf("%w: %d", ErrSkippedRank, rank+1)
+			}
+			continue
+		}
+
+		if writein.Is(proposalID) {
+			if !r.AllowWriteIns {
+				return ErrWriteInNotAllowed
+			}
+
+			if writein.Label(proposalID) == "" {
+				return fmt.Errorf("%w: %d", ErrEmptyWriteIn, rank+1)
+			}
+		}
+
+		candidate := writein.Normalize(proposalID)
+		if _, ok := isRanked[candidate]; ok && !r.AllowDuplicateRanks {
+			return fmt.Errorf("%w: %s", ErrDuplicateRanking, proposalID)
+		}
+
+		isRanked[candidate] = struct{}{}
+	}
+
+	if len(isRanked) == 0 {
+		return ErrEmptyBallot
+	}
+
+	if r.RequireCompleteRanking {
+		required := len(proposalIDs)
+		if r.MaxRanks > 0 && r.MaxRanks < required {
+			required = r.MaxRanks
+		}
+
+		rankedProposals := 0
+		for _, proposalID := range proposalIDs {
+			if _, ok := isRanked[proposalID]; ok {
+				rankedProposals++
+			}
+		}
+
+		if rankedProposals < required {
+			return fmt.Errorf("%w: %d of %d", ErrIncompleteRanking, rankedProposals, required)
+		}
+	}
+
+	return nil
+}
+
+// Normalize returns the Ballots as they are counted, following common ranked
+// choice voting rules:
+//
+//   - Ranks beyond MaxRanks are ignored.
+//   - A skipped rank is passed over, but two consecutive skipped ranks exhaust
+//     the ballot, and the ranks after them are ignored.
+//   - Write-ins with the same normalized label count as one candidate, and a
+//     write-in without a label is passed over like a skipped rank.
+//   - A proposal ranked more than once only counts at its highest rank.
+//   - A ballot left with no ranked proposals is an undervote, and is not counted.
+func (b Ballots) Normalize(rules BallotRules) Ballots {
+	normalized := make(Ballots, 0, len(b))
+
+	for _, ballot := range b {
+		rankedProposalIDs := normalizeBallot(ballot, rules)
+		if len(rankedProposalIDs) == 0 {
+			continue
+		}
+
+		normalized = append(normalized, rankedProposalIDs)
+	}
+
+	return normalized
+}
+
+func normalizeBallot(ballot []string, rules BallotRules) []string {
+	if rules.MaxRanks > 0 && len(ballot) > rules.MaxRanks {
+		ballot = ballot[:rules.MaxRanks]
+	}
+
+	var rankedProposalIDs []string
+	isRanked := make(map[string]struct{}, len(ballot))
+	consecutiveSkippedRanks := 0
+
+	for _, proposalID := range ballot {
+		proposalID = writein.Normalize(proposalID)
+		if proposalID == SkippedRank || proposalID == writein.Prefix {
+			consecutiveSkippedRanks++
+			if consecutiveSkippedRanks == 2 {
+				break
+			}
+			continue
+		}
+
+		consecutiveSkippedRanks = 0
+
+		if _, ok := isRanked[proposalID]; ok {
+			continue
+		}
+
+		isRanked[proposalID] = struct{}{}
+		rankedProposalIDs = append(rankedProposalIDs, proposalID)
+	}
+
+	return rankedProposalIDs
+}
+
+var (
+	ErrEmptyBallot       = fmt.Errorf("ballot does not rank any candidates")
+	ErrDuplicateRanking  = fmt.Errorf("candidate is ranked more than once")
+	ErrSkippedRank       = fmt.Errorf("ballot skips a rank")
+	ErrTooManyRanks      = fmt.Errorf("ballot ranks more candidates than allowed")
+	ErrIncompleteRanking = fmt.Errorf("ballot does not rank every candidate")
+	ErrWriteInNotAllowed = fmt.Errorf("election does not allow write-in candidates")
+	ErrEmptyWriteIn      = fmt.Errorf("write-in candidate has no label")
+)
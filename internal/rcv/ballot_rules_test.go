@@ -0,0 +1,170 @@
+package rcv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inklabs/vote/internal/rcv"
+	"github.com/inklabs/vote/internal/writein"
+)
+
+func TestBallotRules_Validate(t *testing.T) {
+	proposalIDs := []string{A, B, C, D}
+
+	tests := []struct {
+		name              string
+		rules             rcv.BallotRules
+		rankedProposalIDs []string
+		err               error
+	}{
+		{
+			name:              "partial ballot",
+			rankedProposalIDs: []string{A, B},
+		},
+		{
+			name:              "empty ballot",
+			rankedProposalIDs: []string{},
+			err:               rcv.ErrEmptyBallot,
+		},
+		{
+			name:              "only skipped ranks",
+			rules:             rcv.BallotRules{AllowSkippedRanks: true},
+			rankedProposalIDs: []string{rcv.SkippedRank, rcv.SkippedRank},
+			err:               rcv.ErrEmptyBallot,
+		},
+		{
+			name:              "duplicate ranking",
+			rankedProposalIDs: []string{A, B, A},
+			err:               rcv.ErrDuplicateRanking,
+		},
+		{
+			name:              "duplicate ranking allowed",
+			rules:             rcv.BallotRules{AllowDuplicateRanks: true},
+			rankedProposalIDs: []string{A, B, A},
+		},
+		{
+			name:              "skipped rank",
+			rankedProposalIDs: []string{A, rcv.SkippedRank, B},
+			err:               rcv.ErrSkippedRank,
+		},
+		{
+			name:              "skipped rank allowed",
+			rules:             rcv.BallotRules{AllowSkippedRanks: true},
+			rankedProposalIDs: []string{A, rcv.SkippedRank, B},
+		},
+		{
+			name:              "write-in",
+			rules:             rcv.BallotRules{AllowWriteIns: true},
+			rankedProposalIDs: []string{writein.New("Jane Doe"), A},
+		},
+		{
+			name:              "write-in not allowed",
+			rankedProposalIDs: []string{writein.New("Jane Doe"), A},
+			err:               rcv.ErrWriteInNotAllowed,
+		},
+		{
+			name:              "write-in without label",
+			rules:             rcv.BallotRules{AllowWriteIns: true},
+			rankedProposalIDs: []string{writein.New(" ")},
+			err:               rcv.ErrEmptyWriteIn,
+		},
+		{
+			name:              "duplicate write-in",
+			rules:             rcv.BallotRules{AllowWriteIns: true},
+			rankedProposalIDs: []string{writein.New("Jane Doe"), writein.New("jane  doe")},
+			err:               rcv.ErrDuplicateRanking,
+		},
+		{
+			name:              "too many ranks",
+			rules:             rcv.BallotRules{MaxRanks: 2},
+			rankedProposalIDs: []string{A, B, C},
+			err:               rcv.ErrTooManyRanks,
+		},
+		{
+			name:              "incomplete ranking",
+			rules:             rcv.BallotRules{RequireCompleteRanking: true},
+			rankedProposalIDs: []string{A, B, C},
+			err:               rcv.ErrIncompleteRanking,
+		},
+		{
+			name:              "complete ranking",
+			rules:             rcv.BallotRules{RequireCompleteRanking: true},
+			rankedProposalIDs: []string{D, C, B, A},
+		},
+		{
+			name:              "complete ranking up to max ranks",
+			rules:             rcv.BallotRules{MaxRanks: 2, RequireCompleteRanking: true},
+			rankedProposalIDs: []string{D, C},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			err := tt.rules.Validate(tt.rankedProposalIDs, proposalIDs)
+
+			// Then
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBallots_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   rcv.BallotRules
+		ballots rcv.Ballots
+		want    rcv.Ballots
+	}{
+		{
+			name:    "keeps valid ballots",
+			ballots: rcv.Ballots{{A, B}, {C}},
+			want:    rcv.Ballots{{A, B}, {C}},
+		},
+		{
+			name:    "counts duplicate at highest rank",
+			ballots: rcv.Ballots{{A, B, A, C}},
+			want:    rcv.Ballots{{A, B, C}},
+		},
+		{
+			name:    "passes over a skipped rank",
+			ballots: rcv.Ballots{{A, rcv.SkippedRank, B}},
+			want:    rcv.Ballots{{A, B}},
+		},
+		{
+			name:    "exhausts at two consecutive skipped ranks",
+			ballots: rcv.Ballots{{A, rcv.SkippedRank, rcv.SkippedRank, B}},
+			want:    rcv.Ballots{{A}},
+		},
+		{
+			name:    "ignores ranks beyond max ranks",
+			rules:   rcv.BallotRules{MaxRanks: 2},
+			ballots: rcv.Ballots{{A, B, C}},
+			want:    rcv.Ballots{{A, B}},
+		},
+		{
+			name:    "counts write-ins by normalized label",
+			ballots: rcv.Ballots{{writein.New("Jane Doe"), A}, {writein.New(" jane DOE")}},
+			want:    rcv.Ballots{{"write-in:jane doe", A}, {"write-in:jane doe"}},
+		},
+		{
+			name:    "drops undervotes",
+			ballots: rcv.Ballots{{}, {rcv.SkippedRank}, {B}},
+			want:    rcv.Ballots{{B}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			normalized := tt.ballots.Normalize(tt.rules)
+
+			// Then
+			assert.Equal(t, tt.want, normalized)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package rcv
+
+type bordaCount struct {
+	ballots Ballots
+}
+
+// NewBordaCount is a positional voting tabulator. Each ballot awards points to
+// its ranked proposals: the first choice receives one point per ranked proposal,
+// and each subsequent choice receives one point less. The proposal with the most
+// points wins. For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Borda_count).
+func NewBordaCount(ballots Ballots) *bordaCount {
+	return &bordaCount{
+		ballots: ballots,
+	}
+}
+
+// GetWinningProposal returns the proposal with the most points.
+// ErrTie is returned if the most points are tied.
+func (t *bordaCount) GetWinningProposal() (string, error) {
+	return getMaxProposal(calculateBordaCount(t.ballots))
+}
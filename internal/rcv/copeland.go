@@ -0,0 +1,45 @@
+package rcv
+
+type copeland struct {
+	ballots Ballots
+}
+
+// NewCopeland is a Condorcet tabulator using Copeland's method. Each proposal
+// scores a point for every head-to-head matchup it wins and half a point for
+// every tie. The proposal with the highest score wins.
+// For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Copeland%27s_method).
+func NewCopeland(ballots Ballots) *copeland {
+	return &copeland{
+		ballots: ballots,
+	}
+}
+
+// GetWinningProposal returns the proposal with the highest Copeland score.
+// ErrTie is returned if the highest score is tied.
+func (t *copeland) GetWinningProposal() (string, error) {
+	pairwise := calculatePairwisePreferences(t.ballots)
+
+	// scores are doubled to count a tie as a whole number
+	scores := make(map[string]int)
+
+	for _, proposalID := range pairwise.proposalIDs {
+		scores[proposalID] = 0
+
+		for _, otherProposalID := range pairwise.proposalIDs {
+			if proposalID == otherProposalID {
+				continue
+			}
+
+			wins := pairwise.preferences[proposalID][otherProposalID]
+			losses := pairwise.preferences[otherProposalID][proposalID]
+
+			if wins > losses {
+				scores[proposalID] += 2
+			} else if wins == losses {
+				scores[proposalID]++
+			}
+		}
+	}
+
+	return getMaxProposal(scores)
+}
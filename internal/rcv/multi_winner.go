@@ -0,0 +1,309 @@
+package rcv
+
+import (
+	"sort"
+)
+
+type multiWinner struct {
+	seatCount  int
+	quota      float64
+	ballots    Ballots
+	weights    []float64 // ballot index:transfer value
+	bordaCount map[string]int
+	hopefuls   map[string]struct{}
+	elected    []string
+	rounds     []Round
+	tieBreaker *tieBreaker
+}
+
+// NewMultiWinner is a Single Transferable Vote (STV) tabulator that fills
+// seatCount seats from the provided Ballots. A proposal is elected once it
+// reaches the Droop quota, and its surplus is transferred to the next
+// preference on each of its ballots at a fractional transfer value
+// (Gregory method). When no proposal reaches the quota, the proposal with
+// the fewest votes is eliminated. Ties, for election or elimination, are broken
+// with WithTieBreak, defaulting to TieBreakBorda.
+// For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Single_transferable_vote).
+func NewMultiWinner(ballots Ballots, seatCount int, opts ...Option) *multiWinner {
+	o := newOptions(opts)
+	bordaCount := calculateBordaCount(ballots)
+
+	weights := make([]float64, len(ballots))
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	return &multiWinner{
+		seatCount:  seatCount,
+		quota:      droopQuota(len(ballots), seatCount),
+		ballots:    ballots,
+		weights:    weights,
+		bordaCount: bordaCount,
+		hopefuls:   make(map[string]struct{}),
+		tieBreaker: newTieBreaker(o.tieBreak, bordaCount),
+	}
+}
+
+// droopQuota returns the minimum number of votes required to be elected.
+func droopQuota(totalVotes, seatCount int) float64 {
+	return float64(totalVotes/(seatCount+1) + 1)
+}
+
+// GetWinningProposals returns the elected proposals in the order they were
+// elected. ErrWinnerNotFound is returned if no proposal could be elected.
+func (t *multiWinner) GetWinningProposals() ([]string, error) {
+	t.initProposals()
+
+	for len(t.elected) < t.seatCount && len(t.hopefuls) > 0 {
+		counts, assignments := t.tallyVotes()
+		round := t.recordRound(counts, assignments)
+
+		if len(t.elected)+len(t.hopefuls) <= t.seatCount {
+			t.electRemainingHopefuls(counts, round)
+			break
+		}
+
+		proposalID, isElected := t.getProposalOverQuota(counts, round)
+		if isElected {
+			t.elect(proposalID, counts[proposalID], assignments, round)
+			continue
+		}
+
+		t.eliminate(counts, round)
+	}
+
+	if len(t.elected) == 0 {
+		return nil, ErrWinnerNotFound
+	}
+
+	return t.elected, nil
+}
+
+// Rounds returns the round-by-round report of the last tabulation. Each round
+// elects or eliminates a proposal, except for the last, which may elect every
+// remaining proposal.
+func (t *multiWinner) Rounds() []Round {
+	return t.rounds
+}
+
+func (t *multiWinner) initProposals() {
+	for _, proposalIDs := range t.ballots {
+		for _, proposalID := range proposalIDs {
+			t.hopefuls[proposalID] = struct{}{}
+		}
+	}
+}
+
+// tallyVotes sums the transfer value of each ballot toward its highest-ranked
+// proposal still in the running. It also returns the proposal that each
+// ballot currently counts toward, keyed by ballot index.
+func (t *multiWinner) tallyVotes() (map[string]float64, map[int]string) {
+	counts := make(map[string]float64)
+	assignments := make(map[int]string)
+
+	for proposalID := range t.hopefuls {
+		counts[proposalID] = 0
+	}
+
+	for i, rankedProposalIDs := range t.ballots {
+		if t.weights[i] == 0 {
+			continue
+		}
+
+		for _, proposalID := range rankedProposalIDs {
+			if _, ok := t.hopefuls[proposalID]; ok {
+				counts[proposalID] += t.weights[i]
+				assignments[i] = proposalID
+				break
+			}
+		}
+	}
+
+	return counts, assignments
+}
+
+// getProposalOverQuota returns the proposal with the most votes if it meets
+// the quota. A tie is broken by the tie-break policy.
+func (t *multiWinner) getProposalOverQuota(counts map[string]float64, round *Round) (string, bool) {
+	var overQuotaProposalIDs []string
+	for _, proposalID := range t.sortedHopefuls() {
+		if counts[proposalID] >= t.quota {
+			overQuotaProposalIDs = append(overQuotaProposalIDs, proposalID)
+		}
+	}
+
+	if len(overQuotaProposalIDs) == 0 {
+		return "", false
+	}
+
+	maxProposalIDs := keepMaxFloat(overQuotaProposalIDs, counts)
+	if len(maxProposalIDs) == 1 {
+		return maxProposalIDs[0], true
+	}
+
+	t.recordTie(round, maxProposalIDs)
+	return t.tieBreaker.elect(maxProposalIDs, t.previousRounds()), true
+}
+
+// eliminate removes the proposal with the fewest votes. A tie is broken by the
+// tie-break policy.
+func (t *multiWinner) eliminate(counts map[string]float64, round *Round) {
+	minProposalIDs := keepMinFloat(t.sortedHopefuls(), counts)
+
+	minProposalID := minProposalIDs[0]
+	if len(minProposalIDs) > 1 {
+		t.recordTie(round, minProposalIDs)
+		minProposalID = t.tieBreaker.eliminate(minProposalIDs, t.previousRounds())
+	}
+
+	delete(t.hopefuls, minProposalID)
+	round.EliminatedProposalID = minProposalID
+}
+
+// elect marks the proposal as elected and reduces the transfer value of each
+// of its ballots so that only the surplus above the quota carries forward.
+func (t *multiWinner) elect(proposalID string, count float64, assignments map[int]string, round *Round) {
+	transferValue := (count - t.quota) / count
+
+	for i, assignedProposalID := range assignments {
+		if assignedProposalID == proposalID {
+			t.weights[i] *= transferValue
+		}
+	}
+
+	delete(t.hopefuls, proposalID)
+	t.elected = append(t.elected, proposalID)
+
+	round.ElectedProposalIDs = []string{proposalID}
+	round.TransferValue = transferValue
+}
+
+// electRemainingHopefuls fills the remaining seats when there are no more
+// hopefuls than open seats, ordered by current votes. Proposals with the same
+// votes are ordered by the tie-break policy.
+func (t *multiWinner) electRemainingHopefuls(counts map[string]float64, round *Round) {
+	remainingProposalIDs := t.sortedHopefuls()
+
+	for len(remainingProposalIDs) > 0 {
+		maxProposalIDs := keepMaxFloat(remainingProposalIDs, counts)
+		if len(maxProposalIDs) > 1 {
+			t.recordTie(round, maxProposalIDs)
+		}
+
+		for len(maxProposalIDs) > 0 {
+			proposalID := maxProposalIDs[0]
+			if len(maxProposalIDs) > 1 {
+				proposalID = t.tieBreaker.elect(maxProposalIDs, t.previousRounds())
+			}
+
+			maxProposalIDs = without(maxProposalIDs, proposalID)
+			remainingProposalIDs = without(remainingProposalIDs, proposalID)
+
+			delete(t.hopefuls, proposalID)
+			t.elected = append(t.elected, proposalID)
+			round.ElectedProposalIDs = append(round.ElectedProposalIDs, proposalID)
+		}
+	}
+}
+
+// recordRound appends the current counts as the next Round, and returns it so
+// the round's outcome can be filled in.
+func (t *multiWinner) recordRound(counts map[string]float64, assignments map[int]string) *Round {
+	ballotCounts := make(map[string]int, len(counts))
+	for _, proposalID := range assignments {
+		ballotCounts[proposalID]++
+	}
+
+	round := Round{
+		Number: len(t.rounds) + 1,
+	}
+
+	for i := range t.ballots {
+		if _, ok := assignments[i]; !ok && t.weights[i] > 0 {
+			round.ExhaustedBallots++
+		}
+	}
+
+	for proposalID, count := range counts {
+		round.ProposalCounts = append(round.ProposalCounts, ProposalCount{
+			ProposalID:    proposalID,
+			Count:         ballotCounts[proposalID],
+			WeightedCount: count,
+		})
+	}
+
+	sort.Slice(round.ProposalCounts, func(i, j int) bool {
+		if round.ProposalCounts[i].WeightedCount == round.ProposalCounts[j].WeightedCount {
+			return round.ProposalCounts[i].ProposalID < round.ProposalCounts[j].ProposalID
+		}
+		return round.ProposalCounts[i].WeightedCount > round.ProposalCounts[j].WeightedCount
+	})
+
+	t.rounds = append(t.rounds, round)
+
+	return &t.rounds[len(t.rounds)-1]
+}
+
+// recordTie records the proposals the tie-break policy chose between in round.
+func (t *multiWinner) recordTie(round *Round, tiedProposalIDs []string) {
+	round.TiedProposalIDs = append(round.TiedProposalIDs, tiedProposalIDs...)
+	round.UsedBordaTieBreaker = t.tieBreaker.tieBreak.Policy == TieBreakBorda
+}
+
+// previousRounds returns the rounds before the current one, oldest first.
+func (t *multiWinner) previousRounds() []Round {
+	return t.rounds[:len(t.rounds)-1]
+}
+
+func (t *multiWinner) sortedHopefuls() []string {
+	proposalIDs := make([]string, 0, len(t.hopefuls))
+	for proposalID := range t.hopefuls {
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+
+	sort.Strings(proposalIDs)
+
+	return proposalIDs
+}
+
+// keepMaxFloat returns the proposals with the most votes, preserving their order.
+func keepMaxFloat(proposalIDs []string, counts map[string]float64) []string {
+	var maxProposalIDs []string
+
+	for _, proposalID := range proposalIDs {
+		switch {
+		case len(maxProposalIDs) == 0 || counts[proposalID] > counts[maxProposalIDs[0]]:
+			maxProposalIDs = []string{proposalID}
+		case counts[proposalID] == counts[maxProposalIDs[0]]:
+			maxProposalIDs = append(maxProposalIDs, proposalID)
+		}
+	}
+
+	return maxProposalIDs
+}
+
+// keepMinFloat returns the proposals with the fewest votes, preserving their order.
+func keepMinFloat(proposalIDs []string, counts map[string]float64) []string {
+	var minProposalIDs []string
+
+	for _, proposalID := range proposalIDs {
+		switch {
+		case len(minProposalIDs) == 0 || counts[proposalID] < counts[minProposalIDs[0]]:
+			minProposalIDs = []string{proposalID}
+		case counts[proposalID] == counts[minProposalIDs[0]]:
+			minProposalIDs = append(minProposalIDs, proposalID)
+		}
+	}
+
+	return minProposalIDs
+}
+
+func without(proposalIDs []string, removedProposalID string) []string {
+	remainingProposalIDs := make([]string, 0, len(proposalIDs))
+	for _, proposalID := range proposalIDs {
+		if proposalID != removedProposalID {
+			remainingProposalIDs = append(remainingProposalIDs, proposalID)
+		}
+	}
+	return remainingProposalIDs
+}
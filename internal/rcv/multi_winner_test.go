@@ -0,0 +1,238 @@
+package rcv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/rcv"
+)
+
+func TestMultiWinner(t *testing.T) {
+	const (
+		Oranges     = "Oranges"
+		Pears       = "Pears"
+		Chocolate   = "Chocolate"
+		Strawberry  = "Strawberry"
+		Sweets      = "Sweets"
+		Hamburger   = "Hamburger"
+		Chicken     = "Chicken"
+		Cauliflower = "Cauliflower"
+	)
+
+	tests := []struct {
+		name      string
+		ballots   rcv.Ballots
+		seatCount int
+		winners   []string
+	}{
+		{
+			name: "1 seat, 1 ballot",
+			ballots: rcv.Ballots{
+				{A, B},
+			},
+			seatCount: 1,
+			winners:   []string{A},
+		},
+		{
+			name: "fewer proposals than seats",
+			ballots: rcv.Ballots{
+				{A},
+				{B},
+			},
+			seatCount: 3,
+			winners:   []string{A, B},
+		},
+		{
+			// https://en.wikipedia.org/wiki/Single_transferable_vote#Example
+			name: "3 seats with surplus transfer",
+			ballots: rcv.Ballots{
+				{Oranges},
+				{Oranges},
+				{Oranges},
+				{Oranges},
+				{Pears, Oranges},
+				{Pears, Oranges},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Strawberry},
+				{Chocolate, Sweets},
+				{Chocolate, Sweets},
+				{Chocolate, Sweets},
+				{Chocolate, Sweets},
+				{Strawberry},
+				{Sweets},
+			},
+			seatCount: 3,
+			winners:   []string{Chocolate, Oranges, Strawberry},
+		},
+		{
+			name: "2 seats with elimination",
+			ballots: rcv.Ballots{
+				{Hamburger, Chicken},
+				{Hamburger, Chicken},
+				{Hamburger, Chicken},
+				{Chicken, Hamburger},
+				{Chicken, Hamburger},
+				{Cauliflower, Chicken},
+				{Cauliflower, Chicken},
+				{Sweets, Chicken},
+			},
+			seatCount: 2,
+			winners:   []string{Hamburger, Chicken},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			tabulator := rcv.NewMultiWinner(tc.ballots, tc.seatCount)
+
+			// When
+			winningProposalIDs, err := tabulator.GetWinningProposals()
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tc.winners, winningProposalIDs)
+		})
+	}
+}
+
+func TestMultiWinner_WinnerNotFound(t *testing.T) {
+	// Given
+	tabulator := rcv.NewMultiWinner(rcv.Ballots{}, 3)
+
+	// When
+	winningProposalIDs, err := tabulator.GetWinningProposals()
+
+	// Then
+	assert.Equal(t, rcv.ErrWinnerNotFound, err)
+	assert.Empty(t, winningProposalIDs)
+}
+
+func TestMultiWinner_Rounds(t *testing.T) {
+	// Given
+	ballots := rcv.Ballots{
+		{A, C},
+		{A, C},
+		{A, C},
+		{A, C},
+		{B},
+		{B},
+		{C},
+	}
+	tabulator := rcv.NewMultiWinner(ballots, 2)
+
+	// When
+	winningProposalIDs, err := tabulator.GetWinningProposals()
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{A, C}, winningProposalIDs)
+	assert.Equal(t, []rcv.Round{
+		{
+			Number: 1,
+			ProposalCounts: []rcv.ProposalCount{
+				{ProposalID: A, Count: 4, WeightedCount: 4},
+				{ProposalID: B, Count: 2, WeightedCount: 2},
+				{ProposalID: C, Count: 1, WeightedCount: 1},
+			},
+			ElectedProposalIDs: []string{A},
+			TransferValue:      0.25,
+		},
+		{
+			Number: 2,
+			ProposalCounts: []rcv.ProposalCount{
+				{ProposalID: B, Count: 2, WeightedCount: 2},
+				{ProposalID: C, Count: 5, WeightedCount: 2},
+			},
+			EliminatedProposalID: B,
+			UsedBordaTieBreaker:  true,
+			TiedProposalIDs:      []string{B, C},
+		},
+		{
+			Number: 3,
+			ProposalCounts: []rcv.ProposalCount{
+				{ProposalID: C, Count: 5, WeightedCount: 2},
+			},
+			ElectedProposalIDs: []string{C},
+			ExhaustedBallots:   2,
+		},
+	}, tabulator.Rounds())
+}
+
+func TestMultiWinner_TieBreak(t *testing.T) {
+	ballots := rcv.Ballots{
+		{A},
+		{B},
+		{C},
+	}
+
+	tests := []struct {
+		name       string
+		tieBreak   rcv.TieBreak
+		eliminated string
+		winners    []string
+	}{
+		{
+			name: "lexicographic",
+			tieBreak: rcv.TieBreak{
+				Policy: rcv.TieBreakLexicographic,
+			},
+			eliminated: A,
+			winners:    []string{B, C},
+		},
+		{
+			name: "earliest proposal",
+			tieBreak: rcv.TieBreak{
+				Policy:     rcv.TieBreakEarliestProposal,
+				ProposedAt: map[string]int{A: 1, B: 3, C: 2},
+			},
+			eliminated: B,
+			winners:    []string{A, C},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			tabulator := rcv.NewMultiWinner(ballots, 2, rcv.WithTieBreak(tc.tieBreak))
+
+			// When
+			winningProposalIDs, err := tabulator.GetWinningProposals()
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tc.winners, winningProposalIDs)
+			rounds := tabulator.Rounds()
+			require.Len(t, rounds, 2)
+			assert.Equal(t, []string{A, B, C}, rounds[0].TiedProposalIDs)
+			assert.Equal(t, tc.eliminated, rounds[0].EliminatedProposalID)
+			assert.Equal(t, tc.winners, rounds[1].TiedProposalIDs)
+			assert.Equal(t, tc.winners, rounds[1].ElectedProposalIDs)
+		})
+	}
+
+	t.Run("random draw is reproducible with the same seed", func(t *testing.T) {
+		// Given
+		tieBreak := rcv.TieBreak{
+			Policy: rcv.TieBreakRandom,
+			Seed:   42,
+		}
+
+		// When
+		winningProposalIDs1, err := rcv.NewMultiWinner(ballots, 2, rcv.WithTieBreak(tieBreak)).GetWinningProposals()
+		require.NoError(t, err)
+		winningProposalIDs2, err := rcv.NewMultiWinner(ballots, 2, rcv.WithTieBreak(tieBreak)).GetWinningProposals()
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, winningProposalIDs1, winningProposalIDs2)
+	})
+}
@@ -0,0 +1,57 @@
+package rcv
+
+import (
+	"sort"
+)
+
+// pairwisePreferences holds the number of ballots that prefer one proposal
+// over another for every pair of proposals.
+type pairwisePreferences struct {
+	proposalIDs []string
+	preferences map[string]map[string]int // proposalID:proposalID:count
+}
+
+// calculatePairwisePreferences counts head-to-head preferences. A ranked
+// proposal is preferred over every proposal ranked below it, and over every
+// proposal left off the ballot.
+func calculatePairwisePreferences(ballots Ballots) pairwisePreferences {
+	proposalSet := make(map[string]struct{})
+	for _, rankedProposalIDs := range ballots {
+		for _, proposalID := range rankedProposalIDs {
+			proposalSet[proposalID] = struct{}{}
+		}
+	}
+
+	proposalIDs := make([]string, 0, len(proposalSet))
+	for proposalID := range proposalSet {
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+	sort.Strings(proposalIDs)
+
+	preferences := make(map[string]map[string]int)
+	for _, proposalID := range proposalIDs {
+		preferences[proposalID] = make(map[string]int)
+	}
+
+	for _, rankedProposalIDs := range ballots {
+		ranked := make(map[string]struct{})
+
+		for _, proposalID := range rankedProposalIDs {
+			if _, ok := ranked[proposalID]; ok {
+				continue
+			}
+			ranked[proposalID] = struct{}{}
+
+			for _, otherProposalID := range proposalIDs {
+				if _, ok := ranked[otherProposalID]; !ok {
+					preferences[proposalID][otherProposalID]++
+				}
+			}
+		}
+	}
+
+	return pairwisePreferences{
+		proposalIDs: proposalIDs,
+		preferences: preferences,
+	}
+}
@@ -0,0 +1,28 @@
+package rcv
+
+type plurality struct {
+	ballots Ballots
+}
+
+// NewPlurality is a first-past-the-post tabulator. Only the first choice on each
+// ballot is counted, and the proposal with the most first choices wins.
+// For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Plurality_voting).
+func NewPlurality(ballots Ballots) *plurality {
+	return &plurality{
+		ballots: ballots,
+	}
+}
+
+// GetWinningProposal returns the proposal with the most first choices.
+// ErrTie is returned if the most first choices are tied.
+func (t *plurality) GetWinningProposal() (string, error) {
+	firstChoiceCount := make(map[string]int)
+
+	for _, rankedProposalIDs := range t.ballots {
+		if len(rankedProposalIDs) > 0 {
+			firstChoiceCount[rankedProposalIDs[0]]++
+		}
+	}
+
+	return getMaxProposal(firstChoiceCount)
+}
@@ -0,0 +1,86 @@
+package rcv
+
+type schulze struct {
+	ballots Ballots
+}
+
+// NewSchulze is a Condorcet tabulator using the Schulze method. Pairwise
+// preferences between proposals form a graph, and the proposal whose
+// strongest path beats or ties every other proposal's strongest path wins.
+// For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Schulze_method).
+func NewSchulze(ballots Ballots) *schulze {
+	return &schulze{
+		ballots: ballots,
+	}
+}
+
+// GetWinningProposal returns the Schulze winner.
+// ErrTie is returned if there is no unique winner.
+func (t *schulze) GetWinningProposal() (string, error) {
+	pairwise := calculatePairwisePreferences(t.ballots)
+	strongestPaths := t.getStrongestPaths(pairwise)
+
+	var winners []string
+	for _, proposalID := range pairwise.proposalIDs {
+		isWinner := true
+		for _, otherProposalID := range pairwise.proposalIDs {
+			if proposalID == otherProposalID {
+				continue
+			}
+
+			if strongestPaths[proposalID][otherProposalID] < strongestPaths[otherProposalID][proposalID] {
+				isWinner = false
+				break
+			}
+		}
+
+		if isWinner {
+			winners = append(winners, proposalID)
+		}
+	}
+
+	if len(winners) > 1 {
+		return "", ErrTie
+	}
+
+	if len(winners) == 0 {
+		return "", ErrWinnerNotFound
+	}
+
+	return winners[0], nil
+}
+
+// getStrongestPaths computes the widest path between every pair of proposals
+// using a variant of the Floyd–Warshall algorithm.
+func (t *schulze) getStrongestPaths(pairwise pairwisePreferences) map[string]map[string]int {
+	proposalIDs := pairwise.proposalIDs
+	d := pairwise.preferences
+
+	p := make(map[string]map[string]int)
+	for _, i := range proposalIDs {
+		p[i] = make(map[string]int)
+		for _, j := range proposalIDs {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+
+	for _, i := range proposalIDs {
+		for _, j := range proposalIDs {
+			if i == j {
+				continue
+			}
+
+			for _, k := range proposalIDs {
+				if i == k || j == k {
+					continue
+				}
+
+				p[j][k] = max(p[j][k], min(p[j][i], p[i][k]))
+			}
+		}
+	}
+
+	return p
+}
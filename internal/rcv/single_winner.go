@@ -2,6 +2,7 @@ package rcv
 
 import (
 	"fmt"
+	"sort"
 )
 
 // Ballots A 2D slice representing the ranked choices of each voter.
@@ -9,12 +10,41 @@ import (
 // where the first element is the highest-ranked choice.
 type Ballots [][]string
 
+// Round is a snapshot of a single tabulation round. ProposalCounts are ordered
+// by most votes first. EliminatedProposalID is empty for the final round.
+// TiedProposalIDs lists, in sorted order, the proposals that were tied for the
+// fewest votes when the tie-break policy chose which to eliminate.
+// ElectedProposalIDs and TransferValue are only reported by multi-winner
+// tabulation: the proposals elected in the round, and the fraction of each of
+// their ballots transferred on as surplus.
+type Round struct {
+	Number               int
+	ProposalCounts       []ProposalCount
+	EliminatedProposalID string
+	ElectedProposalIDs   []string
+	TransferValue        float64
+	UsedBordaTieBreaker  bool
+	TiedProposalIDs      []string
+	ExhaustedBallots     int
+}
+
+// ProposalCount is the number of ballots counting toward a proposal in a Round.
+// WeightedCount is the sum of their transfer values, and is only reported by
+// multi-winner tabulation.
+type ProposalCount struct {
+	ProposalID    string
+	Count         int
+	WeightedCount float64
+}
+
 type singleWinner struct {
 	totalVotes    int
 	threshold     int
 	proposalCount map[string]int // proposalID:count
 	bordaCount    map[string]int // proposalID:bordaCount
 	ballots       Ballots
+	rounds        []Round
+	tieBreaker    *tieBreaker
 }
 
 // NewSingleWinner is a ranked choice vote tabulator based on the provided
@@ -22,13 +52,18 @@ type singleWinner struct {
 // considering both majority support and eliminating proposals with the least votes.
 // For more information check out [Wikipedia](https://en.wikipedia.org/wiki/Instant-runoff_voting)
 // or [FairVote](https://fairvote.org/our-reforms/ranked-choice-voting).
-func NewSingleWinner(ballots Ballots) *singleWinner {
+// Ties for elimination are broken with WithTieBreak, defaulting to TieBreakBorda.
+func NewSingleWinner(ballots Ballots, opts ...Option) *singleWinner {
+	o := newOptions(opts)
+	bordaCount := calculateBordaCount(ballots)
+
 	return &singleWinner{
 		totalVotes:    len(ballots),
 		threshold:     (len(ballots) / 2) + 1,
 		ballots:       ballots,
-		bordaCount:    calculateBordaCount(ballots),
+		bordaCount:    bordaCount,
 		proposalCount: make(map[string]int),
+		tieBreaker:    newTieBreaker(o.tieBreak, bordaCount),
 	}
 }
 
@@ -50,6 +85,7 @@ func calculateBordaCount(ballots Ballots) map[string]int {
 func (t *singleWinner) GetWinningProposal() (string, error) {
 	t.initProposals()
 	t.tallyVotes()
+	t.recordRound()
 
 	winningProposalID, isFound := t.getWinner()
 	if isFound {
@@ -60,8 +96,8 @@ func (t *singleWinner) GetWinningProposal() (string, error) {
 }
 
 func (t *singleWinner) getWinner() (string, bool) {
-	for proposalID, count := range t.proposalCount {
-		if count >= t.threshold {
+	for _, proposalID := range sortedKeys(t.proposalCount) {
+		if t.proposalCount[proposalID] >= t.threshold {
 			return proposalID, true
 		}
 	}
@@ -87,6 +123,7 @@ func (t *singleWinner) getWinnerFromRemainingProposalIDs() (string, error) {
 		t.removeMinProposal()
 		t.resetProposalCounts()
 		t.tallyVotes()
+		t.recordRound()
 
 		winningProposalID, isFound := t.getWinner()
 		if isFound {
@@ -97,36 +134,26 @@ func (t *singleWinner) getWinnerFromRemainingProposalIDs() (string, error) {
 	return "", ErrWinnerNotFound
 }
 
-// removeMinProposal removes the lowest ranked proposal. The Borda Count
-// method is used as a tiebreaker.
+// removeMinProposal removes the lowest ranked proposal. A tie is broken by the
+// tie-break policy.
 func (t *singleWinner) removeMinProposal() {
-	var minProposalIDs []string
-	var minVotes = t.totalVotes
-
-	for proposalID, count := range t.proposalCount {
-		if count < minVotes {
-			minVotes = count
-			minProposalIDs = append([]string{}, proposalID)
-		} else if count == minVotes {
-			minProposalIDs = append(minProposalIDs, proposalID)
-		}
-	}
+	minProposalIDs := keepMin(sortedKeys(t.proposalCount), func(proposalID string) int {
+		return t.proposalCount[proposalID]
+	})
 
 	minProposalID := minProposalIDs[0]
+	lastRound := &t.rounds[len(t.rounds)-1]
 
 	isATie := len(minProposalIDs) > 1
 	if isATie {
-		minBordaCount := t.bordaCount[minProposalID]
-
-		for _, proposalID := range minProposalIDs {
-			if t.bordaCount[proposalID] < minBordaCount {
-				minProposalID = proposalID
-				minBordaCount = t.bordaCount[proposalID]
-			}
-		}
+		minProposalID = t.tieBreaker.eliminate(minProposalIDs, t.rounds[:len(t.rounds)-1])
+		lastRound.TiedProposalIDs = minProposalIDs
+		lastRound.UsedBordaTieBreaker = t.tieBreaker.tieBreak.Policy == TieBreakBorda
 	}
 
 	delete(t.proposalCount, minProposalID)
+
+	lastRound.EliminatedProposalID = minProposalID
 }
 
 // tallyVotes increments the count for the next highest-ranked proposal
@@ -142,6 +169,36 @@ func (t *singleWinner) tallyVotes() {
 	}
 }
 
+// recordRound appends the current proposal counts as the next Round.
+func (t *singleWinner) recordRound() {
+	round := Round{
+		Number:           len(t.rounds) + 1,
+		ExhaustedBallots: t.totalVotes,
+	}
+
+	for proposalID, count := range t.proposalCount {
+		round.ProposalCounts = append(round.ProposalCounts, ProposalCount{
+			ProposalID: proposalID,
+			Count:      count,
+		})
+		round.ExhaustedBallots -= count
+	}
+
+	sort.Slice(round.ProposalCounts, func(i, j int) bool {
+		if round.ProposalCounts[i].Count == round.ProposalCounts[j].Count {
+			return round.ProposalCounts[i].ProposalID < round.ProposalCounts[j].ProposalID
+		}
+		return round.ProposalCounts[i].Count > round.ProposalCounts[j].Count
+	})
+
+	t.rounds = append(t.rounds, round)
+}
+
+// Rounds returns the round-by-round report of the last tabulation.
+func (t *singleWinner) Rounds() []Round {
+	return t.rounds
+}
+
 // resetProposalCounts resets all vote counts to zero.
 func (t *singleWinner) resetProposalCounts() {
 	for proposalID := range t.proposalCount {
@@ -150,3 +207,7 @@ func (t *singleWinner) resetProposalCounts() {
 }
 
 var ErrWinnerNotFound = fmt.Errorf("winner not found")
+
+// ErrTie is returned if more than one proposal is tied for the win. It wraps
+// ErrWinnerNotFound.
+var ErrTie = fmt.Errorf("%w: tied proposals", ErrWinnerNotFound)
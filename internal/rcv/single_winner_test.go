@@ -157,3 +157,159 @@ func TestSingleWinner_WinnerNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleWinner_Rounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		ballots rcv.Ballots
+		rounds  []rcv.Round
+	}{
+		{
+			name: "3 rounds",
+			ballots: rcv.Ballots{
+				{A},
+				{A},
+				{A},
+				{A},
+				{B},
+				{B},
+				{B},
+				{C, A},
+				{C, A},
+				{D, B},
+			},
+			rounds: []rcv.Round{
+				{
+					Number: 1,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: B, Count: 3},
+						{ProposalID: C, Count: 2},
+						{ProposalID: D, Count: 1},
+					},
+					EliminatedProposalID: D,
+				},
+				{
+					Number: 2,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: B, Count: 4},
+						{ProposalID: C, Count: 2},
+					},
+					EliminatedProposalID: C,
+				},
+				{
+					Number: 3,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 6},
+						{ProposalID: B, Count: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "Borda Count tiebreaker",
+			ballots: rcv.Ballots{
+				{A, B, C},
+				{A, B, C},
+				{A, B, C},
+				{A, B, C},
+				{B, C},
+				{B, D, A},
+				{D, B, A},
+				{C, B, A},
+				{C, B, A},
+				{C, B, A},
+			},
+			rounds: []rcv.Round{
+				{
+					Number: 1,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: C, Count: 3},
+						{ProposalID: B, Count: 2},
+						{ProposalID: D, Count: 1},
+					},
+					EliminatedProposalID: D,
+				},
+				{
+					Number: 2,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: B, Count: 3},
+						{ProposalID: C, Count: 3},
+					},
+					EliminatedProposalID: C,
+					UsedBordaTieBreaker:  true,
+					TiedProposalIDs:      []string{B, C},
+				},
+				{
+					Number: 3,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: B, Count: 6},
+						{ProposalID: A, Count: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "exhausted ballots",
+			ballots: rcv.Ballots{
+				{A},
+				{A},
+				{A},
+				{A},
+				{B},
+				{B},
+				{C},
+				{D, A},
+			},
+			rounds: []rcv.Round{
+				{
+					Number: 1,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: B, Count: 2},
+						{ProposalID: C, Count: 1},
+						{ProposalID: D, Count: 1},
+					},
+					EliminatedProposalID: C,
+					UsedBordaTieBreaker:  true,
+					TiedProposalIDs:      []string{C, D},
+				},
+				{
+					Number: 2,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 4},
+						{ProposalID: B, Count: 2},
+						{ProposalID: D, Count: 1},
+					},
+					EliminatedProposalID: D,
+					ExhaustedBallots:     1,
+				},
+				{
+					Number: 3,
+					ProposalCounts: []rcv.ProposalCount{
+						{ProposalID: A, Count: 5},
+						{ProposalID: B, Count: 2},
+					},
+					ExhaustedBallots: 1,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			tabulator := rcv.NewSingleWinner(tc.ballots)
+
+			// When
+			_, err := tabulator.GetWinningProposal()
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tc.rounds, tabulator.Rounds())
+		})
+	}
+}
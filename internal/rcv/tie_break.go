@@ -0,0 +1,204 @@
+package rcv
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Tie-break policies choose which proposal InstantRunoff eliminates when several
+// are tied for the fewest votes in a round. Multi-winner tabulation also uses
+// them to choose which of the proposals tied for election is elected first. Every policy is deterministic for
+// the same ballots and TieBreak, so a re-tabulation selects the same winner.
+const (
+	// TieBreakBorda eliminates the tied proposal with the lowest Borda count. A
+	// tie in Borda counts is broken by the fewest votes in the previous rounds,
+	// most recent first, and then by TieBreakLexicographic.
+	TieBreakBorda = "Borda"
+
+	// TieBreakLexicographic eliminates the tied proposal whose ProposalID sorts
+	// first.
+	TieBreakLexicographic = "Lexicographic"
+
+	// TieBreakRandom eliminates a tied proposal drawn at random. The draw is
+	// seeded with TieBreak.Seed, and made from the tied ProposalIDs in sorted
+	// order, so recording the seed makes the draw reproducible.
+	TieBreakRandom = "Random"
+
+	// TieBreakEarliestProposal eliminates the tied proposal that was proposed
+	// last, favoring the earliest ProposedAt. Proposals made at the same time are
+	// broken by TieBreakLexicographic.
+	TieBreakEarliestProposal = "EarliestProposal"
+)
+
+// TieBreakPolicies contains the names of all supported tie-break policies.
+var TieBreakPolicies = []string{
+	TieBreakBorda,
+	TieBreakLexicographic,
+	TieBreakRandom,
+	TieBreakEarliestProposal,
+}
+
+// TieBreak configures how a tie for elimination is broken. Seed is only used by
+// TieBreakRandom, and ProposedAt, keyed by ProposalID, only by
+// TieBreakEarliestProposal. An empty Policy defaults to TieBreakBorda.
+type TieBreak struct {
+	Policy     string
+	Seed       int64
+	ProposedAt map[string]int
+}
+
+// Option configures a VotingMethod. Options that do not apply to a voting method
+// are ignored.
+type Option func(*options)
+
+type options struct {
+	tieBreak TieBreak
+}
+
+// WithTieBreak sets the policy InstantRunoff and multi-winner tabulation use to
+// break ties.
+func WithTieBreak(tieBreak TieBreak) Option {
+	return func(o *options) {
+		o.tieBreak = tieBreak
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		tieBreak: TieBreak{
+			Policy: TieBreakBorda,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.tieBreak.Policy == "" {
+		o.tieBreak.Policy = TieBreakBorda
+	}
+
+	return o
+}
+
+// tieBreaker chooses which of the tied proposals to eliminate.
+type tieBreaker struct {
+	tieBreak   TieBreak
+	bordaCount map[string]int
+	random     *rand.Rand
+}
+
+func newTieBreaker(tieBreak TieBreak, bordaCount map[string]int) *tieBreaker {
+	return &tieBreaker{
+		tieBreak:   tieBreak,
+		bordaCount: bordaCount,
+		random:     rand.New(rand.NewSource(tieBreak.Seed)),
+	}
+}
+
+// eliminate returns the proposal to eliminate from tiedProposalIDs, which must be
+// sorted. previousRounds are the rounds before the current one, oldest first.
+func (b *tieBreaker) eliminate(tiedProposalIDs []string, previousRounds []Round) string {
+	switch b.tieBreak.Policy {
+	case TieBreakLexicographic:
+		return tiedProposalIDs[0]
+
+	case TieBreakRandom:
+		return tiedProposalIDs[b.random.Intn(len(tiedProposalIDs))]
+
+	case TieBreakEarliestProposal:
+		return keepMax(tiedProposalIDs, func(proposalID string) int {
+			return b.tieBreak.ProposedAt[proposalID]
+		})[0]
+	}
+
+	remainingProposalIDs := keepMin(tiedProposalIDs, func(proposalID string) int {
+		return b.bordaCount[proposalID]
+	})
+
+	for i := len(previousRounds) - 1; i >= 0 && len(remainingProposalIDs) > 1; i-- {
+		counts := previousRounds[i].counts()
+		remainingProposalIDs = keepMin(remainingProposalIDs, func(proposalID string) int {
+			return counts[proposalID]
+		})
+	}
+
+	return remainingProposalIDs[0]
+}
+
+// elect returns the proposal to elect first from tiedProposalIDs, which must be
+// sorted. It mirrors eliminate: the proposal sorting first, drawn at random,
+// proposed earliest, or with the highest Borda count and then the most votes
+// in the previous rounds.
+func (b *tieBreaker) elect(tiedProposalIDs []string, previousRounds []Round) string {
+	switch b.tieBreak.Policy {
+	case TieBreakLexicographic:
+		return tiedProposalIDs[0]
+
+	case TieBreakRandom:
+		return tiedProposalIDs[b.random.Intn(len(tiedProposalIDs))]
+
+	case TieBreakEarliestProposal:
+		return keepMin(tiedProposalIDs, func(proposalID string) int {
+			return b.tieBreak.ProposedAt[proposalID]
+		})[0]
+	}
+
+	remainingProposalIDs := keepMax(tiedProposalIDs, func(proposalID string) int {
+		return b.bordaCount[proposalID]
+	})
+
+	for i := len(previousRounds) - 1; i >= 0 && len(remainingProposalIDs) > 1; i-- {
+		counts := previousRounds[i].counts()
+		remainingProposalIDs = keepMax(remainingProposalIDs, func(proposalID string) int {
+			return counts[proposalID]
+		})
+	}
+
+	return remainingProposalIDs[0]
+}
+
+// keepMin returns the proposals with the lowest score, preserving their order.
+func keepMin(proposalIDs []string, score func(string) int) []string {
+	return keepMax(proposalIDs, func(proposalID string) int {
+		return -score(proposalID)
+	})
+}
+
+// keepMax returns the proposals with the highest score, preserving their order.
+func keepMax(proposalIDs []string, score func(string) int) []string {
+	var maxProposalIDs []string
+	var maxScore int
+
+	for _, proposalID := range proposalIDs {
+		proposalScore := score(proposalID)
+		if len(maxProposalIDs) == 0 || proposalScore > maxScore {
+			maxProposalIDs = []string{proposalID}
+			maxScore = proposalScore
+		} else if proposalScore == maxScore {
+			maxProposalIDs = append(maxProposalIDs, proposalID)
+		}
+	}
+
+	return maxProposalIDs
+}
+
+// counts returns the round's proposal counts keyed by ProposalID.
+func (r Round) counts() map[string]int {
+	counts := make(map[string]int, len(r.ProposalCounts))
+	for _, proposalCount := range r.ProposalCounts {
+		counts[proposalCount.ProposalID] = proposalCount.Count
+	}
+	return counts
+}
+
+func sortedKeys(proposalCount map[string]int) []string {
+	proposalIDs := make([]string, 0, len(proposalCount))
+	for proposalID := range proposalCount {
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+
+	sort.Strings(proposalIDs)
+
+	return proposalIDs
+}
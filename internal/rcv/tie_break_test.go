@@ -0,0 +1,139 @@
+package rcv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/rcv"
+)
+
+func TestTieBreak(t *testing.T) {
+	// C and D are tied for the fewest votes in round 1, with equal Borda counts.
+	tiedBallots := rcv.Ballots{
+		{A, B, C, D},
+		{A, B, D, C},
+		{B, A, C, D},
+		{B, A, D, C},
+		{C, D, A, B},
+		{D, C, A, B},
+	}
+
+	tests := []struct {
+		name       string
+		tieBreak   rcv.TieBreak
+		eliminated string
+	}{
+		{
+			name:       "Borda falls back to lexicographic",
+			tieBreak:   rcv.TieBreak{Policy: rcv.TieBreakBorda},
+			eliminated: C,
+		},
+		{
+			name:       "default policy is Borda",
+			tieBreak:   rcv.TieBreak{},
+			eliminated: C,
+		},
+		{
+			name:       "lexicographic",
+			tieBreak:   rcv.TieBreak{Policy: rcv.TieBreakLexicographic},
+			eliminated: C,
+		},
+		{
+			name: "earliest proposal",
+			tieBreak: rcv.TieBreak{
+				Policy:     rcv.TieBreakEarliestProposal,
+				ProposedAt: map[string]int{A: 1, B: 2, C: 4, D: 3},
+			},
+			eliminated: C,
+		},
+		{
+			name: "earliest proposal keeps the earlier of two",
+			tieBreak: rcv.TieBreak{
+				Policy:     rcv.TieBreakEarliestProposal,
+				ProposedAt: map[string]int{A: 1, B: 2, C: 3, D: 4},
+			},
+			eliminated: D,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			tabulator := rcv.NewSingleWinner(tiedBallots, rcv.WithTieBreak(tc.tieBreak))
+
+			// When
+			_, err := tabulator.GetWinningProposal()
+
+			// Then
+			require.NoError(t, err)
+			firstRound := tabulator.Rounds()[0]
+			assert.Equal(t, []string{C, D}, firstRound.TiedProposalIDs)
+			assert.Equal(t, tc.eliminated, firstRound.EliminatedProposalID)
+		})
+	}
+
+	t.Run("Borda then previous round counts", func(t *testing.T) {
+		// Given
+		// C and D are tied in round 2 with equal Borda counts, but D had fewer
+		// votes in round 1.
+		ballots := rcv.Ballots{
+			{A}, {A}, {A}, {A}, {A}, {A},
+			{B}, {B}, {B}, {B}, {B},
+			{C, A}, {C, A}, {C, A},
+			{D, A}, {D, A},
+			{E, D, A},
+		}
+		tabulator := rcv.NewSingleWinner(ballots, rcv.WithTieBreak(rcv.TieBreak{Policy: rcv.TieBreakBorda}))
+
+		// When
+		_, err := tabulator.GetWinningProposal()
+
+		// Then
+		require.NoError(t, err)
+		rounds := tabulator.Rounds()
+		assert.Equal(t, E, rounds[0].EliminatedProposalID)
+		assert.Equal(t, []string{C, D}, rounds[1].TiedProposalIDs)
+		assert.Equal(t, D, rounds[1].EliminatedProposalID)
+	})
+
+	t.Run("random draw is reproducible with the same seed", func(t *testing.T) {
+		for seed := int64(0); seed < 20; seed++ {
+			// Given
+			tieBreak := rcv.TieBreak{Policy: rcv.TieBreakRandom, Seed: seed}
+			tabulator1 := rcv.NewSingleWinner(tiedBallots, rcv.WithTieBreak(tieBreak))
+			tabulator2 := rcv.NewSingleWinner(tiedBallots, rcv.WithTieBreak(tieBreak))
+
+			// When
+			winner1, err1 := tabulator1.GetWinningProposal()
+			winner2, err2 := tabulator2.GetWinningProposal()
+
+			// Then
+			require.NoError(t, err1)
+			require.NoError(t, err2)
+			assert.Equal(t, winner1, winner2)
+			assert.Equal(t, tabulator1.Rounds(), tabulator2.Rounds())
+			assert.False(t, tabulator1.Rounds()[0].UsedBordaTieBreaker)
+		}
+	})
+
+	t.Run("random draw depends on the seed", func(t *testing.T) {
+		eliminated := make(map[string]struct{})
+
+		for seed := int64(0); seed < 20; seed++ {
+			// Given
+			tieBreak := rcv.TieBreak{Policy: rcv.TieBreakRandom, Seed: seed}
+			tabulator := rcv.NewSingleWinner(tiedBallots, rcv.WithTieBreak(tieBreak))
+
+			// When
+			_, err := tabulator.GetWinningProposal()
+
+			// Then
+			require.NoError(t, err)
+			eliminated[tabulator.Rounds()[0].EliminatedProposalID] = struct{}{}
+		}
+
+		assert.Len(t, eliminated, 2)
+	})
+}
@@ -0,0 +1,83 @@
+package rcv
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VotingMethod tabulates Ballots to select a single winning proposal.
+type VotingMethod interface {
+	GetWinningProposal() (string, error)
+}
+
+// RoundReporter is implemented by a VotingMethod that tabulates in rounds.
+type RoundReporter interface {
+	Rounds() []Round
+}
+
+const (
+	InstantRunoff = "InstantRunoff"
+	BordaCount    = "BordaCount"
+	Schulze       = "Schulze"
+	Copeland      = "Copeland"
+	Plurality     = "Plurality"
+	Approval      = "Approval"
+)
+
+// VotingMethods contains the names of all supported voting methods.
+var VotingMethods = []string{
+	InstantRunoff,
+	BordaCount,
+	Schulze,
+	Copeland,
+	Plurality,
+	Approval,
+}
+
+// NewVotingMethod returns the VotingMethod tabulator for the given name.
+// ErrUnknownVotingMethod is returned for an unsupported name.
+func NewVotingMethod(name string, ballots Ballots, opts ...Option) (VotingMethod, error) {
+	switch name {
+	case InstantRunoff:
+		return NewSingleWinner(ballots, opts...), nil
+	case BordaCount:
+		return NewBordaCount(ballots), nil
+	case Schulze:
+		return NewSchulze(ballots), nil
+	case Copeland:
+		return NewCopeland(ballots), nil
+	case Plurality:
+		return NewPlurality(ballots), nil
+	case Approval:
+		return NewApproval(ballots), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownVotingMethod, name)
+}
+
+// getMaxProposal returns the proposal with the highest score.
+// ErrWinnerNotFound is returned if there are no proposals, and ErrTie if the highest
+// score is tied.
+func getMaxProposal(scores map[string]int) (string, error) {
+	proposalIDs := make([]string, 0, len(scores))
+	for proposalID := range scores {
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+
+	sort.Slice(proposalIDs, func(i, j int) bool {
+		return scores[proposalIDs[i]] > scores[proposalIDs[j]]
+	})
+
+	if len(proposalIDs) == 0 {
+		return "", ErrWinnerNotFound
+	}
+
+	if len(proposalIDs) > 1 && scores[proposalIDs[0]] == scores[proposalIDs[1]] {
+		return "", ErrTie
+	}
+
+	return proposalIDs[0], nil
+}
+
+// ErrUnknownVotingMethod is returned for an unsupported voting method name.
+var ErrUnknownVotingMethod = fmt.Errorf("unknown voting method")
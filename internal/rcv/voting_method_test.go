@@ -0,0 +1,174 @@
+package rcv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/internal/rcv"
+)
+
+const E = "E"
+
+func TestVotingMethod(t *testing.T) {
+	var schulzeBallots rcv.Ballots
+	schulzeBallots = append(schulzeBallots, repeatBallot(5, A, C, B, E, D)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(5, A, D, E, C, B)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(8, B, E, D, A, C)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(3, C, A, B, E, D)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(7, C, A, E, B, D)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(2, C, B, A, D, E)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(7, D, C, E, B, A)...)
+	schulzeBallots = append(schulzeBallots, repeatBallot(8, E, B, A, D, C)...)
+
+	tests := []struct {
+		name         string
+		votingMethod string
+		ballots      rcv.Ballots
+		winner       string
+	}{
+		{
+			name:         "InstantRunoff",
+			votingMethod: rcv.InstantRunoff,
+			ballots: rcv.Ballots{
+				{A, B},
+				{A, C},
+				{B, A},
+				{C, A},
+			},
+			winner: A,
+		},
+		{
+			name:         "BordaCount",
+			votingMethod: rcv.BordaCount,
+			ballots: rcv.Ballots{
+				{A, B, C},
+				{A, B, C},
+				{B, C, A},
+				{B, C, A},
+				{C, B, A},
+			},
+			winner: B,
+		},
+		{
+			name:         "Schulze",
+			votingMethod: rcv.Schulze,
+			ballots:      schulzeBallots,
+			winner:       E,
+		},
+		{
+			name:         "Schulze Condorcet winner",
+			votingMethod: rcv.Schulze,
+			ballots: rcv.Ballots{
+				{A, B, C},
+				{A, B, C},
+				{B, A, C},
+				{B, A, C},
+				{C, A, B},
+			},
+			winner: A,
+		},
+		{
+			name:         "Copeland",
+			votingMethod: rcv.Copeland,
+			ballots: rcv.Ballots{
+				{A, B, C},
+				{A, B, C},
+				{B, A, C},
+				{B, A, C},
+				{C, A, B},
+			},
+			winner: A,
+		},
+		{
+			name:         "Copeland unranked proposals lose head-to-head",
+			votingMethod: rcv.Copeland,
+			ballots: rcv.Ballots{
+				{B},
+				{B},
+				{A, C},
+			},
+			winner: B,
+		},
+		{
+			name:         "Plurality",
+			votingMethod: rcv.Plurality,
+			ballots: rcv.Ballots{
+				{A, B},
+				{A},
+				{B, C},
+				{C, B},
+				{A},
+			},
+			winner: A,
+		},
+		{
+			name:         "Approval",
+			votingMethod: rcv.Approval,
+			ballots: rcv.Ballots{
+				{A, B},
+				{B},
+				{B, C},
+				{A},
+			},
+			winner: B,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			votingMethod, err := rcv.NewVotingMethod(tt.votingMethod, tt.ballots)
+			require.NoError(t, err)
+
+			// When
+			winner, err := votingMethod.GetWinningProposal()
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.winner, winner)
+		})
+	}
+
+	t.Run("errors when there is a tie", func(t *testing.T) {
+		for _, name := range rcv.VotingMethods {
+			if name == rcv.InstantRunoff {
+				continue
+			}
+
+			t.Run(name, func(t *testing.T) {
+				// Given
+				ballots := rcv.Ballots{
+					{A, B},
+					{B, A},
+				}
+				votingMethod, err := rcv.NewVotingMethod(name, ballots)
+				require.NoError(t, err)
+
+				// When
+				_, err = votingMethod.GetWinningProposal()
+
+				// Then
+				assert.Equal(t, rcv.ErrTie, err)
+				assert.ErrorIs(t, err, rcv.ErrWinnerNotFound)
+			})
+		}
+	})
+
+	t.Run("errors with unknown voting method", func(t *testing.T) {
+		// When
+		_, err := rcv.NewVotingMethod("Unknown", rcv.Ballots{{A}})
+
+		// Then
+		require.ErrorIs(t, err, rcv.ErrUnknownVotingMethod)
+	})
+}
+
+// repeatBallot returns count copies of a ballot with the ranked proposals.
+func repeatBallot(count int, rankedProposalIDs ...string) rcv.Ballots {
+	ballots := make(rcv.Ballots, count)
+	for i := range ballots {
+		ballots[i] = rankedProposalIDs
+	}
+	return ballots
+}
@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/pkg/clock"
+	"go.opentelemetry.io/otel"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+)
+
+const instrumentationName = "github.com/inklabs/vote/internal/scheduler"
+
+var tracer = otel.Tracer(instrumentationName)
+
+// AsyncCommandEnqueuer is satisfied by cqrs.AsyncCommandBus.
+type AsyncCommandEnqueuer interface {
+	Enqueue(ctx context.Context, command cqrs.AsyncCommand) (cqrs.AsyncCommandResponse, error)
+}
+
+// AsyncCommandStatusGetter is satisfied by cqrs.AsyncCommandStore.
+type AsyncCommandStatusGetter interface {
+	GetAsyncCommandStatus(ctx context.Context, id string) (*cqrs.AsyncCommandStatus, error)
+}
+
+// Scheduler periodically closes elections whose VotingEndsAt has passed. The
+// schedule is read from the repository on every tick, so pending closures
+// survive restarts when a persistent repository is in use.
+type Scheduler struct {
+	repository        electionrepository.Repository
+	asyncCommandBus   AsyncCommandEnqueuer
+	asyncCommandStore AsyncCommandStatusGetter
+	clock             clock.Clock
+	interval          time.Duration
+	logger            *log.Logger
+
+	mux     sync.Mutex
+	pending map[string]string // electionID -> async command ID
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func New(
+	repository electionrepository.Repository,
+	asyncCommandBus AsyncCommandEnqueuer,
+	asyncCommandStore AsyncCommandStatusGetter,
+	clock clock.Clock,
+	interval time.Duration,
+	logger *log.Logger,
+) *Scheduler {
+	return &Scheduler{
+		repository:        repository,
+		asyncCommandBus:   asyncCommandBus,
+		asyncCommandStore: asyncCommandStore,
+		clock:             clock,
+		interval:          interval,
+		logger:            logger,
+		pending:           make(map[string]string),
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler in the background until Stop is called.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				err := s.CloseEndedElections(context.Background())
+				if err != nil {
+					s.logger.Printf("unable to close ended elections: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop waits for the current tick to finish and stops the scheduler.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// CloseEndedElections enqueues CloseElectionByOwner for every open election
+// whose voting window has ended. Elections already enqueued by this scheduler
+// are skipped while their command is still running, and enqueued again once
+// that command has failed.
+func (s *Scheduler) CloseEndedElections(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "scheduler.close-ended-elections")
+	defer span.End()
+
+	now := int(s.clock.Now().Unix())
+
+	elections, err := s.repository.ListElectionsToClose(ctx, now)
+	if err != nil {
+		cqrs.RecordSpanError(span, err)
+		return err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	toClose := make(map[string]struct{}, len(elections))
+	for _, e := range elections {
+		toClose[e.ElectionID] = struct{}{}
+	}
+
+	for electionID, commandID := range s.pending {
+		if _, ok := toClose[electionID]; !ok {
+			delete(s.pending, electionID)
+			continue
+		}
+
+		if s.hasFailed(ctx, commandID) {
+			delete(s.pending, electionID)
+		}
+	}
+
+	systemCtx := authorization.NewSystemContext(ctx)
+
+	for _, e := range elections {
+		if _, ok := s.pending[e.ElectionID]; ok {
+			continue
+		}
+
+		commandID := uuid.NewString()
+		_, err = s.asyncCommandBus.Enqueue(systemCtx, election.CloseElectionByOwner{
+			ID:         commandID,
+			ElectionID: e.ElectionID,
+		})
+		if err != nil {
+			s.logger.Printf("unable to close election %s: %v", e.ElectionID, err)
+			continue
+		}
+
+		s.pending[e.ElectionID] = commandID
+	}
+
+	return nil
+}
+
+// hasFailed reports whether the async command finished without success. A
+// command whose status cannot be read is treated as failed so the close is
+// retried rather than skipped forever.
+func (s *Scheduler) hasFailed(ctx context.Context, commandID string) bool {
+	status, err := s.asyncCommandStore.GetAsyncCommandStatus(ctx, commandID)
+	if err != nil {
+		s.logger.Printf("unable to get status of close command %s: %v", commandID, err)
+		return true
+	}
+
+	return status.IsFinished && !status.IsSuccess
+}
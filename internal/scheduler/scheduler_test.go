@@ -0,0 +1,174 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inklabs/cqrs"
+	"github.com/inklabs/cqrs/cqrstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/authorization"
+	"github.com/inklabs/vote/internal/electionrepository"
+	"github.com/inklabs/vote/internal/electionrepository/inmemoryrepo"
+	"github.com/inklabs/vote/internal/scheduler"
+)
+
+func TestScheduler_CloseEndedElections(t *testing.T) {
+	t.Run("enqueues close for elections whose voting has ended", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		repository := inmemoryrepo.New()
+		enqueuer := &recordingEnqueuer{}
+		clock := fixedClock{now: time.Unix(100, 0)}
+		s := scheduler.New(repository, enqueuer, enqueuer, clock, time.Minute, log.Default())
+
+		const (
+			endedElectionID   = "b3a1f2c4-5d6e-4f70-8a9b-0c1d2e3f4a5b"
+			openElectionID    = "c4b2a3d5-6e7f-4a81-9b0c-1d2e3f4a5b6c"
+			closedElectionID  = "d5c3b4e6-7f8a-4b92-8c1d-2e3f4a5b6c7d"
+			noEndElectionID   = "e6d4c5f7-8a9b-4ca3-9d2e-3f4a5b6c7d8e"
+			organizerUserID   = "f7e5d6a8-9b0c-4db4-8e3f-4a5b6c7d8e9f"
+			votingEndedAt     = 50
+			votingNotEndedAt  = 500
+			closedVotingEnded = 40
+		)
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      endedElectionID,
+			OrganizerUserID: organizerUserID,
+			VotingEndsAt:    votingEndedAt,
+		}))
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      openElectionID,
+			OrganizerUserID: organizerUserID,
+			VotingEndsAt:    votingNotEndedAt,
+		}))
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      closedElectionID,
+			OrganizerUserID: organizerUserID,
+			VotingEndsAt:    closedVotingEnded,
+			IsClosed:        true,
+		}))
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      noEndElectionID,
+			OrganizerUserID: organizerUserID,
+		}))
+
+		// When
+		err := s.CloseEndedElections(ctx)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, enqueuer.commands, 1)
+		command := enqueuer.commands[0].(election.CloseElectionByOwner)
+		assert.Equal(t, endedElectionID, command.ElectionID)
+		assert.NotEmpty(t, command.ID)
+		assert.True(t, enqueuer.isSystemContext)
+	})
+
+	t.Run("does not enqueue the same election twice while pending", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		repository := inmemoryrepo.New()
+		enqueuer := &recordingEnqueuer{}
+		clock := fixedClock{now: time.Unix(100, 0)}
+		s := scheduler.New(repository, enqueuer, enqueuer, clock, time.Minute, log.Default())
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      "a8f6e7b9-0c1d-4ec5-9f4a-5b6c7d8e9fa0",
+			OrganizerUserID: "f7e5d6a8-9b0c-4db4-8e3f-4a5b6c7d8e9f",
+			VotingEndsAt:    50,
+		}))
+		require.NoError(t, s.CloseEndedElections(ctx))
+
+		// When
+		err := s.CloseEndedElections(ctx)
+
+		// Then
+		require.NoError(t, err)
+		assert.Len(t, enqueuer.commands, 1)
+	})
+
+	t.Run("enqueues the election again after the close command failed", func(t *testing.T) {
+		// Given
+		ctx := cqrstest.TimeoutContext(t)
+		repository := inmemoryrepo.New()
+		enqueuer := &recordingEnqueuer{}
+		clock := fixedClock{now: time.Unix(100, 0)}
+		s := scheduler.New(repository, enqueuer, enqueuer, clock, time.Minute, log.Default())
+		require.NoError(t, repository.SaveElection(ctx, electionrepository.Election{
+			ElectionID:      "a8f6e7b9-0c1d-4ec5-9f4a-5b6c7d8e9fa0",
+			OrganizerUserID: "f7e5d6a8-9b0c-4db4-8e3f-4a5b6c7d8e9f",
+			VotingEndsAt:    50,
+		}))
+		require.NoError(t, s.CloseEndedElections(ctx))
+		enqueuer.finish(enqueuer.commands[0].(election.CloseElectionByOwner).ID, false)
+
+		// When
+		err := s.CloseEndedElections(ctx)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, enqueuer.commands, 2)
+		first := enqueuer.commands[0].(election.CloseElectionByOwner)
+		second := enqueuer.commands[1].(election.CloseElectionByOwner)
+		assert.Equal(t, first.ElectionID, second.ElectionID)
+		assert.NotEqual(t, first.ID, second.ID)
+	})
+}
+
+type recordingEnqueuer struct {
+	mux             sync.Mutex
+	commands        []cqrs.AsyncCommand
+	statuses        map[string]*cqrs.AsyncCommandStatus
+	isSystemContext bool
+}
+
+func (r *recordingEnqueuer) Enqueue(ctx context.Context, command cqrs.AsyncCommand) (cqrs.AsyncCommandResponse, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.commands = append(r.commands, command)
+	r.isSystemContext = authorization.IsSystemContext(ctx)
+
+	if r.statuses == nil {
+		r.statuses = make(map[string]*cqrs.AsyncCommandStatus)
+	}
+	id := command.(election.CloseElectionByOwner).ID
+	r.statuses[id] = &cqrs.AsyncCommandStatus{}
+
+	return cqrs.AsyncCommandResponse{ID: id, HasBeenQueued: true}, nil
+}
+
+func (r *recordingEnqueuer) GetAsyncCommandStatus(_ context.Context, id string) (*cqrs.AsyncCommandStatus, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	status, ok := r.statuses[id]
+	if !ok {
+		return nil, fmt.Errorf("async command %s not found", id)
+	}
+
+	return status, nil
+}
+
+func (r *recordingEnqueuer) finish(id string, isSuccess bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.statuses[id].IsFinished = true
+	r.statuses[id].IsSuccess = isSuccess
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
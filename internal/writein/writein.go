@@ -0,0 +1,44 @@
+// Package writein identifies write-in candidates on a ballot. A write-in is
+// ranked in place of a ProposalID as Prefix followed by the label the voter wrote,
+// such as "write-in:Jane Doe". Labels that differ only in case or spacing are
+// the same candidate.
+package writein
+
+import (
+	"strings"
+)
+
+// Prefix marks a ranked choice as a write-in label rather than a ProposalID.
+const Prefix = "write-in:"
+
+// New returns the ranked choice for a write-in label.
+func New(label string) string {
+	return Prefix + label
+}
+
+// Is reports whether a ranked choice is a write-in.
+func Is(rankedChoice string) bool {
+	return strings.HasPrefix(rankedChoice, Prefix)
+}
+
+// Label returns the normalized label of a write-in: lowercase, without leading,
+// trailing, or repeated spaces. The label of a ProposalID is empty.
+func Label(rankedChoice string) string {
+	if !Is(rankedChoice) {
+		return ""
+	}
+
+	label := strings.TrimPrefix(rankedChoice, Prefix)
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// Normalize returns the candidate a ranked choice counts toward. Write-ins with
+// the same normalized label count toward the same candidate, and ProposalIDs are
+// returned unchanged.
+func Normalize(rankedChoice string) string {
+	if !Is(rankedChoice) {
+		return rankedChoice
+	}
+
+	return New(Label(rankedChoice))
+}
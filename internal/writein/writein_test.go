@@ -0,0 +1,45 @@
+package writein_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inklabs/vote/internal/writein"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		rankedChoice string
+		want         string
+	}{
+		{
+			name:         "write-in",
+			rankedChoice: "write-in:jane doe",
+			want:         "write-in:jane doe",
+		},
+		{
+			name:         "write-in with case and spacing",
+			rankedChoice: "write-in:  Jane   DOE ",
+			want:         "write-in:jane doe",
+		},
+		{
+			name:         "proposal",
+			rankedChoice: "35d414ea-4b5f-430a-9f57-ef48bce34ef2",
+			want:         "35d414ea-4b5f-430a-9f57-ef48bce34ef2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, writein.Normalize(tt.rankedChoice))
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "jane doe", writein.Label(writein.New(" Jane Doe")))
+	assert.Equal(t, "", writein.Label(writein.New("   ")))
+	assert.Equal(t, "", writein.Label("35d414ea-4b5f-430a-9f57-ef48bce34ef2"))
+}
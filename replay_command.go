@@ -0,0 +1,58 @@
+package vote
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/inklabs/vote/internal/projection"
+)
+
+// NewReplayCommand returns the `replay` CLI command, which drops the election
+// read models and rebuilds them by replaying every event in the event store.
+// It runs in-process, so it is intended for local and operator use. The
+// RebuildElectionProjections async command does the same through the API.
+func NewReplayCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay",
+		Short: "Rebuild read models by replaying the event store",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			progress := &replayProgress{out: cmd.OutOrStdout()}
+
+			err := projection.Replay(
+				cmd.Context(),
+				a.eventStore,
+				progress,
+				projection.NewElectionProjection(a.electionRepository),
+			)
+			if err != nil {
+				return fmt.Errorf("unable to replay events: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(progress.out, "Replayed %d elections\n", progress.totalProcessed)
+
+			return nil
+		},
+	}
+}
+
+type replayProgress struct {
+	out            io.Writer
+	totalToProcess int
+	totalProcessed int
+}
+
+func (p *replayProgress) SetTotalToProcess(totalToProcess int) {
+	p.totalToProcess = totalToProcess
+	_, _ = fmt.Fprintf(p.out, "Replaying events for %d elections\n", totalToProcess)
+}
+
+func (p *replayProgress) IncrementTotalProcessed() {
+	p.totalProcessed++
+
+	if p.totalToProcess < 10 || p.totalProcessed%(p.totalToProcess/10) == 0 {
+		_, _ = fmt.Fprintf(p.out, "%d/%d\n", p.totalProcessed, p.totalToProcess)
+	}
+}
@@ -92,7 +92,7 @@ func ExampleApp_grpcCloseElectionByOwner() {
 	defer done()
 	client := goclient.NewClient(conn)
 
-	recordingEventDispatcher.Add(4)
+	recordingEventDispatcher.Add(5)
 
 	_, _ = client.Election.CommenceElection(ctx, &electionpb.CommenceElectionRequest{
 		ElectionId:      "E1",
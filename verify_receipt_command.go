@@ -0,0 +1,87 @@
+package vote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/inklabs/vote/action/election"
+	"github.com/inklabs/vote/internal/ballotreceipt"
+)
+
+// NewVerifyReceiptCommand returns the `verify-receipt` CLI command, which checks
+// a VerifyBallotReceipt response offline. The proof is checked against the
+// --root published with the election results, so a voter does not have to
+// trust the server that produced the proof. Passing the ballot's VoteID,
+// ElectionID, and ranked proposals also checks that the receipt commits to it.
+func NewVerifyReceiptCommand() *cobra.Command {
+	var (
+		root              string
+		voteID            string
+		electionID        string
+		rankedProposalIDs []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify-receipt <proof.json>",
+		Short: "Verify a ballot receipt's inclusion proof offline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read proof: %w", err)
+			}
+
+			var response election.VerifyBallotReceiptResponse
+			err = json.Unmarshal(data, &response)
+			if err != nil {
+				return fmt.Errorf("unable to decode proof: %w", err)
+			}
+
+			if root == "" {
+				root = response.ReceiptRoot
+			}
+
+			if root != response.ReceiptRoot {
+				return fmt.Errorf("proof is for root %s, not %s", response.ReceiptRoot, root)
+			}
+
+			if voteID != "" {
+				if electionID == "" {
+					electionID = response.ElectionID
+				}
+
+				receipt := ballotreceipt.Receipt(voteID, electionID, rankedProposalIDs)
+				if receipt != response.Receipt {
+					return fmt.Errorf("ballot does not match receipt %s", response.Receipt)
+				}
+			}
+
+			proof := make([]ballotreceipt.ProofStep, len(response.Proof))
+			for i, step := range response.Proof {
+				proof[i] = ballotreceipt.ProofStep{
+					Hash:   step.Hash,
+					IsLeft: step.IsLeft,
+				}
+			}
+
+			err = ballotreceipt.Verify(response.Receipt, proof, root)
+			if err != nil {
+				return fmt.Errorf("unable to verify receipt: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Receipt %s is included in root %s\n", response.Receipt, root)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&root, "root", "", "receipt root published with the election results")
+	cmd.Flags().StringVar(&voteID, "vote-id", "", "VoteID of the ballot to check against the receipt")
+	cmd.Flags().StringVar(&electionID, "election-id", "", "ElectionID of the ballot, defaults to the proof's ElectionID")
+	cmd.Flags().StringSliceVar(&rankedProposalIDs, "ranked-proposal-ids", nil, "ranked proposals of the ballot, in order")
+
+	return cmd
+}
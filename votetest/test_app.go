@@ -18,6 +18,9 @@ import (
 	"github.com/inklabs/vote/internal/electionrepository"
 	"github.com/inklabs/vote/internal/electionrepository/inmemoryrepo"
 	"github.com/inklabs/vote/internal/electionrepository/postgresrepo"
+	"github.com/inklabs/vote/internal/eventstore"
+	"github.com/inklabs/vote/internal/eventstore/inmemorystore"
+	"github.com/inklabs/vote/internal/eventstore/postgresstore"
 )
 
 type testApp struct {
@@ -26,13 +29,24 @@ type testApp struct {
 
 	EventDispatcher    cqrstest.RecordingEventDispatcher
 	ElectionRepository electionrepository.Repository
+	EventStore         eventstore.Store
 	AsyncCommandStore  cqrs.AsyncCommandStore
 	jwtSigningKey      []byte
 	RegularUserID      string
 	AdminUserID        string
 }
 
-func NewTestApp(t *testing.T) testApp {
+type TestAppOption func(*testApp)
+
+// WithElectionRepositoryDecorator wraps the election repository, such as to
+// simulate data edited directly in the database.
+func WithElectionRepositoryDecorator(decorate func(electionrepository.Repository) electionrepository.Repository) TestAppOption {
+	return func(a *testApp) {
+		a.ElectionRepository = decorate(a.ElectionRepository)
+	}
+}
+
+func NewTestApp(t *testing.T, opts ...TestAppOption) testApp {
 	t.Helper()
 
 	a := testApp{
@@ -52,19 +66,33 @@ func NewTestApp(t *testing.T) testApp {
 		ctx := cqrstest.TimeoutContext(t)
 		require.NoError(t, repository.InitDB(ctx))
 
+		eventStore := postgresstore.NewFromDB(db, eventstore.NewElectionCodec())
+		require.NoError(t, eventStore.InitDB(ctx))
+
 		truncateTables(t, db)
 
 		a.ElectionRepository = repository
+		a.EventStore = eventStore
 	} else {
 		a.ElectionRepository = inmemoryrepo.New()
+		a.EventStore = inmemorystore.New()
+	}
+
+	for _, opt := range opts {
+		opt(&a)
 	}
 
 	a.app = vote.NewApp(
 		vote.WithEventDispatcher(a.EventDispatcher),
-		vote.WithAuthorization(authorization.NewJWTAuthorization(a.jwtSigningKey)),
+		vote.WithAuthorization(authorization.NewPolicyAuthorization(
+			authorization.NewJWTAuthorization(a.jwtSigningKey),
+			authorization.DefaultPolicy(),
+			authorization.NewElectionRoleProvider(a.ElectionRepository),
+		)),
 		vote.WithClock(incrementingclock.NewFromZero()),
 		vote.WithAsyncCommandStore(a.AsyncCommandStore),
 		vote.WithElectionRepository(a.ElectionRepository),
+		vote.WithEventStore(a.EventStore),
 	)
 
 	return a
@@ -86,6 +114,10 @@ func (a *testApp) GetAuthenticatedUserContext() context.Context {
 	return context.WithValue(cqrstest.TimeoutContext(a.t), "authorization", a.getUserToken())
 }
 
+func (a *testApp) GetAuthenticatedAdminContext() context.Context {
+	return context.WithValue(cqrstest.TimeoutContext(a.t), "authorization", a.getAdminToken())
+}
+
 func (a *testApp) getUserToken() string {
 	return a.getSignedBearerToken(authorization.JWTClaims{
 		Email:   "john.user@example.com",
@@ -129,6 +161,7 @@ func truncateTables(t *testing.T, db *sql.DB) {
 		"TRUNCATE TABLE vote CASCADE",
 		"TRUNCATE TABLE proposal CASCADE",
 		"TRUNCATE TABLE election CASCADE",
+		"TRUNCATE TABLE event_log",
 	}
 
 	for _, sqlStatement := range sqlStatements {